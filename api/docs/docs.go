@@ -0,0 +1,21 @@
+// Package docs 嵌入生成的OpenAPI文档与接口文档页面
+package docs
+
+import "embed"
+
+//go:generate go run ../../openapi -root ../.. -out openapi.json
+
+// OpenAPI 由api/handler注释生成的OpenAPI 3文档（修改接口注释后需重新执行go generate）
+//
+//go:embed openapi.json
+var OpenAPI []byte
+
+// UI 接口文档页面（Swagger UI，加载同目录下的openapi.json）
+//
+//go:embed index.html
+var UI []byte
+
+// Assets 接口文档页面使用的Swagger UI静态资源（随服务嵌入，不依赖外部CDN）
+//
+//go:embed swagger-ui/swagger-ui.css swagger-ui/swagger-ui-bundle.js
+var Assets embed.FS
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/repository"
+	"customs/service"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// AuditLogHandler 审计日志接口处理器
+type AuditLogHandler struct {
+	svc *service.AuditService
+}
+
+// NewAuditLogHandler 初始化处理器
+func NewAuditLogHandler(svc *service.AuditService) *AuditLogHandler {
+	return &AuditLogHandler{svc: svc}
+}
+
+// ListAuditLogs 分页查询审计日志
+// @Summary 查询审计日志
+// @Description 按操作类型、操作人、任务/资源ID与时间范围过滤，按时间倒序分页返回
+// @Tags 审计日志
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param action query string false "操作类型（如UPLOAD/CONFIRM）"
+// @Param actor query string false "操作人"
+// @Param dict_task_id query string false "字典任务ID"
+// @Param db_resource_id query string false "数据库资源ID"
+// @Param start query string false "起始时间（RFC3339，含）"
+// @Param end query string false "截止时间（RFC3339，不含）"
+// @Param page query int false "页码" default(1)
+// @Param size query int false "每页条数" default(20)
+// @Success 200 {object} response.Response
+// @Router /api/audit_logs [get]
+func (h *AuditLogHandler) ListAuditLogs(c *gin.Context) {
+	// 步骤1：解析过滤条件
+	filter := repository.AuditLogFilter{
+		Action:       c.Query("action"),
+		Actor:        c.Query("actor"),
+		DictTaskID:   c.Query("dict_task_id"),
+		DBResourceID: c.Query("db_resource_id"),
+	}
+	var err error
+	if start := c.Query("start"); start != "" {
+		if filter.Start, err = time.Parse(time.RFC3339, start); err != nil {
+			response.InvalidParam(c, "起始时间格式错误，应为RFC3339")
+			return
+		}
+	}
+	if end := c.Query("end"); end != "" {
+		if filter.End, err = time.Parse(time.RFC3339, end); err != nil {
+			response.InvalidParam(c, "截止时间格式错误，应为RFC3339")
+			return
+		}
+	}
+
+	// 步骤2：解析分页参数
+	page, size, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+
+	// 步骤3：调用Service层方法
+	logs, total, err := h.svc.Query(c.Request.Context(), filter, page, size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"items": logs,
+		"total": total,
+		"page":  page,
+		"size":  size,
+	})
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ColumnMappingHandler 列映射方案接口处理器
+type ColumnMappingHandler struct {
+	svc *service.ColumnMappingService
+}
+
+// NewColumnMappingHandler 初始化处理器
+func NewColumnMappingHandler(svc *service.ColumnMappingService) *ColumnMappingHandler {
+	return &ColumnMappingHandler{svc: svc}
+}
+
+// ColumnMappingRequest 新增或修改列映射方案请求体
+type ColumnMappingRequest struct {
+	Name        string              `json:"name" binding:"required"`    // 方案名称（上传时按名称指定）
+	Description string              `json:"description"`                // 方案说明
+	Aliases     map[string][]string `json:"aliases" binding:"required"` // 模板列名到表头别名，如{"数据表名称（英文）":["表名"]}
+}
+
+// ListMappings 查询列映射方案
+// @Summary 查询列映射方案
+// @Description 未指定方案上传时按名称顺序尝试各方案，使用首个能识别全部标准列的方案
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Success 200 {object} response.Response{data=[]model.ColumnMapping}
+// @Router /api/v2/column_mappings [get]
+func (h *ColumnMappingHandler) ListMappings(c *gin.Context) {
+	mappings, err := h.svc.ListMappings(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, mappings)
+}
+
+// CreateMapping 新增列映射方案
+// @Summary 新增列映射方案
+// @Description aliases的键须为模板列名，别名比较时忽略空白、大小写与全半角括号；同一别名不能对应多个模板列
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param body body handler.ColumnMappingRequest true "方案信息"
+// @Success 201 {object} response.Response{data=model.ColumnMapping}
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 409 {object} response.Response "同名方案已存在"
+// @Router /api/v2/column_mappings [post]
+func (h *ColumnMappingHandler) CreateMapping(c *gin.Context) {
+	var req ColumnMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	mapping, err := h.svc.CreateMapping(c.Request.Context(), service.ColumnMappingInput(req))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/column_mappings/"+mapping.ID)
+	response.SuccessWithStatus(c, http.StatusCreated, mapping)
+}
+
+// GetMapping 查询列映射方案详情
+// @Summary 查询列映射方案详情
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "方案ID"
+// @Success 200 {object} response.Response{data=model.ColumnMapping}
+// @Failure 404 {object} response.Response "方案不存在"
+// @Router /api/v2/column_mappings/{id} [get]
+func (h *ColumnMappingHandler) GetMapping(c *gin.Context) {
+	mapping, err := h.svc.GetMapping(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, mapping)
+}
+
+// UpdateMapping 修改列映射方案
+// @Summary 修改列映射方案
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param id path string true "方案ID"
+// @Param body body handler.ColumnMappingRequest true "方案信息"
+// @Success 200 {object} response.Response{data=model.ColumnMapping}
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 404 {object} response.Response "方案不存在"
+// @Failure 409 {object} response.Response "同名方案已存在"
+// @Router /api/v2/column_mappings/{id} [put]
+func (h *ColumnMappingHandler) UpdateMapping(c *gin.Context) {
+	var req ColumnMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	mapping, err := h.svc.UpdateMapping(c.Request.Context(), c.Param("id"), service.ColumnMappingInput(req))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, mapping)
+}
+
+// DeleteMapping 删除列映射方案
+// @Summary 删除列映射方案
+// @Description 已按该方案上传但尚未解析的任务将解析失败
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "方案ID"
+// @Success 204 "删除成功"
+// @Failure 404 {object} response.Response "方案不存在"
+// @Router /api/v2/column_mappings/{id} [delete]
+func (h *ColumnMappingHandler) DeleteMapping(c *gin.Context) {
+	if err := h.svc.DeleteMapping(c.Request.Context(), c.Param("id")); err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
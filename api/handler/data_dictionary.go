@@ -2,12 +2,12 @@ package handler
 
 import (
 	"customs/api/response"
+	"customs/infrastructure/metrics"
 	"customs/service"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
-	"strings"
 )
 
 // DataDictionaryHandler 数据字典接口处理器
@@ -22,17 +22,19 @@ func NewDataDictionaryHandler(svc *service.DataDictionaryService) *DataDictionar
 
 // DownloadTemplate 下载数据字典Excel模板
 // @Summary 下载数据字典Excel模板
-// @Description 获取系统数据字典导入模板（system-db.xls）
+// @Description 按标准列定义生成数据字典导入模板（system-db.xlsx，含填写说明、示例与单元格校验）
 // @Tags 数据字典
+// @Security BearerAuth
+// @Security BasicAuth
 // @Produce application/octet-stream
 // @Success 200 {file} file "模板文件流"
-// @Failure 400 {object} response.Response "获取模板失败"
+// @Failure 500 {object} response.Response "生成模板失败"
 // @Router /api/data_dictionary/insert [get]
 func (h *DataDictionaryHandler) DownloadTemplate(c *gin.Context) {
 	// 调用Service获取文件流和文件名
 	fileReader, fileName, err := h.svc.DownloadTemplate(c.Request.Context())
 	if err != nil {
-		response.Fail(c, response.ErrCodeFileError, err.Error())
+		response.Error(c, err)
 		return
 	}
 
@@ -40,12 +42,7 @@ func (h *DataDictionaryHandler) DownloadTemplate(c *gin.Context) {
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
 
 	// 根据文件后缀设置MIME类型
-	var contentType string
-	if strings.HasSuffix(fileName, ".xlsx") {
-		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
-	} else {
-		contentType = "application/vnd.ms-excel"
-	}
+	contentType := excelContentType(fileName)
 	c.Header("Content-Type", contentType) // 正确设置Content-Type
 
 	// 直接通过流返回文件内容，使用上面定义的contentType变量
@@ -56,31 +53,40 @@ func (h *DataDictionaryHandler) DownloadTemplate(c *gin.Context) {
 // @Summary 上传Excel文件并触发解析
 // @Description 上传Excel文件，关联资源备注，生产解析异步任务
 // @Tags 数据字典
+// @Security BearerAuth
+// @Security BasicAuth
 // @Accept multipart/form-data
 // @Param resource_comment formData string true "资源备注"
 // @Param file formData file true "Excel文件"
 // @Success 200 {object} response.Response{data=model.DictionaryTask}
+// @Failure 400 {object} response.Response "参数或Excel格式错误（缺失列见details）"
+// @Failure 413 {object} response.Response "文件或请求体超过大小上限"
+// @Failure 429 {object} response.Response "上传过于频繁"
 // @Router /api/data_dictionary/insert [post]
 func (h *DataDictionaryHandler) UploadExcel(c *gin.Context) {
 	// 步骤1：解析表单参数
+	if !parseUploadForm(c) {
+		return
+	}
 	resourceComment := c.PostForm("resource_comment")
 	if resourceComment == "" {
-		response.Fail(c, response.ErrCodeInvalidParam, "资源备注不能为空")
+		response.InvalidParam(c, "资源备注不能为空")
 		return
 	}
 
 	// 步骤2：解析上传的文件
 	file, err := c.FormFile("file")
 	if err != nil {
-		response.Fail(c, response.ErrCodeFileError, "文件上传失败："+err.Error())
+		response.FailWithStatus(c, http.StatusBadRequest, response.ErrCodeFileError, "文件上传失败："+err.Error())
 		return
 	}
+	metrics.UploadSizeBytes.Observe(float64(file.Size))
 
 	// 步骤3：调用Service层方法
-	dictTask, err := h.svc.UploadExcel(c.Request.Context(), resourceComment, file)
+	dictTask, err := h.svc.UploadExcel(c.Request.Context(), resourceComment, "", file)
 	if err != nil {
-		// 根据错误类型返回对应提示（这里简化，直接返回错误信息）
-		response.Fail(c, response.ErrCodeBusinessError, err.Error())
+		// 按错误码返回对应HTTP状态码与提示（原始错误仅记录日志）
+		response.Error(c, err)
 		return
 	}
 
@@ -92,16 +98,20 @@ func (h *DataDictionaryHandler) UploadExcel(c *gin.Context) {
 // @Summary 查询Excel解析结果
 // @Description 根据任务ID查询解析结果（缓存/任务状态）
 // @Tags 数据字典
+// @Security BearerAuth
+// @Security BasicAuth
 // @Param task_id query string true "字典任务ID"
 // @Param page query int false "页码" default(1)
 // @Param size query int false "每页条数" default(10)
 // @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response "任务或解析结果不存在"
+// @Failure 409 {object} response.Response "解析未完成"
 // @Router /api/data_dictionary/insert/data [get]
 func (h *DataDictionaryHandler) GetParseResult(c *gin.Context) {
 	// 步骤1：解析查询参数
 	taskID := c.Query("task_id")
 	if taskID == "" {
-		response.Fail(c, response.ErrCodeInvalidParam, "任务ID不能为空")
+		response.InvalidParam(c, "任务ID不能为空")
 		return
 	}
 
@@ -112,21 +122,21 @@ func (h *DataDictionaryHandler) GetParseResult(c *gin.Context) {
 	// 转换page为int，并校验合法性
 	pageInt, err := strconv.Atoi(pageStr)
 	if err != nil || pageInt < 1 {
-		response.Fail(c, response.ErrCodeInvalidParam, "页码必须为正整数")
+		response.InvalidParam(c, "页码必须为正整数")
 		return
 	}
 
 	// 转换size为int，并校验合法性（限制最大条数，避免查询过多数据）
 	sizeInt, err := strconv.Atoi(sizeStr)
 	if err != nil || sizeInt < 1 || sizeInt > 100 {
-		response.Fail(c, response.ErrCodeInvalidParam, "每页条数必须为1-100的整数")
+		response.InvalidParam(c, "每页条数必须为1-100的整数")
 		return
 	}
 
 	// 步骤3：调用Service层方法
 	result, err := h.svc.GetParseResult(c.Request.Context(), taskID, pageInt, sizeInt)
 	if err != nil {
-		response.Fail(c, response.ErrCodeBusinessError, err.Error())
+		response.Error(c, err)
 		return
 	}
 
@@ -135,26 +145,34 @@ func (h *DataDictionaryHandler) GetParseResult(c *gin.Context) {
 }
 
 // ConfirmInsert 确认入库接口
-// @Summary 确认/取消Excel解析结果入库
-// @Description 根据任务ID确认入库，生产入库异步任务
+// @Summary 审批（确认/驳回）Excel解析结果入库
+// @Description 根据任务ID审批入库，确认时生产入库异步任务；审批人需具备reviewer角色且不能是上传人
 // @Tags 数据字典
-// @Param task_id query string true "字典任务ID"
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "字典任务ID"
 // @Param confirm query bool true "是否确认入库"
+// @Param reason query string false "驳回原因"
 // @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response "审批人为上传人"
+// @Failure 429 {object} response.Response "操作过于频繁"
+// @Failure 404 {object} response.Response "任务不存在"
+// @Failure 409 {object} response.Response "解析未完成或已审批"
 // @Router /api/data_dictionary/insert/{id} [post]
 func (h *DataDictionaryHandler) ConfirmInsert(c *gin.Context) {
 	// 步骤1：解析参数
 	taskID := c.Param("id")
 	if taskID == "" {
-		response.Fail(c, response.ErrCodeInvalidParam, "任务ID不能为空")
+		response.InvalidParam(c, "任务ID不能为空")
 		return
 	}
 	confirm := c.Query("confirm") == "true" // 转换为bool
+	reason := c.Query("reason")
 
 	// 步骤2：调用Service层方法
-	err := h.svc.ConfirmInsert(c.Request.Context(), taskID, confirm)
+	err := h.svc.ConfirmInsert(c.Request.Context(), taskID, confirm, reason)
 	if err != nil {
-		response.Fail(c, response.ErrCodeBusinessError, err.Error())
+		response.Error(c, err)
 		return
 	}
 
@@ -166,13 +184,16 @@ func (h *DataDictionaryHandler) ConfirmInsert(c *gin.Context) {
 // @Summary 查询所有资源备注
 // @Description 获取去重的资源备注列表
 // @Tags 数据字典
+// @Security BearerAuth
+// @Security BasicAuth
 // @Success 200 {object} response.Response{data=[]string}
 // @Router /api/data_dictionary/resource_comment [get]
+// @Router /api/v2/resource_comments [get]
 func (h *DataDictionaryHandler) GetResourceComments(c *gin.Context) {
 	// 步骤1：调用Service层方法
 	comments, err := h.svc.GetResourceComments(c.Request.Context())
 	if err != nil {
-		response.Fail(c, response.ErrCodeDBError, "查询资源备注失败："+err.Error())
+		response.Error(c, err)
 		return
 	}
 
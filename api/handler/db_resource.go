@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"bytes"
+	"customs/api/response"
+	"customs/repository"
+	"customs/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+)
+
+// DBResourceHandler 数据库资源与已入库数据字典导出接口处理器
+type DBResourceHandler struct {
+	svc     *service.ExportService
+	dictSvc *service.DataDictionaryService
+}
+
+// NewDBResourceHandler 初始化处理器
+func NewDBResourceHandler(svc *service.ExportService, dictSvc *service.DataDictionaryService) *DBResourceHandler {
+	return &DBResourceHandler{svc: svc, dictSvc: dictSvc}
+}
+
+// CreateDBResourceRequest 登记数据库资源请求体
+type CreateDBResourceRequest struct {
+	ResourceComment string `json:"resource_comment" binding:"required"` // 资源备注
+	SystemName      string `json:"system_name" binding:"required"`      // 系统名
+	DBName          string `json:"db_name" binding:"required"`          // 数据库名
+	ResourceType    string `json:"resource_type"`                       // 资源类型（MySQL/SQLite等）
+	ConnectionDSN   string `json:"connection_dsn"`                      // 内省连接串（口令须以${INTROSPECT_XXX}引用环境变量，SQLite文件须位于INTROSPECT_SQLITE_DIR下；不会在响应中返回）
+}
+
+// UpdateConnectionRequest 修改内省连接请求体
+type UpdateConnectionRequest struct {
+	ResourceType  string `json:"resource_type" binding:"required"` // 资源类型（MySQL/SQLite）
+	ConnectionDSN string `json:"connection_dsn"`                   // 内省连接串（为空表示清除；限制同登记接口）
+}
+
+// CreateDBResource 登记数据库资源
+// @Summary 登记数据库资源
+// @Description 用于尚无工作簿的系统：登记后配置内省连接，即可从数据库表结构生成字典草稿
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param body body handler.CreateDBResourceRequest true "资源信息"
+// @Success 201 {object} response.Response{data=model.DBResource}
+// @Failure 400 {object} response.Response "参数错误或资源类型不支持内省"
+// @Failure 409 {object} response.Response "同一资源备注下已登记同名数据库"
+// @Router /api/v2/db_resources [post]
+func (h *DBResourceHandler) CreateDBResource(c *gin.Context) {
+	var req CreateDBResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	resource, err := h.dictSvc.CreateDBResource(c.Request.Context(), service.DBResourceInput{
+		ResourceComment: req.ResourceComment,
+		SystemName:      req.SystemName,
+		DBName:          req.DBName,
+		ResourceType:    req.ResourceType,
+		ConnectionDSN:   req.ConnectionDSN,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/db_resources/"+resource.ID)
+	response.SuccessWithStatus(c, http.StatusCreated, resource)
+}
+
+// UpdateConnection 修改内省连接
+// @Summary 修改数据库资源的类型与内省连接
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param id path string true "数据库资源ID"
+// @Param body body handler.UpdateConnectionRequest true "连接信息"
+// @Success 200 {object} response.Response{data=model.DBResource}
+// @Failure 400 {object} response.Response "资源类型不支持内省"
+// @Failure 404 {object} response.Response "资源不存在"
+// @Router /api/v2/db_resources/{id}/connection [put]
+func (h *DBResourceHandler) UpdateConnection(c *gin.Context) {
+	var req UpdateConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	resource, err := h.dictSvc.UpdateDBResourceConnection(c.Request.Context(), c.Param("id"), req.ResourceType, req.ConnectionDSN)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, resource)
+}
+
+// Introspect 从数据库表结构生成字典草稿
+// @Summary 从数据库表结构生成字典草稿
+// @Description 读取information_schema（SQLite为表定义）中的表、字段与注释，按模板生成工作簿并创建解析任务；表/字段注释作为中文名称，解析完成后按普通任务审批入库
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "数据库资源ID"
+// @Success 201 {object} response.Response{data=model.DictionaryTask}
+// @Failure 404 {object} response.Response "资源不存在或数据库中没有表"
+// @Failure 409 {object} response.Response "资源未配置内省连接"
+// @Failure 502 {object} response.Response "连接或读取数据库失败"
+// @Router /api/v2/db_resources/{id}/introspect [post]
+func (h *DBResourceHandler) Introspect(c *gin.Context) {
+	dictTask, err := h.dictSvc.IntrospectDBResource(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/tasks/"+dictTask.ID)
+	response.SuccessWithStatus(c, http.StatusCreated, dictTask)
+}
+
+// ListDBResources 分页查询数据库资源
+// @Summary 查询已登记的数据库资源
+// @Description 入库成功后按 资源备注+库名 登记，按资源备注、库名排序
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param resource_comment query string false "资源备注"
+// @Param db query string false "数据库名"
+// @Param page query int false "页码" default(1)
+// @Param size query int false "每页条数" default(20)
+// @Success 200 {object} response.Response{data=[]model.DBResource}
+// @Router /api/v2/db_resources [get]
+func (h *DBResourceHandler) ListDBResources(c *gin.Context) {
+	filter := repository.DBResourceFilter{
+		ResourceComment: c.Query("resource_comment"),
+		DBName:          c.Query("db"),
+	}
+	page, size, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+
+	resources, total, err := h.svc.ListDBResources(c.Request.Context(), filter, page, size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"items": resources,
+		"total": total,
+		"page":  page,
+		"size":  size,
+	})
+}
+
+// ExportExcel 按资源导出数据字典
+// @Summary 导出数据库资源的数据字典（Excel模板格式）
+// @Description 生成包含5个标准列的工作簿，文件名为"系统名-dbname.xlsx"，修改后可直接重新上传
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path string true "数据库资源ID"
+// @Success 200 {file} file "工作簿文件流"
+// @Failure 404 {object} response.Response "资源不存在或尚无已入库的字段"
+// @Router /api/v2/db_resources/{id}/excel [get]
+func (h *DBResourceHandler) ExportExcel(c *gin.Context) {
+	h.exportExcel(c, repository.DictionaryFieldFilter{DBResourceID: c.Param("id")})
+}
+
+// ExportExcelBySystem 按系统名与库名导出数据字典
+// @Summary 按系统名与库名导出数据字典（Excel模板格式）
+// @Description 同一系统与库在多个资源备注下入库时需指定resource_comment
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param system query string true "系统名"
+// @Param db query string true "数据库名"
+// @Param resource_comment query string false "资源备注"
+// @Success 200 {file} file "工作簿文件流"
+// @Failure 400 {object} response.Response "参数缺失或匹配到多个资源"
+// @Failure 404 {object} response.Response "尚无已入库的字段"
+// @Router /api/v2/dictionaries/excel [get]
+func (h *DBResourceHandler) ExportExcelBySystem(c *gin.Context) {
+	h.exportExcel(c, repository.DictionaryFieldFilter{
+		ResourceComment: c.Query("resource_comment"),
+		SystemName:      c.Query("system"),
+		DBName:          c.Query("db"),
+	})
+}
+
+// DownloadDocs 下载数据库资源的数据字典文档
+// @Summary 下载数据字典文档（Markdown与HTML）
+// @Description ZIP内含按表组织的Markdown与自包含HTML文档（先列目录，再逐表列出字段的中文名与说明）；每次入库成功后自动重新生成
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Produce application/zip
+// @Param id path string true "数据库资源ID"
+// @Success 200 {file} file "文档ZIP文件流"
+// @Failure 404 {object} response.Response "资源不存在或尚无已入库的字段"
+// @Router /api/v2/db_resources/{id}/docs [get]
+func (h *DBResourceHandler) DownloadDocs(c *gin.Context) {
+	content, fileName, err := h.svc.DownloadDocs(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Content-Disposition", attachment(fileName))
+	c.Data(http.StatusOK, "application/zip", content)
+}
+
+// ExportDDL 按数据字典生成建表语句
+// @Summary 生成建表语句（DDL）
+// @Description 表与字段的COMMENT取自中文名称与说明；数据字典不含字段类型，字段使用占位类型，执行前需按实际类型调整
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Produce text/plain
+// @Param id path string true "数据库资源ID"
+// @Param dialect query string false "SQL方言（mysql/postgresql/oracle），默认按资源类型选择，资源类型为空或没有对应方言时为mysql"
+// @Success 200 {file} file "SQL文件流"
+// @Failure 400 {object} response.Response "指定的方言不支持"
+// @Failure 404 {object} response.Response "资源不存在或尚无已入库的字段"
+// @Router /api/v2/db_resources/{id}/ddl [get]
+func (h *DBResourceHandler) ExportDDL(c *gin.Context) {
+	content, fileName, err := h.svc.ExportDDL(c.Request.Context(), c.Param("id"), c.Query("dialect"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Content-Disposition", attachment(fileName))
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", content)
+}
+
+// ExportJSONSchema 按数据字典生成JSON Schema
+// @Summary 生成JSON Schema
+// @Description 每张表一个JSON Schema文档（draft 2020-12），打包为ZIP；属性的title/description取自字段中文名称与说明
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Produce application/zip
+// @Param id path string true "数据库资源ID"
+// @Success 200 {file} file "JSON Schema ZIP文件流"
+// @Failure 404 {object} response.Response "资源不存在或尚无已入库的字段"
+// @Router /api/v2/db_resources/{id}/json_schema [get]
+func (h *DBResourceHandler) ExportJSONSchema(c *gin.Context) {
+	content, fileName, err := h.svc.ExportJSONSchema(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Content-Disposition", attachment(fileName))
+	c.Data(http.StatusOK, "application/zip", content)
+}
+
+// exportExcel 导出工作簿并写入下载响应
+func (h *DBResourceHandler) exportExcel(c *gin.Context, filter repository.DictionaryFieldFilter) {
+	content, fileName, err := h.svc.ExportExcel(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Content-Disposition", attachment(fileName))
+	c.DataFromReader(http.StatusOK, int64(len(content)), excelContentType(fileName), bytes.NewReader(content), nil)
+}
+
+// attachment 生成下载响应的Content-Disposition（filename*按RFC 5987编码，保证中文文件名不乱码）
+func attachment(fileName string) string {
+	return fmt.Sprintf("attachment; filename=%q; filename*=UTF-8''%s", fileName, url.PathEscape(fileName))
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/model"
+	"customs/repository"
+	"customs/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// DictionaryBatchHandler v2版数据字典批次接口处理器（一次上传多个Excel或ZIP，整批审批）
+type DictionaryBatchHandler struct {
+	svc *service.DataDictionaryService
+}
+
+// NewDictionaryBatchHandler 初始化处理器
+func NewDictionaryBatchHandler(svc *service.DataDictionaryService) *DictionaryBatchHandler {
+	return &DictionaryBatchHandler{svc: svc}
+}
+
+// ListBatches 查询批次列表
+// @Summary 分页查询数据字典批次
+// @Description 按上传人、资源备注、审批结论过滤，按创建时间倒序；状态由子任务汇总
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param uploader query string false "上传人"
+// @Param resource_comment query string false "资源备注"
+// @Param decision query string false "审批结论（APPROVED/REJECTED/PENDING）"
+// @Param page query int false "页码" default(1)
+// @Param size query int false "每页条数" default(20)
+// @Success 200 {object} response.Response{data=[]service.BatchDetail}
+// @Router /api/v2/batches [get]
+func (h *DictionaryBatchHandler) ListBatches(c *gin.Context) {
+	filter := repository.DictionaryBatchFilter{
+		Uploader:        c.Query("uploader"),
+		ResourceComment: c.Query("resource_comment"),
+	}
+	switch decision := c.Query("decision"); decision {
+	case "":
+	case "PENDING":
+		filter.Pending = true
+	case model.DecisionApproved, model.DecisionRejected:
+		filter.Decision = decision
+	default:
+		response.InvalidParam(c, "审批结论必须为APPROVED、REJECTED或PENDING")
+		return
+	}
+	page, size, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+
+	batches, total, err := h.svc.ListBatches(c.Request.Context(), filter, page, size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"items": batches,
+		"total": total,
+		"page":  page,
+		"size":  size,
+	})
+}
+
+// CreateBatch 批量上传Excel创建批次
+// @Summary 批量上传Excel或ZIP并创建批次
+// @Description files可重复提交多个Excel或ZIP压缩包；任一文件校验未通过时整批拒绝，逐个文件的错误见details
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept multipart/form-data
+// @Param resource_comment formData string true "资源备注"
+// @Param column_mapping formData string false "列映射方案名称（为空时逐个文件按表头自动识别）"
+// @Param files formData file true "Excel文件或ZIP压缩包（可多个）"
+// @Success 201 {object} response.Response{data=service.BatchDetail}
+// @Failure 400 {object} response.Response{details=[]service.BatchFileError} "参数错误或文件未全部通过校验"
+// @Failure 404 {object} response.Response "列映射方案不存在"
+// @Failure 413 {object} response.Response "请求体超过大小上限"
+// @Failure 429 {object} response.Response "上传过于频繁"
+// @Router /api/v2/batches [post]
+func (h *DictionaryBatchHandler) CreateBatch(c *gin.Context) {
+	if !parseUploadForm(c) {
+		return
+	}
+	resourceComment := c.PostForm("resource_comment")
+	if resourceComment == "" {
+		response.InvalidParam(c, "资源备注不能为空")
+		return
+	}
+	files := c.Request.MultipartForm.File["files"]
+	if len(files) == 0 {
+		response.InvalidParam(c, "请至少上传一个Excel或ZIP文件")
+		return
+	}
+
+	batch, err := h.svc.UploadBatch(c.Request.Context(), resourceComment, c.PostForm("column_mapping"), files)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/batches/"+batch.Batch.ID)
+	response.SuccessWithStatus(c, http.StatusCreated, batch)
+}
+
+// GetBatch 查询批次详情
+// @Summary 查询数据字典批次详情（含子任务）
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "批次ID"
+// @Success 200 {object} response.Response{data=service.BatchDetail}
+// @Failure 404 {object} response.Response "批次不存在"
+// @Router /api/v2/batches/{id} [get]
+func (h *DictionaryBatchHandler) GetBatch(c *gin.Context) {
+	batch, err := h.svc.GetBatch(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, batch)
+}
+
+// ConfirmBatch 批次整体确认入库
+// @Summary 批次整体审批通过并入库
+// @Description 全部子任务解析成功后才能审批；审批人需具备reviewer角色且不能是上传人
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "批次ID"
+// @Success 202 {object} response.Response
+// @Failure 403 {object} response.Response "审批人为上传人"
+// @Failure 404 {object} response.Response "批次不存在"
+// @Failure 409 {object} response.Response "子任务未全部解析成功、已审批或存在error级命名规范违规"
+// @Failure 429 {object} response.Response "操作过于频繁"
+// @Router /api/v2/batches/{id}/confirm [post]
+func (h *DictionaryBatchHandler) ConfirmBatch(c *gin.Context) {
+	if err := h.svc.DecideBatch(c.Request.Context(), c.Param("id"), true, ""); err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.SuccessWithStatus(c, http.StatusAccepted, gin.H{"msg": "已确认，入库任务已提交"})
+}
+
+// RejectBatch 批次整体驳回
+// @Summary 批次整体审批驳回
+// @Description 审批人需具备reviewer角色且不能是上传人
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param id path string true "批次ID"
+// @Param body body handler.RejectTaskRequest false "驳回原因"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response "审批人为上传人"
+// @Failure 404 {object} response.Response "批次不存在"
+// @Failure 409 {object} response.Response "子任务未全部解析成功或已审批"
+// @Failure 429 {object} response.Response "操作过于频繁"
+// @Router /api/v2/batches/{id}/reject [post]
+func (h *DictionaryBatchHandler) RejectBatch(c *gin.Context) {
+	var req RejectTaskRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.InvalidParam(c, "请求体格式错误："+err.Error())
+			return
+		}
+	}
+	if err := h.svc.DecideBatch(c.Request.Context(), c.Param("id"), false, req.Reason); err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{"msg": "已驳回"})
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/repository"
+	"customs/service"
+	"github.com/gin-gonic/gin"
+)
+
+// DictionarySearchHandler 数据字典全文检索接口处理器
+type DictionarySearchHandler struct {
+	svc *service.SearchService
+}
+
+// NewDictionarySearchHandler 初始化处理器
+func NewDictionarySearchHandler(svc *service.SearchService) *DictionarySearchHandler {
+	return &DictionarySearchHandler{svc: svc}
+}
+
+// Search 全文检索已入库的数据字典
+// @Summary 检索数据字典
+// @Description 在表名、字段名（中英文）与字段说明中全文检索（中文按二元组分词），按相关度倒序分页返回；highlights为命中列的片段，已HTML转义，命中处以<em>标记
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param q query string true "检索词（至少2个字符，空格分隔多个词）"
+// @Param resource_comment query string false "资源备注"
+// @Param system query string false "系统名"
+// @Param db query string false "数据库名"
+// @Param page query int false "页码" default(1)
+// @Param size query int false "每页条数" default(20)
+// @Success 200 {object} response.Response{data=[]service.SearchHit}
+// @Failure 400 {object} response.Response "检索词过短"
+// @Router /api/v2/search [get]
+func (h *DictionarySearchHandler) Search(c *gin.Context) {
+	filter := repository.DictionaryFieldFilter{
+		ResourceComment: c.Query("resource_comment"),
+		SystemName:      c.Query("system"),
+		DBName:          c.Query("db"),
+	}
+	page, size, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+
+	hits, total, err := h.svc.Search(c.Request.Context(), c.Query("q"), filter, page, size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"items": hits,
+		"total": total,
+		"page":  page,
+		"size":  size,
+	})
+}
@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/infrastructure/metrics"
+	"customs/model"
+	"customs/repository"
+	"customs/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"path/filepath"
+)
+
+// DictionaryTaskHandler v2版数据字典任务接口处理器（面向资源的REST风格）
+type DictionaryTaskHandler struct {
+	svc *service.DataDictionaryService
+}
+
+// NewDictionaryTaskHandler 初始化处理器
+func NewDictionaryTaskHandler(svc *service.DataDictionaryService) *DictionaryTaskHandler {
+	return &DictionaryTaskHandler{svc: svc}
+}
+
+// RejectTaskRequest 驳回任务请求体
+type RejectTaskRequest struct {
+	Reason string `json:"reason"` // 驳回原因
+}
+
+// GetTemplate 下载数据字典Excel模板
+// @Summary 下载数据字典Excel模板
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Produce application/octet-stream
+// @Success 200 {file} file "模板文件流"
+// @Failure 500 {object} response.Response "生成模板失败"
+// @Router /api/v2/template [get]
+func (h *DictionaryTaskHandler) GetTemplate(c *gin.Context) {
+	fileReader, fileName, err := h.svc.DownloadTemplate(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.DataFromReader(http.StatusOK, -1, excelContentType(fileName), fileReader, nil)
+}
+
+// ListTasks 查询任务列表
+// @Summary 分页查询数据字典任务
+// @Description 按上传人、资源备注、审批结论、所属批次过滤，按创建时间倒序
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param uploader query string false "上传人"
+// @Param resource_comment query string false "资源备注"
+// @Param decision query string false "审批结论（APPROVED/REJECTED/PENDING）"
+// @Param batch_id query string false "所属批次ID"
+// @Param page query int false "页码" default(1)
+// @Param size query int false "每页条数" default(20)
+// @Success 200 {object} response.Response{data=[]model.DictionaryTask}
+// @Router /api/v2/tasks [get]
+func (h *DictionaryTaskHandler) ListTasks(c *gin.Context) {
+	filter := repository.DictionaryTaskFilter{
+		Uploader:        c.Query("uploader"),
+		ResourceComment: c.Query("resource_comment"),
+		BatchID:         c.Query("batch_id"),
+	}
+	switch decision := c.Query("decision"); decision {
+	case "":
+	case "PENDING":
+		filter.Pending = true
+	case model.DecisionApproved, model.DecisionRejected:
+		filter.Decision = decision
+	default:
+		response.InvalidParam(c, "审批结论必须为APPROVED、REJECTED或PENDING")
+		return
+	}
+	page, size, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+
+	tasks, total, err := h.svc.ListTasks(c.Request.Context(), filter, page, size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"items": tasks,
+		"total": total,
+		"page":  page,
+		"size":  size,
+	})
+}
+
+// CreateTask 上传Excel创建任务
+// @Summary 上传Excel文件并创建解析任务
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept multipart/form-data
+// @Param resource_comment formData string true "资源备注"
+// @Param column_mapping formData string false "列映射方案名称（为空时按表头自动识别）"
+// @Param file formData file true "Excel文件"
+// @Success 201 {object} response.Response{data=model.DictionaryTask}
+// @Failure 400 {object} response.Response "参数或Excel格式错误（缺失列见details）"
+// @Failure 404 {object} response.Response "列映射方案不存在"
+// @Failure 413 {object} response.Response "文件或请求体超过大小上限"
+// @Failure 429 {object} response.Response "上传过于频繁"
+// @Router /api/v2/tasks [post]
+func (h *DictionaryTaskHandler) CreateTask(c *gin.Context) {
+	if !parseUploadForm(c) {
+		return
+	}
+	resourceComment := c.PostForm("resource_comment")
+	if resourceComment == "" {
+		response.InvalidParam(c, "资源备注不能为空")
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.FailWithStatus(c, http.StatusBadRequest, response.ErrCodeFileError, "文件上传失败："+err.Error())
+		return
+	}
+	metrics.UploadSizeBytes.Observe(float64(file.Size))
+
+	dictTask, err := h.svc.UploadExcel(c.Request.Context(), resourceComment, c.PostForm("column_mapping"), file)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/tasks/"+dictTask.ID)
+	response.SuccessWithStatus(c, http.StatusCreated, dictTask)
+}
+
+// GetTask 查询任务详情
+// @Summary 查询数据字典任务详情
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "字典任务ID"
+// @Success 200 {object} response.Response{data=model.DictionaryTask}
+// @Failure 404 {object} response.Response "任务不存在"
+// @Router /api/v2/tasks/{id} [get]
+func (h *DictionaryTaskHandler) GetTask(c *gin.Context) {
+	dictTask, err := h.svc.GetTask(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, dictTask)
+}
+
+// GetTaskResult 查询解析结果
+// @Summary 查询Excel解析结果
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "字典任务ID"
+// @Param page query int false "页码" default(1)
+// @Param size query int false "每页条数" default(10)
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response "任务或解析结果不存在"
+// @Failure 409 {object} response.Response "解析未完成"
+// @Router /api/v2/tasks/{id}/result [get]
+func (h *DictionaryTaskHandler) GetTaskResult(c *gin.Context) {
+	page, size, ok := parsePage(c, 10)
+	if !ok {
+		return
+	}
+	result, err := h.svc.GetParseResult(c.Request.Context(), c.Param("id"), page, size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
+// GetNamingReport 查询命名规范检查结果
+// @Summary 查询Excel解析时的命名规范检查结果
+// @Description 逐行列出违规（sheet、行号、列、规则、级别）；开启NAMING_BLOCK_ON_ERROR时，存在error级违规的任务只能驳回
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "字典任务ID"
+// @Success 200 {object} response.Response{data=service.NamingReport}
+// @Failure 404 {object} response.Response "任务不存在"
+// @Failure 409 {object} response.Response "解析未完成"
+// @Router /api/v2/tasks/{id}/naming [get]
+func (h *DictionaryTaskHandler) GetNamingReport(c *gin.Context) {
+	report, err := h.svc.GetNamingReport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, report)
+}
+
+// ConfirmTask 确认入库
+// @Summary 审批通过并入库
+// @Description 生产入库异步任务；审批人需具备reviewer角色且不能是上传人
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "字典任务ID"
+// @Success 202 {object} response.Response
+// @Failure 403 {object} response.Response "审批人为上传人"
+// @Failure 429 {object} response.Response "操作过于频繁"
+// @Failure 404 {object} response.Response "任务不存在"
+// @Failure 409 {object} response.Response "解析未完成、已审批、属于批次或存在error级命名规范违规"
+// @Router /api/v2/tasks/{id}/confirm [post]
+func (h *DictionaryTaskHandler) ConfirmTask(c *gin.Context) {
+	if err := h.svc.ConfirmInsert(c.Request.Context(), c.Param("id"), true, ""); err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.SuccessWithStatus(c, http.StatusAccepted, gin.H{"msg": "已确认，入库任务已提交"})
+}
+
+// RejectTask 驳回入库
+// @Summary 审批驳回
+// @Description 审批人需具备reviewer角色且不能是上传人
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param id path string true "字典任务ID"
+// @Param body body handler.RejectTaskRequest false "驳回原因"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response "审批人为上传人"
+// @Failure 429 {object} response.Response "操作过于频繁"
+// @Failure 404 {object} response.Response "任务不存在"
+// @Failure 409 {object} response.Response "解析未完成、已审批或属于批次"
+// @Router /api/v2/tasks/{id}/reject [post]
+func (h *DictionaryTaskHandler) RejectTask(c *gin.Context) {
+	var req RejectTaskRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.InvalidParam(c, "请求体格式错误："+err.Error())
+			return
+		}
+	}
+	if err := h.svc.ConfirmInsert(c.Request.Context(), c.Param("id"), false, req.Reason); err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{"msg": "已驳回"})
+}
+
+// DeleteTask 删除任务
+// @Summary 删除数据字典任务
+// @Description 仅上传人或管理员可删除，已确认入库的任务与批次子任务不能删除
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "字典任务ID"
+// @Success 204 "删除成功"
+// @Failure 403 {object} response.Response "无权删除"
+// @Failure 404 {object} response.Response "任务不存在"
+// @Failure 409 {object} response.Response "任务已确认入库或属于批次"
+// @Router /api/v2/tasks/{id} [delete]
+func (h *DictionaryTaskHandler) DeleteTask(c *gin.Context) {
+	if err := h.svc.DeleteTask(c.Request.Context(), c.Param("id")); err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// excelContentType 根据文件后缀返回Excel的MIME类型
+func excelContentType(fileName string) string {
+	if filepath.Ext(fileName) == ".xlsx" {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/vnd.ms-excel"
+}
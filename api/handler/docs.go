@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"customs/api/docs"
+	"github.com/gin-gonic/gin"
+	"io/fs"
+	"net/http"
+	"path"
+)
+
+// DocsHandler 接口文档处理器
+type DocsHandler struct{}
+
+// NewDocsHandler 初始化处理器
+func NewDocsHandler() *DocsHandler {
+	return &DocsHandler{}
+}
+
+// OpenAPISpec 返回OpenAPI文档
+func (h *DocsHandler) OpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", docs.OpenAPI)
+}
+
+// UI 返回接口文档页面
+func (h *DocsHandler) UI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docs.UI)
+}
+
+// Asset 返回接口文档页面的静态资源（只提供嵌入的文件，不列目录）
+func (h *DocsHandler) Asset(c *gin.Context) {
+	name := path.Join("swagger-ui", c.Param("filepath"))
+	if info, err := fs.Stat(docs.Assets, name); err != nil || info.IsDir() {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.FileFromFS(name, http.FS(docs.Assets))
+}
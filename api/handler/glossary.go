@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GlossaryHandler 业务术语接口处理器
+type GlossaryHandler struct {
+	svc *service.GlossaryService
+}
+
+// NewGlossaryHandler 初始化处理器
+func NewGlossaryHandler(svc *service.GlossaryService) *GlossaryHandler {
+	return &GlossaryHandler{svc: svc}
+}
+
+// TermRequest 新增或修改术语请求体
+type TermRequest struct {
+	Name         string `json:"name" binding:"required"` // 术语名称（中文，解析时与字段中文名匹配）
+	Abbreviation string `json:"abbreviation"`            // 标准英文缩写
+	Definition   string `json:"definition"`              // 定义
+}
+
+// TermLinkRequest 关联字段与术语请求体
+type TermLinkRequest struct {
+	TableNameEN string `json:"table_name_en" binding:"required"` // 数据表名称（英文）
+	FieldNameEN string `json:"field_name_en" binding:"required"` // 字段名称（英文）
+	TermID      string `json:"term_id" binding:"required"`       // 术语ID
+}
+
+// ListTerms 分页查询业务术语
+// @Summary 查询业务术语
+// @Tags 业务术语
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param q query string false "按名称或英文缩写模糊匹配"
+// @Param page query int false "页码" default(1)
+// @Param size query int false "每页条数" default(20)
+// @Success 200 {object} response.Response{data=[]model.GlossaryTerm}
+// @Router /api/v2/glossary [get]
+func (h *GlossaryHandler) ListTerms(c *gin.Context) {
+	page, size, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+
+	terms, total, err := h.svc.ListTerms(c.Request.Context(), c.Query("q"), page, size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"items": terms,
+		"total": total,
+		"page":  page,
+		"size":  size,
+	})
+}
+
+// CreateTerm 新增业务术语
+// @Summary 新增业务术语
+// @Description 此后解析的Excel中，字段中文名与术语名称一致（忽略空白）的字段在入库时自动关联该术语
+// @Tags 业务术语
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param body body handler.TermRequest true "术语信息"
+// @Success 201 {object} response.Response{data=model.GlossaryTerm}
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 409 {object} response.Response "同名术语已存在"
+// @Router /api/v2/glossary [post]
+func (h *GlossaryHandler) CreateTerm(c *gin.Context) {
+	var req TermRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	term, err := h.svc.CreateTerm(c.Request.Context(), service.TermInput(req))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/glossary/"+term.ID)
+	response.SuccessWithStatus(c, http.StatusCreated, term)
+}
+
+// GetTerm 查询业务术语及关联的字段
+// @Summary 查询业务术语及关联的字段
+// @Description 列出各系统中关联该术语的字段及其中文名与说明，便于对照同一业务概念的描述是否一致
+// @Tags 业务术语
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "术语ID"
+// @Success 200 {object} response.Response{data=service.TermFields}
+// @Failure 404 {object} response.Response "术语不存在"
+// @Router /api/v2/glossary/{id} [get]
+func (h *GlossaryHandler) GetTerm(c *gin.Context) {
+	result, err := h.svc.GetTermFields(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
+// UpdateTerm 修改业务术语
+// @Summary 修改业务术语
+// @Tags 业务术语
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param id path string true "术语ID"
+// @Param body body handler.TermRequest true "术语信息"
+// @Success 200 {object} response.Response{data=model.GlossaryTerm}
+// @Failure 404 {object} response.Response "术语不存在"
+// @Failure 409 {object} response.Response "同名术语已存在"
+// @Router /api/v2/glossary/{id} [put]
+func (h *GlossaryHandler) UpdateTerm(c *gin.Context) {
+	var req TermRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	term, err := h.svc.UpdateTerm(c.Request.Context(), c.Param("id"), service.TermInput(req))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, term)
+}
+
+// DeleteTerm 删除业务术语
+// @Summary 删除业务术语
+// @Description 同时删除该术语的全部字段关联
+// @Tags 业务术语
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "术语ID"
+// @Success 204 "删除成功"
+// @Failure 404 {object} response.Response "术语不存在"
+// @Router /api/v2/glossary/{id} [delete]
+func (h *GlossaryHandler) DeleteTerm(c *gin.Context) {
+	if err := h.svc.DeleteTerm(c.Request.Context(), c.Param("id")); err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListResourceLinks 查询数据库资源下字段的术语关联
+// @Summary 查询数据库资源下字段的术语关联
+// @Description source为MANUAL（手动关联，重新入库时保留）或AUTO（解析时按字段中文名匹配，重新入库时按最新匹配结果替换）
+// @Tags 业务术语
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "数据库资源ID"
+// @Success 200 {object} response.Response{data=[]repository.TermLinkDetail}
+// @Failure 404 {object} response.Response "资源不存在"
+// @Router /api/v2/db_resources/{id}/term_links [get]
+func (h *GlossaryHandler) ListResourceLinks(c *gin.Context) {
+	links, err := h.svc.ListResourceLinks(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, links)
+}
+
+// LinkField 手动关联字段与术语
+// @Summary 手动关联字段与业务术语
+// @Description 字段须存在于已入库的数据字典中；字段已有关联（含自动关联）时替换
+// @Tags 业务术语
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param id path string true "数据库资源ID"
+// @Param body body handler.TermLinkRequest true "字段与术语"
+// @Success 200 {object} response.Response{data=model.TermLink}
+// @Failure 404 {object} response.Response "资源、术语或字段不存在"
+// @Router /api/v2/db_resources/{id}/term_links [put]
+func (h *GlossaryHandler) LinkField(c *gin.Context) {
+	var req TermLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	link, err := h.svc.LinkField(c.Request.Context(), c.Param("id"), req.TableNameEN, req.FieldNameEN, req.TermID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, link)
+}
+
+// UnlinkField 取消字段的术语关联
+// @Summary 取消字段与业务术语的关联
+// @Description 取消自动关联后，重新入库时若仍能匹配会再次自动关联
+// @Tags 业务术语
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "数据库资源ID"
+// @Param table_name_en query string true "数据表名称（英文）"
+// @Param field_name_en query string true "字段名称（英文）"
+// @Success 204 "取消成功"
+// @Failure 404 {object} response.Response "资源不存在或字段未关联术语"
+// @Router /api/v2/db_resources/{id}/term_links [delete]
+func (h *GlossaryHandler) UnlinkField(c *gin.Context) {
+	tableNameEN, fieldNameEN := c.Query("table_name_en"), c.Query("field_name_en")
+	if tableNameEN == "" || fieldNameEN == "" {
+		response.InvalidParam(c, "table_name_en与field_name_en不能为空")
+		return
+	}
+	if err := h.svc.UnlinkField(c.Request.Context(), c.Param("id"), tableNameEN, fieldNameEN); err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
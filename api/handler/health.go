@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"customs/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// HealthHandler 健康检查接口处理器
+type HealthHandler struct {
+	svc *service.HealthService
+}
+
+// NewHealthHandler 初始化处理器
+func NewHealthHandler(svc *service.HealthService) *HealthHandler {
+	return &HealthHandler{svc: svc}
+}
+
+// Legacy 兼容旧探针（保持原有响应{"status":"ok"}，不检查依赖）
+// @Summary 健康检查（旧接口）
+// @Tags 健康检查
+// @Success 200 {object} map[string]string
+// @Router /api/health [get]
+func (h *HealthHandler) Legacy(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Liveness 存活探针（进程可响应即视为存活，不检查依赖）
+// @Summary 存活探针
+// @Tags 健康检查
+// @Success 200 {object} map[string]string
+// @Router /api/health/live [get]
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": service.HealthStatusUp})
+}
+
+// Readiness 就绪探针（逐项检查依赖，任一不可用返回503）
+// @Summary 就绪探针
+// @Tags 健康检查
+// @Success 200 {object} service.HealthReport
+// @Failure 503 {object} service.HealthReport
+// @Router /api/health/ready [get]
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	report := h.svc.Readiness(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status != service.HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
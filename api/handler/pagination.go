@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"customs/api/response"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+// maxPageSize 每页最大条数（避免一次查询过多数据）
+const maxPageSize = 100
+
+// parsePage 解析分页参数page/size，校验失败时已写入400响应并返回false
+func parsePage(c *gin.Context, defaultSize int) (page, size int, ok bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		response.InvalidParam(c, "页码必须为正整数")
+		return 0, 0, false
+	}
+	size, err = strconv.Atoi(c.DefaultQuery("size", strconv.Itoa(defaultSize)))
+	if err != nil || size < 1 || size > maxPageSize {
+		response.InvalidParam(c, "每页条数必须为1-100的整数")
+		return 0, 0, false
+	}
+	return page, size, true
+}
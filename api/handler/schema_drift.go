@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/repository"
+	"customs/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// DriftHandler 表结构差异检查接口处理器
+type DriftHandler struct {
+	svc *service.DriftService
+}
+
+// NewDriftHandler 初始化处理器
+func NewDriftHandler(svc *service.DriftService) *DriftHandler {
+	return &DriftHandler{svc: svc}
+}
+
+// TriggerCheck 手动触发差异检查
+// @Summary 手动触发表结构差异检查
+// @Description 异步读取数据库表结构并与已入库的数据字典比对，完成后通过 GET /api/v2/db_resources/{id}/drift 查询报告
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "数据库资源ID"
+// @Success 202 {object} response.Response
+// @Failure 404 {object} response.Response "资源不存在"
+// @Failure 409 {object} response.Response "资源未配置内省连接"
+// @Router /api/v2/db_resources/{id}/drift [post]
+func (h *DriftHandler) TriggerCheck(c *gin.Context) {
+	if err := h.svc.TriggerCheck(c.Request.Context(), c.Param("id")); err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.SuccessWithStatus(c, http.StatusAccepted, gin.H{"msg": "差异检查任务已提交"})
+}
+
+// LatestReport 查询资源最近一次的差异报告
+// @Summary 查询最近一次表结构差异报告
+// @Description 差异类型：UNDOCUMENTED_TABLE/UNDOCUMENTED_COLUMN（数据库中有、字典中没有），MISSING_TABLE/MISSING_COLUMN（字典中有、数据库中没有），TABLE_COMMENT/COLUMN_COMMENT（数据库注释与字典不一致）
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "数据库资源ID"
+// @Success 200 {object} response.Response{data=model.DriftReport}
+// @Failure 404 {object} response.Response "资源不存在或尚未检查"
+// @Router /api/v2/db_resources/{id}/drift [get]
+func (h *DriftHandler) LatestReport(c *gin.Context) {
+	report, err := h.svc.LatestReport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, report)
+}
+
+// ListReports 分页查询差异报告
+// @Summary 查询表结构差异报告列表
+// @Description 按检查时间倒序，列表不含差异明细
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param db_resource_id query string false "数据库资源ID"
+// @Param status query string false "检查结果（CLEAN/DRIFTED/FAILED）"
+// @Param page query int false "页码" default(1)
+// @Param size query int false "每页条数" default(20)
+// @Success 200 {object} response.Response{data=[]model.DriftReport}
+// @Router /api/v2/drift_reports [get]
+func (h *DriftHandler) ListReports(c *gin.Context) {
+	filter := repository.DriftReportFilter{
+		DBResourceID: c.Query("db_resource_id"),
+		Status:       c.Query("status"),
+	}
+	page, size, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+
+	reports, total, err := h.svc.ListReports(c.Request.Context(), filter, page, size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"items": reports,
+		"total": total,
+		"page":  page,
+		"size":  size,
+	})
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/service"
+	"github.com/gin-gonic/gin"
+)
+
+// RelationHandler 字段关联关系接口处理器
+type RelationHandler struct {
+	svc *service.RelationService
+}
+
+// NewRelationHandler 初始化处理器
+func NewRelationHandler(svc *service.RelationService) *RelationHandler {
+	return &RelationHandler{svc: svc}
+}
+
+// GetTableRelations 查询表的关联关系
+// @Summary 查询数据表的出向与入向关联关系
+// @Description 关联关系来自上传Excel的可选列（是否主键、关联表、关联字段），随每次入库整体替换；入向关系按系统名+库名+表名匹配，包含其他系统中引用本表的字段；未写明系统名且库名在多个资源中重名的引用单独列在ambiguous中
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "数据库资源ID"
+// @Param table path string true "数据表名称（英文）"
+// @Success 200 {object} response.Response{data=service.TableRelations}
+// @Failure 404 {object} response.Response "资源不存在"
+// @Router /api/v2/db_resources/{id}/tables/{table}/relations [get]
+func (h *RelationHandler) GetTableRelations(c *gin.Context) {
+	result, err := h.svc.GetTableRelations(c.Request.Context(), c.Param("id"), c.Param("table"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, result)
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/common/errno"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// parseUploadForm 解析multipart上传表单（超过BodyLimit上限时返回413），失败时已写入响应并返回false
+// gin的PostForm/FormFile会吞掉解析错误，因此需先显式解析
+func parseUploadForm(c *gin.Context) bool {
+	if _, err := c.MultipartForm(); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			response.Error(c, errno.ErrRequestTooLarge.WithDetails(map[string]int64{"max_bytes": maxErr.Limit}))
+			return false
+		}
+		response.FailWithStatus(c, http.StatusBadRequest, response.ErrCodeFileError, "文件上传失败："+err.Error())
+		return false
+	}
+	return true
+}
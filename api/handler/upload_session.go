@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// UploadSessionHandler 浏览器直传MinIO的上传会话接口处理器
+type UploadSessionHandler struct {
+	svc *service.DataDictionaryService
+}
+
+// NewUploadSessionHandler 初始化处理器
+func NewUploadSessionHandler(svc *service.DataDictionaryService) *UploadSessionHandler {
+	return &UploadSessionHandler{svc: svc}
+}
+
+// CreateUploadSessionRequest 创建上传会话请求体
+type CreateUploadSessionRequest struct {
+	ResourceComment string `json:"resource_comment" binding:"required"` // 资源备注
+	FileName        string `json:"file_name" binding:"required"`        // Excel文件名（系统名-dbname.xlsx）
+	Size            int64  `json:"size" binding:"required,gt=0"`        // 文件大小（字节）
+	ColumnMapping   string `json:"column_mapping"`                      // 列映射方案名称（为空时完成上传后按表头自动识别）
+}
+
+// CreateUploadSession 创建直传会话
+// @Summary 创建直传上传会话
+// @Description 返回各分片的预签名PUT地址，浏览器按offset/size切分文件后直接上传到对象存储；小于一个分片的文件只有一个分片
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param body body handler.CreateUploadSessionRequest true "文件信息"
+// @Success 201 {object} response.Response{data=service.UploadSessionDetail}
+// @Failure 400 {object} response.Response "参数或文件名格式错误"
+// @Failure 404 {object} response.Response "列映射方案不存在"
+// @Failure 413 {object} response.Response "文件超过大小上限"
+// @Failure 429 {object} response.Response "上传过于频繁"
+// @Router /api/v2/upload_sessions [post]
+func (h *UploadSessionHandler) CreateUploadSession(c *gin.Context) {
+	var req CreateUploadSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	detail, err := h.svc.CreateUploadSession(c.Request.Context(), req.ResourceComment, req.FileName, req.ColumnMapping, req.Size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/upload_sessions/"+detail.Session.ID)
+	response.SuccessWithStatus(c, http.StatusCreated, detail)
+}
+
+// GetUploadSession 查询直传会话
+// @Summary 查询直传会话进度（断点续传）
+// @Description 返回已上传的分片，并为未上传的分片重新签发预签名地址
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "会话ID"
+// @Success 200 {object} response.Response{data=service.UploadSessionDetail}
+// @Failure 403 {object} response.Response "非会话创建人"
+// @Failure 404 {object} response.Response "会话不存在"
+// @Router /api/v2/upload_sessions/{id} [get]
+func (h *UploadSessionHandler) GetUploadSession(c *gin.Context) {
+	detail, err := h.svc.GetUploadSession(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, detail)
+}
+
+// CompleteUploadSession 完成直传
+// @Summary 完成直传并创建解析任务
+// @Description 合并分片并校验Excel格式，通过后生产解析任务；校验未通过时会话结束，需重新创建
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "会话ID"
+// @Success 201 {object} response.Response{data=model.DictionaryTask}
+// @Failure 400 {object} response.Response "Excel格式错误或大小与声明不一致"
+// @Failure 404 {object} response.Response "会话不存在"
+// @Failure 409 {object} response.Response "分片未全部上传或会话已结束"
+// @Failure 429 {object} response.Response "上传过于频繁"
+// @Router /api/v2/upload_sessions/{id}/complete [post]
+func (h *UploadSessionHandler) CompleteUploadSession(c *gin.Context) {
+	dictTask, err := h.svc.CompleteUploadSession(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/tasks/"+dictTask.ID)
+	response.SuccessWithStatus(c, http.StatusCreated, dictTask)
+}
+
+// AbortUploadSession 取消直传
+// @Summary 取消直传会话
+// @Tags 数据字典v2
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param id path string true "会话ID"
+// @Success 204 "已取消"
+// @Failure 404 {object} response.Response "会话不存在"
+// @Failure 409 {object} response.Response "会话已结束"
+// @Router /api/v2/upload_sessions/{id} [delete]
+func (h *UploadSessionHandler) AbortUploadSession(c *gin.Context) {
+	if err := h.svc.AbortUploadSession(c.Request.Context(), c.Param("id")); err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"customs/api/response"
+	"customs/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// UserHandler 本地用户管理接口处理器
+type UserHandler struct {
+	svc *service.UserService
+}
+
+// NewUserHandler 初始化处理器
+func NewUserHandler(svc *service.UserService) *UserHandler {
+	return &UserHandler{svc: svc}
+}
+
+// CreateUserRequest 创建用户请求体
+type CreateUserRequest struct {
+	Username    string   `json:"username" binding:"required"` // 登录名
+	DisplayName string   `json:"display_name"`                // 显示名称
+	Password    string   `json:"password" binding:"required"` // 密码
+	Roles       []string `json:"roles"`                       // 角色（uploader/reviewer/admin）
+}
+
+// UserRolesRequest 分配角色请求体
+type UserRolesRequest struct {
+	Roles []string `json:"roles"` // 角色（uploader/reviewer/admin，为空表示收回全部角色）
+}
+
+// ListUsers 分页查询本地用户
+// @Summary 查询本地用户
+// @Tags 用户管理
+// @Security BearerAuth
+// @Security BasicAuth
+// @Param page query int false "页码" default(1)
+// @Param size query int false "每页条数" default(20)
+// @Success 200 {object} response.Response{data=[]model.User}
+// @Failure 403 {object} response.Response "非管理员"
+// @Router /api/v2/users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	page, size, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+
+	users, total, err := h.svc.ListUsers(c.Request.Context(), page, size)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"items": users,
+		"total": total,
+		"page":  page,
+		"size":  size,
+	})
+}
+
+// CreateUser 创建本地用户
+// @Summary 创建本地用户
+// @Description 仅管理员可操作，密码以bcrypt哈希存储
+// @Tags 用户管理
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param body body handler.CreateUserRequest true "用户信息"
+// @Success 201 {object} response.Response{data=model.User}
+// @Failure 400 {object} response.Response "参数错误或角色未定义"
+// @Failure 403 {object} response.Response "非管理员"
+// @Failure 409 {object} response.Response "同名用户已存在"
+// @Router /api/v2/users [post]
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var req CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	user, err := h.svc.RegisterUser(c.Request.Context(), service.UserInput(req))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/users/"+user.ID)
+	response.SuccessWithStatus(c, http.StatusCreated, user)
+}
+
+// AssignRoles 分配用户角色
+// @Summary 分配用户角色
+// @Description 以请求中的角色替换用户现有角色，对外部身份提供方的用户无效
+// @Tags 用户管理
+// @Security BearerAuth
+// @Security BasicAuth
+// @Accept json
+// @Param id path string true "用户ID"
+// @Param body body handler.UserRolesRequest true "角色"
+// @Success 200 {object} response.Response{data=model.User}
+// @Failure 400 {object} response.Response "角色未定义"
+// @Failure 403 {object} response.Response "非管理员"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Router /api/v2/users/{id}/roles [put]
+func (h *UserHandler) AssignRoles(c *gin.Context) {
+	var req UserRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.InvalidParam(c, "请求体格式错误："+err.Error())
+		return
+	}
+
+	user, err := h.svc.AssignRoles(c.Request.Context(), c.Param("id"), req.Roles)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, user)
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"customs/api/response"
+	"customs/common/auth"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+// ContextKeyIdentity gin上下文中保存用户身份的键
+const ContextKeyIdentity = "identity"
+
+// Auth 认证中间件（依次尝试各认证器，首个识别出用户的生效；均无凭证或凭证无效时返回401）
+// failLimiter不为nil时按客户端IP限制认证失败次数，失败次数耗尽后在校验凭证前直接返回429（防止暴力猜测密码）
+func Auth(log *slog.Logger, failLimiter *RateLimiter, authenticators ...auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := "ip:" + c.ClientIP()
+		if failLimiter != nil {
+			if blocked, retryAfter := failLimiter.exhausted(key); blocked {
+				tooManyRequests(c, log, failLimiter, key, retryAfter)
+				return
+			}
+		}
+
+		for _, authenticator := range authenticators {
+			identity, err := authenticator.Authenticate(c.Request)
+			if errors.Is(err, auth.ErrNoCredentials) {
+				continue
+			}
+			if errors.Is(err, auth.ErrInvalidCredentials) {
+				log.WarnContext(c.Request.Context(), "认证失败",
+					slog.String("scheme", authenticator.Scheme()), slog.Any("error", err))
+				if failLimiter != nil {
+					failLimiter.reserve(key) // 记录一次失败
+				}
+				unauthorized(c, authenticators, "认证失败：凭证无效或已过期")
+				return
+			}
+			if err != nil {
+				// 查询用户失败等服务端错误不计入认证失败
+				log.ErrorContext(c.Request.Context(), "认证出错",
+					slog.String("scheme", authenticator.Scheme()), slog.Any("error", err))
+				response.Error(c, err)
+				return
+			}
+
+			c.Set(ContextKeyIdentity, identity)
+			c.Request = c.Request.WithContext(auth.WithIdentity(c.Request.Context(), identity))
+			c.Next()
+			return
+		}
+
+		unauthorized(c, authenticators, "未登录：请提供认证凭证")
+	}
+}
+
+// unauthorized 返回401并声明支持的认证方案
+func unauthorized(c *gin.Context, authenticators []auth.Authenticator, msg string) {
+	for _, authenticator := range authenticators {
+		c.Writer.Header().Add("WWW-Authenticate", authenticator.Scheme())
+	}
+	response.FailWithStatus(c, http.StatusUnauthorized, response.ErrCodeUnauthorized, msg)
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"customs/common/auth"
+	"customs/common/errno"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// stubAuthenticator 按Authorization头返回固定结果的认证器
+type stubAuthenticator struct{}
+
+func (stubAuthenticator) Scheme() string { return "Stub" }
+
+func (stubAuthenticator) Authenticate(r *http.Request) (*auth.Identity, error) {
+	switch r.Header.Get("Authorization") {
+	case "":
+		return nil, auth.ErrNoCredentials
+	case "ok":
+		return &auth.Identity{Username: "alice"}, nil
+	case "db-down":
+		return nil, errno.ErrDBQueryFailed
+	default:
+		return nil, auth.ErrInvalidCredentials
+	}
+}
+
+func TestAuthFailureLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := NewRateLimiter("auth_fail", 1, 2, time.Minute)
+	r := gin.New()
+	r.Use(Auth(slog.New(slog.NewTextHandler(io.Discard, nil)), limiter, stubAuthenticator{}))
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(credential string) int {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("Authorization", credential)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := send("db-down"); code != http.StatusInternalServerError {
+		t.Errorf("查询用户失败时状态码 = %d, want 500", code)
+	}
+	for i := 0; i < 2; i++ {
+		if code := send("wrong"); code != http.StatusUnauthorized {
+			t.Errorf("第%d次凭证错误时状态码 = %d, want 401", i+1, code)
+		}
+	}
+	// 失败次数耗尽后，同一IP即使凭证正确也在校验前被拒绝
+	if code := send("ok"); code != http.StatusTooManyRequests {
+		t.Errorf("失败次数耗尽后状态码 = %d, want 429", code)
+	}
+}
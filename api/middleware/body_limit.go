@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"customs/api/response"
+	"customs/common/errno"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// BodyLimit 请求体大小限制中间件
+// Content-Length超限时直接返回413；否则包装请求体，读取超过上限时报错（由处理器转换为413），避免整体缓冲超大请求
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			response.Error(c, errno.ErrRequestTooLarge.WithDetails(map[string]int64{"max_bytes": maxBytes}))
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
@@ -1,19 +1,60 @@
 package middleware
 
 import (
+	"customs/config"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"time"
+	"log/slog"
+	"slices"
+	"strings"
 )
 
-// Cors 跨域中间件
-func Cors() gin.HandlerFunc {
-	return cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	})
+// Cors 跨域中间件（策略来自配置）
+// 未配置允许的来源或配置无效时不输出任何跨域响应头，由浏览器拒绝跨域请求
+func Cors(log *slog.Logger, cfg config.CORSConfig) gin.HandlerFunc {
+	corsCfg := cors.Config{
+		AllowMethods:     cfg.AllowMethods,
+		AllowHeaders:     cfg.AllowHeaders,
+		ExposeHeaders:    cfg.ExposeHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		AllowWildcard:    true, // 支持 https://*.example.com 形式的子域名通配
+		MaxAge:           cfg.MaxAge,
+	}
+
+	switch {
+	case len(cfg.AllowOrigins) == 0:
+		log.Warn("未配置允许的跨域来源，跨域请求将被拒绝")
+		return skipCors
+	case slices.Contains(cfg.AllowOrigins, "*"):
+		// 浏览器不接受 Access-Control-Allow-Origin:* 与凭证同时出现
+		corsCfg.AllowAllOrigins = true
+		if corsCfg.AllowCredentials {
+			log.Warn("允许任意跨域来源时不允许携带凭证，已关闭AllowCredentials")
+			corsCfg.AllowCredentials = false
+		}
+	default:
+		corsCfg.AllowOrigins = cfg.AllowOrigins
+	}
+
+	if err := corsCfg.Validate(); err != nil {
+		log.Error("跨域配置无效，跨域请求将被拒绝", slog.Any("error", err))
+		return skipCors
+	}
+	// 通配规则中多个*会导致cors.New panic，此处提前拦截
+	for _, origin := range corsCfg.AllowOrigins {
+		if strings.Count(origin, "*") > 1 {
+			log.Error("跨域来源最多包含一个通配符，跨域请求将被拒绝", slog.String("origin", origin))
+			return skipCors
+		}
+	}
+	log.Info("跨域策略已加载",
+		slog.Any("allow_origins", cfg.AllowOrigins),
+		slog.Bool("allow_credentials", corsCfg.AllowCredentials),
+	)
+	return cors.New(corsCfg)
+}
+
+// skipCors 不处理跨域（预检请求将因缺少允许头而被浏览器拒绝）
+func skipCors(c *gin.Context) {
+	c.Next()
 }
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"customs/config"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// corsResponse 以给定配置处理来自origin的GET请求
+func corsResponse(t *testing.T, cfg config.CORSConfig, origin string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Cors(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg))
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("Origin", origin)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCors(t *testing.T) {
+	base := config.CORSConfig{AllowMethods: []string{http.MethodGet}, AllowCredentials: true}
+	withOrigins := func(origins ...string) config.CORSConfig {
+		cfg := base
+		cfg.AllowOrigins = origins
+		return cfg
+	}
+	tests := []struct {
+		name            string
+		cfg             config.CORSConfig
+		origin          string
+		wantOrigin      string
+		wantCredentials string
+	}{
+		{"未配置来源时不输出跨域头", base, "https://a.example.com", "", ""},
+		{"任意来源时关闭凭证", withOrigins("*"), "https://a.example.com", "*", ""},
+		{"精确匹配", withOrigins("https://app.example.com"), "https://app.example.com", "https://app.example.com", "true"},
+		{"子域名通配匹配", withOrigins("https://*.example.com"), "https://a.example.com", "https://a.example.com", "true"},
+		{"子域名通配不匹配其他域名", withOrigins("https://*.example.com"), "https://a.example.org", "", ""},
+		{"多个通配符时不输出跨域头", withOrigins("https://*.*.example.com"), "https://a.b.example.com", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := corsResponse(t, tt.cfg, tt.origin)
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+				t.Errorf("Access-Control-Allow-Credentials = %q, want %q", got, tt.wantCredentials)
+			}
+		})
+	}
+}
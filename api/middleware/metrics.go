@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"customs/infrastructure/metrics"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"time"
+)
+
+// Metrics HTTP指标中间件（按路由模板统计，避免路径参数导致标签爆炸）
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched" // 未匹配任何路由（404）
+		}
+		method := c.Request.Method
+		metrics.HTTPRequestsTotal.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
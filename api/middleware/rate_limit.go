@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"customs/api/response"
+	"customs/common/auth"
+	"customs/common/errno"
+	"customs/infrastructure/metrics"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+	"log/slog"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter 按客户端（已认证用户优先，否则客户端IP）区分的令牌桶限流器
+// 令牌桶保存在进程内存中，多实例部署时各实例独立计数
+type RateLimiter struct {
+	name    string
+	limit   rate.Limit
+	burst   int
+	idleTTL time.Duration
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+// clientLimiter 单个客户端的令牌桶
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimiter 初始化限流器（perMinute为每分钟补充的令牌数，burst为令牌桶容量）
+func NewRateLimiter(name string, perMinute float64, burst int, idleTTL time.Duration) *RateLimiter {
+	return &RateLimiter{
+		name:      name,
+		limit:     rate.Limit(perMinute / 60),
+		burst:     burst,
+		idleTTL:   idleTTL,
+		clients:   make(map[string]*clientLimiter),
+		lastSweep: time.Now(),
+	}
+}
+
+// reserve 为客户端消耗一个令牌，令牌不足时返回需等待的时长
+func (l *RateLimiter) reserve(key string) (bool, time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	l.sweep(now)
+	client, ok := l.clients[key]
+	if !ok {
+		client = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[key] = client
+	}
+	client.lastSeen = now
+	l.mu.Unlock()
+
+	r := client.limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return false, l.idleTTL
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now) // 本次不放行，归还令牌
+		return false, delay
+	}
+	return true, 0
+}
+
+// exhausted 查询客户端的令牌是否已耗尽（不消耗令牌），耗尽时返回需等待的时长
+func (l *RateLimiter) exhausted(key string) (bool, time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	client, ok := l.clients[key]
+	l.mu.Unlock()
+	if !ok {
+		return false, 0
+	}
+	tokens := client.limiter.TokensAt(now)
+	if tokens >= 1 {
+		return false, 0
+	}
+	if l.limit <= 0 {
+		return true, l.idleTTL
+	}
+	return true, time.Duration((1 - tokens) / float64(l.limit) * float64(time.Second))
+}
+
+// tooManyRequests 返回429并设置Retry-After
+func tooManyRequests(c *gin.Context, log *slog.Logger, limiter *RateLimiter, key string, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	metrics.RateLimitedTotal.WithLabelValues(limiter.name).Inc()
+	log.WarnContext(c.Request.Context(), "请求被限流",
+		slog.String("limiter", limiter.name), slog.String("client", key), slog.Int("retry_after", seconds))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	response.Error(c, errno.ErrTooManyRequests.WithDetails(map[string]int{"retry_after_seconds": seconds}))
+}
+
+// sweep 回收空闲超过idleTTL的客户端令牌桶（需持有锁）
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.idleTTL {
+		return
+	}
+	for key, client := range l.clients {
+		if now.Sub(client.lastSeen) > l.idleTTL {
+			delete(l.clients, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// RateLimit 限流中间件（需在Auth之后使用，以便按用户限流），超限时返回429并设置Retry-After
+func RateLimit(log *slog.Logger, limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := "ip:" + c.ClientIP()
+		if username := auth.Actor(c.Request.Context()); username != "" {
+			key = "user:" + username
+		}
+
+		allowed, retryAfter := limiter.reserve(key)
+		if allowed {
+			c.Next()
+			return
+		}
+		tooManyRequests(c, log, limiter, key, retryAfter)
+	}
+}
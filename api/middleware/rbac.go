@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"customs/api/response"
+	"customs/common/auth"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// RequireRoles 角色校验中间件（需在Auth之后使用；拥有任一角色即放行，admin拥有全部权限）
+func RequireRoles(log *slog.Logger, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, ok := auth.FromContext(c.Request.Context())
+		if !ok {
+			response.FailWithStatus(c, http.StatusUnauthorized, response.ErrCodeUnauthorized, "未登录：请提供认证凭证")
+			return
+		}
+		if !identity.HasAnyRole(roles...) {
+			log.WarnContext(c.Request.Context(), "权限不足",
+				slog.String("username", identity.Username),
+				slog.Any("roles", identity.Roles),
+				slog.Any("required", roles),
+			)
+			response.FailWithStatus(c, http.StatusForbidden, response.ErrCodeForbidden,
+				"权限不足：需要角色 "+strings.Join(roles, "/"))
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"customs/common/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// HeaderRequestID 请求ID的HTTP头
+const HeaderRequestID = "X-Request-ID"
+
+// RequestID 请求ID中间件（沿用调用方传入的ID，否则生成新ID，并写入请求上下文与响应头；同时记录客户端IP）
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(HeaderRequestID)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = uuid.New().String()
+		}
+
+		ctx := logger.WithRequestID(c.Request.Context(), requestID)
+		ctx = logger.WithClientIP(ctx, c.ClientIP())
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(HeaderRequestID, requestID)
+		c.Next()
+	}
+}
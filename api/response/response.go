@@ -1,6 +1,8 @@
 package response
 
 import (
+	"customs/common/errno"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -13,14 +15,17 @@ const (
 	ErrCodeDBError       = 1003 // 数据库错误（查询/插入/更新）
 	ErrCodeTaskError     = 1004 // 异步任务错误（生产/查询/执行）
 	ErrCodeBusinessError = 1005 // 业务逻辑错误（前置条件不满足等）
+	ErrCodeUnauthorized  = 1006 // 未认证或认证失败
+	ErrCodeForbidden     = 1007 // 已认证但无权限
 	ErrCodeSystemError   = 5000 // 系统内部错误（兜底）
 )
 
 // Response 统一响应结构体
 type Response struct {
-	Code int         `json:"code"` // 业务错误码（0=成功）
-	Msg  string      `json:"msg"`  // 提示信息
-	Data interface{} `json:"data"` // 业务数据（可选）
+	Code    int         `json:"code"`              // 业务错误码（0=成功）
+	Msg     string      `json:"msg"`               // 提示信息
+	Data    interface{} `json:"data"`              // 业务数据（可选）
+	Details interface{} `json:"details,omitempty"` // 结构化错误详情（可选，如缺失的列名）
 }
 
 // Success 成功响应
@@ -32,6 +37,15 @@ func Success(c *gin.Context, data interface{}) {
 	})
 }
 
+// SuccessWithStatus 成功响应（指定HTTP状态码，如201创建、202已受理）
+func SuccessWithStatus(c *gin.Context, status int, data interface{}) {
+	c.JSON(status, Response{
+		Code: 0,
+		Msg:  "success",
+		Data: data,
+	})
+}
+
 // Fail 失败响应
 func Fail(c *gin.Context, code int, msg string) {
 	c.JSON(http.StatusOK, Response{
@@ -40,3 +54,35 @@ func Fail(c *gin.Context, code int, msg string) {
 		Data: nil,
 	})
 }
+
+// FailWithStatus 失败响应（指定HTTP状态码，用于认证失败等需要客户端识别状态码的场景）
+func FailWithStatus(c *gin.Context, status, code int, msg string) {
+	c.AbortWithStatusJSON(status, Response{
+		Code: code,
+		Msg:  msg,
+		Data: nil,
+	})
+}
+
+// InvalidParam 参数校验失败响应（HTTP 400）
+func InvalidParam(c *gin.Context, msg string) {
+	FailWithStatus(c, http.StatusBadRequest, ErrCodeInvalidParam, msg)
+}
+
+// Error 按错误类型返回失败响应
+// errno.Errno：返回其错误码、提示信息、详情与对应HTTP状态码；其他错误：统一返回500，不暴露内部信息
+// 原始错误记录到gin上下文，由访问日志中间件输出
+func Error(c *gin.Context, err error) {
+	_ = c.Error(err)
+
+	var e *errno.Errno
+	if errors.As(err, &e) {
+		c.AbortWithStatusJSON(e.Status(), Response{
+			Code:    e.Code,
+			Msg:     e.Msg,
+			Details: e.Details,
+		})
+		return
+	}
+	FailWithStatus(c, http.StatusInternalServerError, ErrCodeSystemError, errno.ErrInternalServer.Msg)
+}
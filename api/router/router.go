@@ -3,32 +3,181 @@ package router
 import (
 	"customs/api/handler"
 	"customs/api/middleware"
+	"customs/common/auth"
+	"customs/config"
+	"customs/model"
 	"customs/service"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"log/slog"
 )
 
 // NewRouter 初始化路由
-func NewRouter(serviceContainer *service.ServiceContainer) *gin.Engine {
-	r := gin.Default()
+func NewRouter(
+	cfg *config.Config,
+	serviceContainer *service.ServiceContainer,
+	logger *slog.Logger,
+	authenticators []auth.Authenticator,
+) *gin.Engine {
+	r := gin.New()
+	r.MaxMultipartMemory = cfg.Upload.MaxMemoryBytes // 超出部分写入临时文件，避免大文件整体驻留内存
+	// 客户端IP用于限流与审计，只采信可信代理转发的X-Forwarded-For，防止伪造
+	if err := r.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		logger.Error("可信代理配置无效，不信任任何代理", slog.Any("error", err))
+		_ = r.SetTrustedProxies(nil)
+	}
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.RequestID())            // 请求ID（需在日志中间件之前）
+	r.Use(middleware.Logger(logger))         // 结构化访问日志
+	r.Use(middleware.Cors(logger, cfg.CORS)) // 跨域
+	r.Use(middleware.Metrics())              // Prometheus指标
 
-	r.Use(middleware.Cors()) // 跨域
+	r.GET("/metrics", gin.WrapH(promhttp.Handler())) // 指标采集端点
 
 	ddHandler := handler.NewDataDictionaryHandler(serviceContainer.DataDictionary)
+	taskHandler := handler.NewDictionaryTaskHandler(serviceContainer.DataDictionary)
+	batchHandler := handler.NewDictionaryBatchHandler(serviceContainer.DataDictionary)
+	uploadHandler := handler.NewUploadSessionHandler(serviceContainer.DataDictionary)
+	healthHandler := handler.NewHealthHandler(serviceContainer.Health)
+	auditHandler := handler.NewAuditLogHandler(serviceContainer.Audit)
+	searchHandler := handler.NewDictionarySearchHandler(serviceContainer.Search)
+	resourceHandler := handler.NewDBResourceHandler(serviceContainer.Export, serviceContainer.DataDictionary)
+	driftHandler := handler.NewDriftHandler(serviceContainer.Drift)
+	glossaryHandler := handler.NewGlossaryHandler(serviceContainer.Glossary)
+	relationHandler := handler.NewRelationHandler(serviceContainer.Relation)
+	mappingHandler := handler.NewColumnMappingHandler(serviceContainer.ColumnMapping)
+	userHandler := handler.NewUserHandler(serviceContainer.User)
+	docsHandler := handler.NewDocsHandler()
 
 	apiGroup := r.Group("/api")
 	{
-		dictGroup := apiGroup.Group("/data_dictionary")
+		apiGroup.GET("/openapi.json", docsHandler.OpenAPISpec)    // OpenAPI文档
+		apiGroup.GET("/docs", docsHandler.UI)                     // 接口文档页面
+		apiGroup.GET("/docs/assets/*filepath", docsHandler.Asset) // 接口文档页面的静态资源
+
+		// 角色校验依赖认证结果，未启用认证时不做角色限制（审批仍要求已认证的审批人）
+		authenticate := func(c *gin.Context) { c.Next() }
+		requireRoles := func(roles ...string) gin.HandlerFunc {
+			return func(c *gin.Context) { c.Next() }
+		}
+		// 认证失败按客户端IP限流，各认证入口共用同一限流器
+		var authFailLimiter *middleware.RateLimiter
+		if cfg.RateLimit.Enabled {
+			authFailLimiter = middleware.NewRateLimiter("auth_fail",
+				cfg.RateLimit.AuthFailPerMinute, cfg.RateLimit.AuthFailBurst, cfg.RateLimit.IdleTTL)
+		}
+		if len(authenticators) > 0 {
+			authenticate = middleware.Auth(logger, authFailLimiter, authenticators...)
+			requireRoles = func(roles ...string) gin.HandlerFunc {
+				return middleware.RequireRoles(logger, roles...)
+			}
+		} else {
+			logger.Warn("未配置认证方式，数据字典接口允许匿名访问")
+		}
+
+		// 上传接口：请求体大小限制 + 按用户/IP限流；审批接口：按用户/IP限流
+		uploadLimits := []gin.HandlerFunc{middleware.BodyLimit(cfg.Upload.MaxRequestBytes)}
+		var confirmLimits []gin.HandlerFunc
+		if cfg.RateLimit.Enabled {
+			uploadLimiter := middleware.NewRateLimiter("upload",
+				cfg.RateLimit.UploadPerMinute, cfg.RateLimit.UploadBurst, cfg.RateLimit.IdleTTL)
+			confirmLimiter := middleware.NewRateLimiter("confirm",
+				cfg.RateLimit.ConfirmPerMinute, cfg.RateLimit.ConfirmBurst, cfg.RateLimit.IdleTTL)
+			uploadLimits = append(uploadLimits, middleware.RateLimit(logger, uploadLimiter))
+			confirmLimits = append(confirmLimits, middleware.RateLimit(logger, confirmLimiter))
+		}
+		upload := func(role string, h gin.HandlerFunc) []gin.HandlerFunc {
+			return append(append([]gin.HandlerFunc{requireRoles(role)}, uploadLimits...), h)
+		}
+		confirm := func(role string, h gin.HandlerFunc) []gin.HandlerFunc {
+			return append(append([]gin.HandlerFunc{requireRoles(role)}, confirmLimits...), h)
+		}
+
+		// v1接口（保持兼容）
+		dictGroup := apiGroup.Group("/data_dictionary", authenticate)
 		{
-			dictGroup.GET("/insert", ddHandler.DownloadTemplate)              // 下载模版文件
-			dictGroup.POST("/insert", ddHandler.UploadExcel)                  // 上传Excel
-			dictGroup.GET("/insert/data", ddHandler.GetParseResult)           // 查询解析结果
-			dictGroup.POST("/insert/{id}", ddHandler.ConfirmInsert)           // 确认入库
-			dictGroup.GET("/resource_comment", ddHandler.GetResourceComments) // 查询资源备注
+			dictGroup.GET("/insert", ddHandler.DownloadTemplate)                                   // 下载模版文件
+			dictGroup.POST("/insert", upload(model.RoleUploader, ddHandler.UploadExcel)...)        // 上传Excel
+			dictGroup.GET("/insert/data", ddHandler.GetParseResult)                                // 查询解析结果
+			dictGroup.POST("/insert/:id", confirm(model.RoleReviewer, ddHandler.ConfirmInsert)...) // 审批入库
+			dictGroup.GET("/resource_comment", ddHandler.GetResourceComments)                      // 查询资源备注
 		}
 
-		apiGroup.GET("/health", func(c *gin.Context) {
-			c.JSON(200, gin.H{"status": "ok"})
-		})
+		// v2接口（面向资源）
+		v2Group := apiGroup.Group("/v2", authenticate)
+		{
+			v2Group.GET("/template", taskHandler.GetTemplate)                                                                 // 下载模版文件
+			v2Group.GET("/column_mappings", mappingHandler.ListMappings)                                                      // 列映射方案列表
+			v2Group.POST("/column_mappings", requireRoles(model.RoleAdmin), mappingHandler.CreateMapping)                     // 新增列映射方案
+			v2Group.GET("/column_mappings/:id", mappingHandler.GetMapping)                                                    // 列映射方案详情
+			v2Group.PUT("/column_mappings/:id", requireRoles(model.RoleAdmin), mappingHandler.UpdateMapping)                  // 修改列映射方案
+			v2Group.DELETE("/column_mappings/:id", requireRoles(model.RoleAdmin), mappingHandler.DeleteMapping)               // 删除列映射方案
+			v2Group.GET("/tasks", taskHandler.ListTasks)                                                                      // 任务列表
+			v2Group.POST("/tasks", upload(model.RoleUploader, taskHandler.CreateTask)...)                                     // 上传Excel创建任务
+			v2Group.GET("/tasks/:id", taskHandler.GetTask)                                                                    // 任务详情
+			v2Group.DELETE("/tasks/:id", requireRoles(model.RoleUploader), taskHandler.DeleteTask)                            // 删除任务
+			v2Group.GET("/tasks/:id/result", taskHandler.GetTaskResult)                                                       // 解析结果
+			v2Group.GET("/tasks/:id/naming", taskHandler.GetNamingReport)                                                     // 命名规范检查结果
+			v2Group.POST("/tasks/:id/confirm", confirm(model.RoleReviewer, taskHandler.ConfirmTask)...)                       // 审批通过
+			v2Group.POST("/tasks/:id/reject", confirm(model.RoleReviewer, taskHandler.RejectTask)...)                         // 审批驳回
+			v2Group.GET("/batches", batchHandler.ListBatches)                                                                 // 批次列表
+			v2Group.POST("/batches", upload(model.RoleUploader, batchHandler.CreateBatch)...)                                 // 批量上传创建批次
+			v2Group.GET("/batches/:id", batchHandler.GetBatch)                                                                // 批次详情
+			v2Group.POST("/batches/:id/confirm", confirm(model.RoleReviewer, batchHandler.ConfirmBatch)...)                   // 批次整体审批通过
+			v2Group.POST("/batches/:id/reject", confirm(model.RoleReviewer, batchHandler.RejectBatch)...)                     // 批次整体驳回
+			v2Group.POST("/upload_sessions", upload(model.RoleUploader, uploadHandler.CreateUploadSession)...)                // 创建直传会话
+			v2Group.GET("/upload_sessions/:id", requireRoles(model.RoleUploader), uploadHandler.GetUploadSession)             // 直传进度
+			v2Group.POST("/upload_sessions/:id/complete", upload(model.RoleUploader, uploadHandler.CompleteUploadSession)...) // 完成直传
+			v2Group.DELETE("/upload_sessions/:id", requireRoles(model.RoleUploader), uploadHandler.AbortUploadSession)        // 取消直传
+			v2Group.GET("/search", searchHandler.Search)                                                                      // 全文检索数据字典
+			v2Group.GET("/db_resources", resourceHandler.ListDBResources)                                                     // 数据库资源列表
+			v2Group.POST("/db_resources", requireRoles(model.RoleAdmin), resourceHandler.CreateDBResource)                    // 登记数据库资源
+			v2Group.PUT("/db_resources/:id/connection", requireRoles(model.RoleAdmin), resourceHandler.UpdateConnection)      // 修改内省连接
+			v2Group.POST("/db_resources/:id/introspect", requireRoles(model.RoleUploader), resourceHandler.Introspect)        // 从表结构生成字典草稿
+			v2Group.GET("/db_resources/:id/excel", resourceHandler.ExportExcel)                                               // 按资源导出数据字典
+			v2Group.GET("/db_resources/:id/docs", resourceHandler.DownloadDocs)                                               // 下载数据字典文档
+			v2Group.GET("/db_resources/:id/ddl", resourceHandler.ExportDDL)                                                   // 生成建表语句
+			v2Group.GET("/db_resources/:id/json_schema", resourceHandler.ExportJSONSchema)                                    // 生成JSON Schema
+			v2Group.GET("/db_resources/:id/tables/:table/relations", relationHandler.GetTableRelations)                       // 表的关联关系
+			v2Group.GET("/db_resources/:id/drift", driftHandler.LatestReport)                                                 // 最近一次表结构差异报告
+			v2Group.POST("/db_resources/:id/drift", requireRoles(model.RoleUploader), driftHandler.TriggerCheck)              // 手动触发差异检查
+			v2Group.GET("/drift_reports", driftHandler.ListReports)                                                           // 差异报告列表
+			v2Group.GET("/db_resources/:id/term_links", glossaryHandler.ListResourceLinks)                                    // 字段的术语关联
+			v2Group.PUT("/db_resources/:id/term_links", requireRoles(model.RoleUploader), glossaryHandler.LinkField)          // 手动关联字段与术语
+			v2Group.DELETE("/db_resources/:id/term_links", requireRoles(model.RoleUploader), glossaryHandler.UnlinkField)     // 取消字段的术语关联
+			v2Group.GET("/glossary", glossaryHandler.ListTerms)                                                               // 业务术语列表
+			v2Group.POST("/glossary", requireRoles(model.RoleAdmin), glossaryHandler.CreateTerm)                              // 新增业务术语
+			v2Group.GET("/glossary/:id", glossaryHandler.GetTerm)                                                             // 业务术语及关联字段
+			v2Group.PUT("/glossary/:id", requireRoles(model.RoleAdmin), glossaryHandler.UpdateTerm)                           // 修改业务术语
+			v2Group.DELETE("/glossary/:id", requireRoles(model.RoleAdmin), glossaryHandler.DeleteTerm)                        // 删除业务术语
+			v2Group.GET("/dictionaries/excel", resourceHandler.ExportExcelBySystem)                                           // 按系统名与库名导出数据字典
+			v2Group.GET("/resource_comments", ddHandler.GetResourceComments)                                                  // 查询资源备注
+		}
+
+		// 审计日志与用户管理仅管理员可操作；未启用认证时无法识别操作人，不开放这些接口
+		if len(authenticators) > 0 {
+			auditGroup := apiGroup.Group("/audit_logs",
+				middleware.Auth(logger, authFailLimiter, authenticators...),
+				middleware.RequireRoles(logger, model.RoleAdmin),
+			)
+			auditGroup.GET("", auditHandler.ListAuditLogs) // 查询审计日志
+
+			userGroup := apiGroup.Group("/v2/users",
+				middleware.Auth(logger, authFailLimiter, authenticators...),
+				middleware.RequireRoles(logger, model.RoleAdmin),
+			)
+			userGroup.GET("", userHandler.ListUsers)             // 本地用户列表
+			userGroup.POST("", userHandler.CreateUser)           // 创建本地用户
+			userGroup.PUT("/:id/roles", userHandler.AssignRoles) // 分配用户角色
+		}
+
+		healthGroup := apiGroup.Group("/health")
+		{
+			healthGroup.GET("", healthHandler.Legacy)          // 兼容旧探针
+			healthGroup.GET("/live", healthHandler.Liveness)   // 存活探针
+			healthGroup.GET("/ready", healthHandler.Readiness) // 就绪探针（检查依赖）
+		}
 	}
 
 	return r
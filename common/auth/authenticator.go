@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	// ErrNoCredentials 请求未携带当前认证方式的凭证（交给下一个认证器处理）
+	ErrNoCredentials = errors.New("未提供认证凭证")
+	// ErrInvalidCredentials 凭证无效（过期、签名错误、密码错误等）
+	ErrInvalidCredentials = errors.New("认证凭证无效")
+)
+
+// Authenticator 可插拔认证器（JWT、本地用户表等）
+type Authenticator interface {
+	// Scheme 返回WWW-Authenticate响应头使用的认证方案（如Bearer、Basic）
+	Scheme() string
+	// Authenticate 识别请求中的用户；未携带本认证方式的凭证时返回ErrNoCredentials
+	Authenticate(r *http.Request) (*Identity, error)
+}
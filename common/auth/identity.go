@@ -0,0 +1,58 @@
+package auth
+
+import "context"
+
+// 认证来源
+const (
+	SourceJWT   = "jwt"   // JWT Bearer令牌
+	SourceLocal = "local" // 本地用户表
+)
+
+// Identity 已认证的用户身份（由认证中间件写入请求上下文）
+type Identity struct {
+	UserID      string   `json:"user_id"`      // 用户唯一标识（JWT的sub或本地用户ID）
+	Username    string   `json:"username"`     // 登录名（记录为上传人/确认人/创建人）
+	DisplayName string   `json:"display_name"` // 显示名称
+	Source      string   `json:"source"`       // 认证来源（jwt/local）
+	Roles       []string `json:"roles"`        // 角色（uploader/reviewer/admin）
+}
+
+// roleAdmin 管理员角色（拥有全部角色权限，与model.RoleAdmin一致）
+const roleAdmin = "admin"
+
+// HasAnyRole 是否拥有任一指定角色（管理员视为拥有全部角色）
+func (i *Identity) HasAnyRole(roles ...string) bool {
+	for _, have := range i.Roles {
+		if have == roleAdmin {
+			return true
+		}
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ctxKey 上下文键类型（避免与其他包冲突）
+type ctxKey struct{}
+
+// WithIdentity 将用户身份写入上下文
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, ctxKey{}, identity)
+}
+
+// FromContext 从上下文读取用户身份
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(ctxKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// Actor 返回当前操作人登录名（未认证时返回空字符串）
+func Actor(ctx context.Context) string {
+	if identity, ok := FromContext(ctx); ok {
+		return identity.Username
+	}
+	return ""
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTConfig JWT认证配置
+type JWTConfig struct {
+	Issuer         string   // 签发方（必须与令牌iss一致，为空时不校验）
+	Audience       string   // 受众（为空时不校验）
+	Secret         string   // HMAC密钥（HS256/384/512）
+	PublicKeyFiles []string // RSA/ECDSA公钥PEM文件（文件名去掉扩展名即kid）
+	UsernameClaim  string   // 作为登录名的声明（默认preferred_username，缺失时回退sub）
+	RolesClaim     string   // 角色声明（默认roles，支持字符串数组或空格/逗号分隔的字符串）
+}
+
+// JWTAuthenticator JWT Bearer认证器
+type JWTAuthenticator struct {
+	cfg        JWTConfig
+	publicKeys map[string]crypto.PublicKey // kid -> 公钥
+	parser     *jwt.Parser
+}
+
+// NewJWTAuthenticator 初始化JWT认证器（加载公钥文件）
+func NewJWTAuthenticator(cfg JWTConfig) (*JWTAuthenticator, error) {
+	if cfg.Secret == "" && len(cfg.PublicKeyFiles) == 0 {
+		return nil, errors.New("JWT认证需配置HMAC密钥或公钥文件")
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = "preferred_username"
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+
+	publicKeys := make(map[string]crypto.PublicKey, len(cfg.PublicKeyFiles))
+	for _, file := range cfg.PublicKeyFiles {
+		key, err := loadPublicKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("加载公钥%s失败：%w", file, err)
+		}
+		kid := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		publicKeys[kid] = key
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &JWTAuthenticator{
+		cfg:        cfg,
+		publicKeys: publicKeys,
+		parser:     jwt.NewParser(opts...),
+	}, nil
+}
+
+// Scheme 实现Authenticator
+func (a *JWTAuthenticator) Scheme() string {
+	return "Bearer"
+}
+
+// Authenticate 实现Authenticator（校验Authorization: Bearer <token>）
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	header := r.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return nil, ErrNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(strings.TrimSpace(header[7:]), claims, a.keyFunc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	sub, _ := claims.GetSubject()
+	if sub == "" {
+		return nil, fmt.Errorf("%w: 令牌缺少sub声明", ErrInvalidCredentials)
+	}
+	username, _ := claims[a.cfg.UsernameClaim].(string)
+	if username == "" {
+		username = sub
+	}
+	displayName, _ := claims["name"].(string)
+
+	return &Identity{
+		UserID:      sub,
+		Username:    username,
+		DisplayName: displayName,
+		Source:      SourceJWT,
+		Roles:       parseRoles(claims[a.cfg.RolesClaim]),
+	}, nil
+}
+
+// parseRoles 解析角色声明（字符串数组，或空格/逗号分隔的字符串）
+func parseRoles(claim interface{}) []string {
+	var roles []string
+	switch v := claim.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if role, ok := item.(string); ok && role != "" {
+				roles = append(roles, role)
+			}
+		}
+	case string:
+		roles = strings.FieldsFunc(v, func(r rune) bool { return r == ' ' || r == ',' })
+	}
+	return roles
+}
+
+// keyFunc 按签名算法与kid选择校验密钥
+func (a *JWTAuthenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if a.cfg.Secret == "" {
+			return nil, errors.New("未配置HMAC密钥")
+		}
+		return []byte(a.cfg.Secret), nil
+	default:
+		if kid, ok := token.Header["kid"].(string); ok {
+			if key, ok := a.publicKeys[kid]; ok {
+				return key, nil
+			}
+			return nil, fmt.Errorf("未知的kid：%s", kid)
+		}
+		// 未指定kid时，仅在只配置了一个公钥的情况下使用该公钥
+		if len(a.publicKeys) == 1 {
+			for _, key := range a.publicKeys {
+				return key, nil
+			}
+		}
+		return nil, errors.New("令牌未指定kid")
+	}
+}
+
+// loadPublicKey 从PEM文件加载RSA或ECDSA公钥
+func loadPublicKey(file string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	return jwt.ParseECPublicKeyFromPEM(data)
+}
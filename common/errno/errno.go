@@ -1,18 +1,44 @@
 package errno
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 // Errno 自定义错误类型
+// Msg与Details返回给客户端；cause仅用于日志排查，不对外暴露
 type Errno struct {
-	Code int
-	Msg  string
+	Code       int
+	Msg        string
+	HTTPStatus int         // 对应的HTTP状态码（为0时按错误码分类推断）
+	Details    interface{} // 结构化错误详情（如缺失的列名）
+	cause      error       // 原始错误
 }
 
-// Error 实现error接口
+// Error 实现error接口（包含原始错误，便于日志记录）
 func (e *Errno) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %v", e.Msg, e.cause)
+	}
 	return e.Msg
 }
 
+// Unwrap 返回原始错误（支持errors.Is/As穿透）
+func (e *Errno) Unwrap() error {
+	return e.cause
+}
+
+// Is 错误码相同即视为同一错误（WithXxx派生的副本仍可用errors.Is与预定义错误比较）
+func (e *Errno) Is(target error) bool {
+	t, ok := target.(*Errno)
+	return ok && t.Code == e.Code
+}
+
+// Cause 返回原始错误
+func (e *Errno) Cause() error {
+	return e.cause
+}
+
 // 定义通用错误码
 var (
 	ErrInternalServer = &Errno{Code: 500, Msg: "服务器内部错误"}
@@ -28,26 +54,105 @@ var (
 	ErrExcelReadFailed       = &Errno{Code: 1007, Msg: "Excel文件读取失败"}
 	ErrExcelColumnMissing    = &Errno{Code: 1008, Msg: "Excel缺少必要列（需包含数据表名称、字段名称等标准列）"}
 	ErrExcelOpenFailed       = &Errno{Code: 1009, Msg: "Excel打开失败"}
+	ErrFileTooLarge          = &Errno{Code: 1010, Msg: "文件超过大小上限", HTTPStatus: http.StatusRequestEntityTooLarge}
+	ErrRequestTooLarge       = &Errno{Code: 1011, Msg: "请求体超过大小上限", HTTPStatus: http.StatusRequestEntityTooLarge}
+	ErrBatchValidationFailed = &Errno{Code: 1012, Msg: "批量上传的文件未全部通过校验（逐个文件的错误见details）"}
+	ErrBatchTooManyFiles     = &Errno{Code: 1013, Msg: "批量上传的文件数超过上限"}
+	ErrZipReadFailed         = &Errno{Code: 1014, Msg: "ZIP压缩包读取失败"}
+	ErrUploadSizeMismatch    = &Errno{Code: 1015, Msg: "上传的文件大小与声明不一致"}
 
 	ErrDBInsertFailed = &Errno{Code: 2001, Msg: "数据库插入失败"}
 	ErrDBUpdateFailed = &Errno{Code: 2002, Msg: "数据库更新失败"}
 	ErrDBQueryFailed  = &Errno{Code: 2003, Msg: "数据库查询失败"}
 
-	ErrRedisGetFailed = &Errno{Code: 3001, Msg: "Redis获取失败"}
-	ErrRedisSetFailed = &Errno{Code: 3002, Msg: "Redis设置失败"}
+	ErrRedisGetFailed      = &Errno{Code: 3001, Msg: "Redis获取失败"}
+	ErrRedisSetFailed      = &Errno{Code: 3002, Msg: "Redis设置失败"}
+	ErrParseResultNotFound = &Errno{Code: 3003, Msg: "解析结果不存在或已过期", HTTPStatus: http.StatusNotFound}
 
-	ErrTaskCreateFailed    = &Errno{Code: 4001, Msg: "任务创建失败"}
-	ErrTaskQueryFailed     = &Errno{Code: 4002, Msg: "任务状态查询失败"}
-	ErrPreTaskNotCompleted = &Errno{Code: 4003, Msg: "前置任务未完成"}
+	ErrTaskCreateFailed      = &Errno{Code: 4001, Msg: "任务创建失败", HTTPStatus: http.StatusInternalServerError}
+	ErrTaskQueryFailed       = &Errno{Code: 4002, Msg: "任务状态查询失败", HTTPStatus: http.StatusInternalServerError}
+	ErrPreTaskNotCompleted   = &Errno{Code: 4003, Msg: "前置任务未完成"}
+	ErrSelfApproval          = &Errno{Code: 4004, Msg: "审批人不能是任务上传人", HTTPStatus: http.StatusForbidden}
+	ErrTaskAlreadyDecided    = &Errno{Code: 4005, Msg: "任务已审批，不能重复操作"}
+	ErrApproverRequired      = &Errno{Code: 4006, Msg: "审批操作需要已认证的审批人", HTTPStatus: http.StatusUnauthorized}
+	ErrTaskNotFound          = &Errno{Code: 4007, Msg: "任务不存在", HTTPStatus: http.StatusNotFound}
+	ErrTaskNotDeletable      = &Errno{Code: 4008, Msg: "任务已确认入库，不能删除"}
+	ErrTaskPermission        = &Errno{Code: 4009, Msg: "无权操作该任务（仅上传人或管理员）", HTTPStatus: http.StatusForbidden}
+	ErrBatchNotFound         = &Errno{Code: 4010, Msg: "批次不存在", HTTPStatus: http.StatusNotFound}
+	ErrTaskInBatch           = &Errno{Code: 4011, Msg: "任务属于批次，需按批次整体审批"}
+	ErrTaskInBatchDelete     = &Errno{Code: 4012, Msg: "任务属于批次，不能单独删除"}
+	ErrUploadSessionNotFound = &Errno{Code: 4013, Msg: "上传会话不存在", HTTPStatus: http.StatusNotFound}
+	ErrUploadSessionClosed   = &Errno{Code: 4014, Msg: "上传会话已结束或已过期"}
+	ErrUploadIncomplete      = &Errno{Code: 4015, Msg: "分片未全部上传"}
+	ErrDBResourceNotFound    = &Errno{Code: 4016, Msg: "数据库资源不存在", HTTPStatus: http.StatusNotFound}
+	ErrDictionaryNotFound    = &Errno{Code: 4017, Msg: "未找到已入库的数据字典", HTTPStatus: http.StatusNotFound}
+	ErrDictionaryAmbiguous   = &Errno{Code: 4018, Msg: "匹配到多个资源的数据字典，请指定资源备注或资源ID", HTTPStatus: http.StatusBadRequest}
+	ErrDBResourceExists      = &Errno{Code: 4019, Msg: "同一资源备注下已登记同名数据库"}
+	ErrConnectionMissing     = &Errno{Code: 4020, Msg: "数据库资源未配置内省连接"}
+	ErrDriftReportNotFound   = &Errno{Code: 4021, Msg: "尚无表结构差异报告", HTTPStatus: http.StatusNotFound}
+	ErrNamingViolation       = &Errno{Code: 4022, Msg: "数据字典存在error级命名规范违规，不能确认入库"}
+	ErrTermNotFound          = &Errno{Code: 4023, Msg: "业务术语不存在", HTTPStatus: http.StatusNotFound}
+	ErrTermExists            = &Errno{Code: 4024, Msg: "同名业务术语已存在"}
+	ErrFieldNotFound         = &Errno{Code: 4025, Msg: "数据字典中不存在该字段", HTTPStatus: http.StatusNotFound}
+	ErrTermLinkNotFound      = &Errno{Code: 4026, Msg: "字段未关联业务术语", HTTPStatus: http.StatusNotFound}
+	ErrColumnMappingNotFound = &Errno{Code: 4027, Msg: "列映射方案不存在", HTTPStatus: http.StatusNotFound}
+	ErrColumnMappingExists   = &Errno{Code: 4028, Msg: "同名列映射方案已存在"}
 
 	ErrMinioUploadFailed   = &Errno{Code: 5001, Msg: "MinIO上传失败"}
 	ErrMinioDownloadFailed = &Errno{Code: 5002, Msg: "MinIO下载失败"}
+	ErrIntrospectFailed    = &Errno{Code: 5003, Msg: "读取数据库表结构失败"}
+
+	ErrUserNotFound = &Errno{Code: 6001, Msg: "用户不存在", HTTPStatus: http.StatusNotFound}
+	ErrUserExists   = &Errno{Code: 6002, Msg: "同名用户已存在", HTTPStatus: http.StatusConflict}
+
+	ErrTooManyRequests = &Errno{Code: 7001, Msg: "请求过于频繁，请稍后重试", HTTPStatus: http.StatusTooManyRequests}
 )
 
+// Status 返回HTTP状态码（未显式指定时按错误码分类推断）
+//
+//	400/1xxx 参数与文件校验错误 -> 400
+//	2xxx/3xxx 数据库与缓存错误 -> 500
+//	4xxx     任务状态冲突     -> 409
+//	5xxx     对象存储与外部数据库错误 -> 502
+func (e *Errno) Status() int {
+	if e.HTTPStatus != 0 {
+		return e.HTTPStatus
+	}
+	switch {
+	case e.Code == 400, e.Code >= 1000 && e.Code < 2000:
+		return http.StatusBadRequest
+	case e.Code >= 4000 && e.Code < 5000:
+		return http.StatusConflict
+	case e.Code >= 5000 && e.Code < 6000:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // WithMessage 为错误添加自定义消息（不改变错误码）
 func (e *Errno) WithMessage(msg string) *Errno {
-	return &Errno{
-		Code: e.Code,
-		Msg:  fmt.Sprintf("%s: %s", e.Msg, msg),
-	}
+	c := e.clone()
+	c.Msg = fmt.Sprintf("%s: %s", e.Msg, msg)
+	return c
+}
+
+// WithCause 附加原始错误（仅用于日志，不返回给客户端）
+func (e *Errno) WithCause(cause error) *Errno {
+	c := e.clone()
+	c.cause = cause
+	return c
+}
+
+// WithDetails 附加结构化错误详情（返回给客户端）
+func (e *Errno) WithDetails(details interface{}) *Errno {
+	c := e.clone()
+	c.Details = details
+	return c
+}
+
+// clone 复制错误（预定义错误为共享变量，不能直接修改）
+func (e *Errno) clone() *Errno {
+	c := *e
+	return &c
 }
@@ -0,0 +1,57 @@
+package errno
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Errno
+		want int
+	}{
+		{"参数错误", ErrInvalidParam, http.StatusBadRequest},
+		{"文件校验错误", ErrExcelColumnMissing, http.StatusBadRequest},
+		{"数据库错误", ErrDBQueryFailed, http.StatusInternalServerError},
+		{"缓存错误", ErrRedisGetFailed, http.StatusInternalServerError},
+		{"任务状态冲突", ErrTaskAlreadyDecided, http.StatusConflict},
+		{"对象存储错误", ErrMinioUploadFailed, http.StatusBadGateway},
+		{"服务器内部错误", ErrInternalServer, http.StatusInternalServerError},
+		{"显式状态码优先于分类", ErrTaskNotFound, http.StatusNotFound},
+		{"显式状态码（1xxx）", ErrFileTooLarge, http.StatusRequestEntityTooLarge},
+		{"未分类错误码", &Errno{Code: 9001}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Status(); got != tt.want {
+				t.Errorf("Status() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithXxxKeepsPredefinedErrorUnchanged(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := ErrDBQueryFailed.WithMessage("查询任务").WithCause(cause).WithDetails(map[string]string{"id": "1"})
+
+	if ErrDBQueryFailed.Msg != "数据库查询失败" || ErrDBQueryFailed.Details != nil || ErrDBQueryFailed.Cause() != nil {
+		t.Fatalf("预定义错误被修改：%+v", ErrDBQueryFailed)
+	}
+	if err.Msg != "数据库查询失败: 查询任务" {
+		t.Errorf("Msg = %q", err.Msg)
+	}
+	if !errors.Is(err, ErrDBQueryFailed) {
+		t.Error("派生错误应与预定义错误errors.Is相等")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("派生错误应可穿透到原始错误")
+	}
+	if errors.Is(err, ErrDBUpdateFailed) {
+		t.Error("错误码不同的错误不应相等")
+	}
+	if err.Status() != http.StatusInternalServerError {
+		t.Errorf("Status() = %d", err.Status())
+	}
+}
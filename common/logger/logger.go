@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"os"
+)
+
+// 日志字段名（API与Worker保持一致，便于按字段关联检索）
+const (
+	KeyRequestID = "request_id"    // 发起请求的ID（HTTP请求头X-Request-ID）
+	KeyDictTask  = "dict_task_id"  // 关联的DictionaryTask ID
+	KeyAsynqTask = "asynq_task_id" // Asynq任务ID
+	KeyTraceID   = "trace_id"      // 链路追踪ID
+)
+
+// ctxKey 上下文键类型（避免与其他包冲突）
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	dictTaskIDKey
+	clientIPKey
+)
+
+// New 初始化结构化日志（生产环境输出JSON，其他环境输出文本）
+func New(env string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: slog.LevelInfo}
+
+	var h slog.Handler
+	if env == "prod" {
+		h = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		opts.Level = slog.LevelDebug
+		h = slog.NewTextHandler(os.Stdout, opts)
+	}
+	return slog.New(&contextHandler{Handler: h})
+}
+
+// WithRequestID 将请求ID写入上下文
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID 从上下文读取请求ID
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithDictTaskID 将DictionaryTask ID写入上下文
+func WithDictTaskID(ctx context.Context, dictTaskID string) context.Context {
+	return context.WithValue(ctx, dictTaskIDKey, dictTaskID)
+}
+
+// DictTaskID 从上下文读取DictionaryTask ID
+func DictTaskID(ctx context.Context) string {
+	id, _ := ctx.Value(dictTaskIDKey).(string)
+	return id
+}
+
+// WithClientIP 将客户端IP写入上下文（供审计日志记录）
+func WithClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPKey, clientIP)
+}
+
+// ClientIP 从上下文读取客户端IP
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
+
+// contextHandler 输出日志时自动附加上下文中的请求ID、任务ID与链路ID（需使用XxxContext方法）
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle 实现slog.Handler
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(KeyRequestID, id))
+	}
+	if id := DictTaskID(ctx); id != "" {
+		r.AddAttrs(slog.String(KeyDictTask, id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		r.AddAttrs(slog.String(KeyTraceID, sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs 实现slog.Handler（保持包装，避免丢失上下文字段）
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup 实现slog.Handler
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
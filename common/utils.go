@@ -2,6 +2,7 @@ package common
 
 import (
 	"encoding/json"
+	"path/filepath"
 	"strings"
 )
 
@@ -11,6 +12,15 @@ func IsExcelFile(filename string) bool {
 	return strings.HasSuffix(ext, ".xlsx") || strings.HasSuffix(ext, ".xls")
 }
 
+// SplitExcelName 拆分"系统名-dbname.xlsx"格式的文件名，返回系统名与库名（格式不符时ok为false）
+func SplitExcelName(filename string) (systemName, dbName string, ok bool) {
+	parts := strings.Split(filename, "-")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], strings.TrimSuffix(parts[1], filepath.Ext(parts[1])), true
+}
+
 // Paginate 通用分页处理（输入总条数、页码、页大小，返回分页信息）
 func Paginate(total, page, size int) (int, int, map[string]int) {
 	if page < 1 {
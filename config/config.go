@@ -0,0 +1,337 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config 应用全局配置（API服务与Worker共用）
+type Config struct {
+	Env        string           // 运行环境（dev/test/prod）
+	HTTP       HTTPConfig       // API服务配置
+	Worker     WorkerConfig     // Worker进程配置
+	MySQL      MySQLConfig      // MySQL配置
+	Redis      RedisConfig      // Redis配置
+	Minio      MinioConfig      // MinIO配置
+	Health     HealthConfig     // 健康检查配置
+	Tracing    TracingConfig    // 链路追踪配置
+	Auth       AuthConfig       // 认证配置
+	Upload     UploadConfig     // 上传限制
+	RateLimit  RateLimitConfig  // 限流配置
+	CORS       CORSConfig       // 跨域配置
+	Introspect IntrospectConfig // 数据库内省配置
+	Drift      DriftConfig      // 表结构差异检查配置
+	Naming     NamingConfig     // 命名规范检查配置
+}
+
+// HTTPConfig API服务配置
+type HTTPConfig struct {
+	Addr           string   // 监听地址
+	TrustedProxies []string // 可信代理的IP或CIDR（仅信任其转发的X-Forwarded-For，为空时以连接对端地址作为客户端IP）
+}
+
+// WorkerConfig Worker进程配置
+type WorkerConfig struct {
+	Concurrency int    // 并发消费数
+	HealthAddr  string // 健康检查监听地址
+}
+
+// MySQLConfig MySQL配置
+type MySQLConfig struct {
+	DSN string
+}
+
+// RedisConfig Redis配置（缓存与Asynq共用）
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+// MinioConfig MinIO配置
+type MinioConfig struct {
+	Endpoint    string
+	AccessKey   string
+	SecretKey   string
+	Secure      bool
+	ExcelBucket string // 存放上传Excel的桶
+	CSVBucket   string // 存放解析后CSV的桶
+	DocsBucket  string // 存放生成的数据字典文档（首次生成时创建，不参与健康检查）
+
+	PublicEndpoint string // 浏览器直传使用的地址（为空时使用Endpoint签发预签名URL）
+	PublicSecure   bool   // 浏览器直传地址是否使用HTTPS
+	Region         string // 区域（签发预签名URL时使用，避免查询桶区域）
+}
+
+// Buckets 返回业务使用的所有桶名
+func (c MinioConfig) Buckets() []string {
+	return []string{c.ExcelBucket, c.CSVBucket}
+}
+
+// HealthConfig 健康检查配置
+type HealthConfig struct {
+	Timeout time.Duration // 单个依赖检查的超时时间
+}
+
+// TracingConfig 链路追踪配置
+type TracingConfig struct {
+	Exporter    string  // 导出器：none/stdout/otlp
+	Endpoint    string  // OTLP/HTTP地址（如本地Collector的127.0.0.1:4318）
+	Insecure    bool    // 是否使用明文HTTP
+	SampleRatio float64 // 采样率（0~1，上游已采样的链路始终保留）
+}
+
+// AuthConfig 认证配置
+type AuthConfig struct {
+	Providers         []string // 启用的认证方式（jwt/local，按顺序尝试；为空时不启用认证）
+	JWTIssuer         string   // JWT签发方
+	JWTAudience       string   // JWT受众
+	JWTSecret         string   // JWT HMAC密钥
+	JWTPublicKeyFiles []string // JWT公钥PEM文件（文件名即kid）
+	JWTUsernameClaim  string   // 作为登录名的JWT声明
+	JWTRolesClaim     string   // 作为角色的JWT声明
+	BootstrapUser     string   // 本地用户表为空时创建的初始用户名
+	BootstrapPassword string   // 初始用户密码
+}
+
+// UploadConfig 上传限制
+type UploadConfig struct {
+	MaxRequestBytes int64 // 上传请求体上限（超出时在读取前拒绝）
+	MaxFileBytes    int64 // 单个文件上限
+	MaxMemoryBytes  int64 // multipart解析的内存缓冲上限（超出部分写入临时文件）
+	MaxBatchFiles   int   // 批量上传单批最多的Excel个数（含ZIP解压后的文件）
+	MaxBatchBytes   int64 // 批量上传单批Excel解压后的总字节数上限
+
+	SessionMaxBytes  int64         // 直传会话允许的文件上限（不超过MaxFileBytes，完成上传时需整体读入校验）
+	SessionPartBytes int64         // 直传分片大小（S3要求除最后一片外不小于5MB）
+	SessionTTL       time.Duration // 直传会话有效期（过期后不能再完成上传）
+	PresignExpiry    time.Duration // 预签名URL有效期
+
+	SessionCleanupSchedule string // 清理过期直传会话（取消分片上传、删除暂存对象）的cron表达式（为空时不清理；环境变量设置为"-"表示关闭）
+}
+
+// RateLimitConfig 按用户/IP的令牌桶限流配置
+type RateLimitConfig struct {
+	Enabled           bool          // 是否启用
+	UploadPerMinute   float64       // 上传接口每分钟补充的令牌数
+	UploadBurst       int           // 上传接口令牌桶容量（允许的突发请求数）
+	ConfirmPerMinute  float64       // 审批接口每分钟补充的令牌数
+	ConfirmBurst      int           // 审批接口令牌桶容量
+	AuthFailPerMinute float64       // 每个客户端IP每分钟恢复的认证失败次数
+	AuthFailBurst     int           // 每个客户端IP允许连续认证失败的次数
+	IdleTTL           time.Duration // 客户端空闲多久后回收其令牌桶
+}
+
+// CORSConfig 跨域配置
+// 来源支持精确匹配（https://a.example.com）与单个通配符（https://*.example.com、http://localhost:*）；
+// "*"表示允许任意来源，此时不允许携带凭证
+type CORSConfig struct {
+	AllowOrigins     []string      // 允许的来源（为空时拒绝所有跨域请求）
+	AllowMethods     []string      // 允许的请求方法
+	AllowHeaders     []string      // 允许的请求头
+	ExposeHeaders    []string      // 允许前端读取的响应头
+	AllowCredentials bool          // 是否允许携带凭证
+	MaxAge           time.Duration // 预检结果缓存时长
+}
+
+// IntrospectConfig 从已登记的数据库连接读取表结构的配置
+type IntrospectConfig struct {
+	Timeout   time.Duration // 单次读取表结构的超时时间
+	SQLiteDir string        // 允许内省的SQLite文件所在目录（为空时不允许内省SQLite）
+}
+
+// DriftConfig 数据字典与数据库实际表结构的差异检查配置
+type DriftConfig struct {
+	Schedule string // 定时检查的cron表达式（按本地时区，为空时不定时检查；环境变量设置为"-"表示关闭）
+}
+
+// NamingConfig 解析Excel时的命名规范检查配置
+type NamingConfig struct {
+	RulesFile    string // 规则集JSON文件（为空时使用内置默认规则）
+	BlockOnError bool   // 存在error级违规时是否禁止确认入库
+}
+
+// Load 从环境变量加载配置（未设置时使用开发环境默认值）
+func Load() *Config {
+	env := getEnv("APP_ENV", "dev")
+	return &Config{
+		Env: env,
+		HTTP: HTTPConfig{
+			Addr:           getEnv("HTTP_ADDR", ":8080"),
+			TrustedProxies: getEnvList("HTTP_TRUSTED_PROXIES", nil),
+		},
+		Worker: WorkerConfig{
+			Concurrency: getEnvInt("WORKER_CONCURRENCY", 5),
+			HealthAddr:  getEnv("WORKER_HEALTH_ADDR", ":8081"),
+		},
+		MySQL: MySQLConfig{
+			DSN: getEnv("MYSQL_DSN", "root:123456@tcp(127.0.0.1:3306)/customs?parseTime=true&charset=utf8mb4"),
+		},
+		Redis: RedisConfig{
+			Addr:     getEnv("REDIS_ADDR", "127.0.0.1:6379"),
+			Password: getEnv("REDIS_PASSWORD", ""),
+			DB:       getEnvInt("REDIS_DB", 0),
+		},
+		Minio: MinioConfig{
+			Endpoint:    getEnv("MINIO_ENDPOINT", "127.0.0.1:9000"),
+			AccessKey:   getEnv("MINIO_ACCESS_KEY", "minioadmin"),
+			SecretKey:   getEnv("MINIO_SECRET_KEY", "minioadmin"),
+			Secure:      getEnvBool("MINIO_SECURE", false),
+			ExcelBucket: getEnv("MINIO_EXCEL_BUCKET", "sjdt-update-dictionary-config-excel"),
+			CSVBucket:   getEnv("MINIO_CSV_BUCKET", "csv-bucket"),
+			DocsBucket:  getEnv("MINIO_DOCS_BUCKET", "dictionary-docs"),
+
+			PublicEndpoint: getEnv("MINIO_PUBLIC_ENDPOINT", ""),
+			PublicSecure:   getEnvBool("MINIO_PUBLIC_SECURE", false),
+			Region:         getEnv("MINIO_REGION", "us-east-1"),
+		},
+		Health: HealthConfig{
+			Timeout: getEnvDuration("HEALTH_TIMEOUT", 2*time.Second),
+		},
+		Tracing: TracingConfig{
+			Exporter:    getEnv("TRACING_EXPORTER", "none"),
+			Endpoint:    getEnv("TRACING_OTLP_ENDPOINT", "127.0.0.1:4318"),
+			Insecure:    getEnvBool("TRACING_OTLP_INSECURE", true),
+			SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Auth: AuthConfig{
+			Providers:         getEnvList("AUTH_PROVIDERS", []string{"local"}),
+			JWTIssuer:         getEnv("AUTH_JWT_ISSUER", ""),
+			JWTAudience:       getEnv("AUTH_JWT_AUDIENCE", ""),
+			JWTSecret:         getEnv("AUTH_JWT_SECRET", ""),
+			JWTPublicKeyFiles: getEnvList("AUTH_JWT_PUBLIC_KEY_FILES", nil),
+			JWTUsernameClaim:  getEnv("AUTH_JWT_USERNAME_CLAIM", "preferred_username"),
+			JWTRolesClaim:     getEnv("AUTH_JWT_ROLES_CLAIM", "roles"),
+			BootstrapUser:     getEnv("AUTH_BOOTSTRAP_USER", "admin"),
+			BootstrapPassword: getEnv("AUTH_BOOTSTRAP_PASSWORD", ""),
+		},
+		Upload: UploadConfig{
+			MaxRequestBytes: getEnvInt64("UPLOAD_MAX_REQUEST_BYTES", 50<<20),
+			MaxFileBytes:    getEnvInt64("UPLOAD_MAX_FILE_BYTES", 20<<20),
+			MaxMemoryBytes:  getEnvInt64("UPLOAD_MAX_MEMORY_BYTES", 8<<20),
+			MaxBatchFiles:   getEnvInt("UPLOAD_MAX_BATCH_FILES", 100),
+			MaxBatchBytes:   getEnvInt64("UPLOAD_MAX_BATCH_BYTES", 200<<20),
+
+			SessionMaxBytes:  getEnvInt64("UPLOAD_SESSION_MAX_BYTES", 20<<20),
+			SessionPartBytes: getEnvInt64("UPLOAD_SESSION_PART_BYTES", 8<<20),
+			SessionTTL:       getEnvDuration("UPLOAD_SESSION_TTL", 24*time.Hour),
+			PresignExpiry:    getEnvDuration("UPLOAD_PRESIGN_EXPIRY", time.Hour),
+
+			SessionCleanupSchedule: getEnvSchedule("UPLOAD_SESSION_CLEANUP_SCHEDULE", "*/30 * * * *"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:           getEnvBool("RATE_LIMIT_ENABLED", true),
+			UploadPerMinute:   getEnvFloat("RATE_LIMIT_UPLOAD_PER_MINUTE", 12),
+			UploadBurst:       getEnvInt("RATE_LIMIT_UPLOAD_BURST", 5),
+			ConfirmPerMinute:  getEnvFloat("RATE_LIMIT_CONFIRM_PER_MINUTE", 60),
+			ConfirmBurst:      getEnvInt("RATE_LIMIT_CONFIRM_BURST", 10),
+			AuthFailPerMinute: getEnvFloat("RATE_LIMIT_AUTH_FAIL_PER_MINUTE", 5),
+			AuthFailBurst:     getEnvInt("RATE_LIMIT_AUTH_FAIL_BURST", 10),
+			IdleTTL:           getEnvDuration("RATE_LIMIT_IDLE_TTL", 10*time.Minute),
+		},
+		CORS: CORSConfig{
+			AllowOrigins:     getEnvList("CORS_ALLOW_ORIGINS", defaultCORSOrigins(env)),
+			AllowMethods:     getEnvList("CORS_ALLOW_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
+			AllowHeaders:     getEnvList("CORS_ALLOW_HEADERS", []string{"Origin", "Content-Type", "Authorization", "X-Request-ID"}),
+			ExposeHeaders:    getEnvList("CORS_EXPOSE_HEADERS", []string{"Content-Length", "Content-Disposition", "Location", "Retry-After", "X-Request-ID"}),
+			AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", true),
+			MaxAge:           getEnvDuration("CORS_MAX_AGE", 12*time.Hour),
+		},
+		Introspect: IntrospectConfig{
+			Timeout:   getEnvDuration("INTROSPECT_TIMEOUT", 30*time.Second),
+			SQLiteDir: getEnv("INTROSPECT_SQLITE_DIR", ""),
+		},
+		Naming: NamingConfig{
+			RulesFile:    getEnv("NAMING_RULES_FILE", ""),
+			BlockOnError: getEnvBool("NAMING_BLOCK_ON_ERROR", false),
+		},
+		Drift: DriftConfig{
+			Schedule: getEnvSchedule("DRIFT_SCHEDULE", "0 3 * * *"),
+		},
+	}
+}
+
+// defaultCORSOrigins 各环境默认允许的跨域来源：
+// 开发环境放行本机任意端口的前端；测试与生产环境必须通过CORS_ALLOW_ORIGINS显式配置
+func defaultCORSOrigins(env string) []string {
+	if env == "dev" {
+		return []string{"http://localhost:*", "http://127.0.0.1:*"}
+	}
+	return nil
+}
+
+// getEnv 读取字符串环境变量
+func getEnv(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// getEnvList 读取逗号分隔的列表环境变量（设置为"-"表示空列表）
+func getEnvList(key string, def []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	if v == "-" {
+		return nil
+	}
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvSchedule 读取cron表达式环境变量（设置为"-"表示关闭，返回空字符串）
+func getEnvSchedule(key, def string) string {
+	if v := getEnv(key, def); v != "-" {
+		return v
+	}
+	return ""
+}
+
+// getEnvInt 读取整数环境变量（解析失败时使用默认值）
+func getEnvInt(key string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// getEnvInt64 读取64位整数环境变量（解析失败时使用默认值）
+func getEnvInt64(key string, def int64) int64 {
+	if v, err := strconv.ParseInt(os.Getenv(key), 10, 64); err == nil {
+		return v
+	}
+	return def
+}
+
+// getEnvBool 读取布尔环境变量（解析失败时使用默认值）
+func getEnvBool(key string, def bool) bool {
+	if v, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// getEnvFloat 读取浮点数环境变量（解析失败时使用默认值）
+func getEnvFloat(key string, def float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return v
+	}
+	return def
+}
+
+// getEnvDuration 读取时长环境变量（如"2s"，解析失败时使用默认值）
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
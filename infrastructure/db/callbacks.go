@@ -0,0 +1,71 @@
+package db
+
+import (
+	"customs/infrastructure/metrics"
+	"customs/infrastructure/tracing"
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 指标中的后端名称
+const metricsBackend = "mysql"
+
+// 在GORM语句实例中保存开始时间与Span的键
+const (
+	instrumentStartKey = "instrument:start"
+	instrumentSpanKey  = "instrument:span"
+)
+
+// registerCallbacks 为增删改查注册前后置回调，记录SQL耗时指标与Span
+func registerCallbacks(db *gorm.DB) error {
+	before := func(operation string) func(tx *gorm.DB) {
+		return func(tx *gorm.DB) {
+			ctx, span := tracing.StartWithKind(tx.Statement.Context, "mysql."+operation, trace.SpanKindClient,
+				attribute.String("db.system", "mysql"),
+				attribute.String("db.operation", operation),
+				attribute.String("db.sql.table", tx.Statement.Table),
+			)
+			tx.Statement.Context = ctx
+			tx.InstanceSet(instrumentStartKey, time.Now())
+			tx.InstanceSet(instrumentSpanKey, span)
+		}
+	}
+	after := func(operation string) func(tx *gorm.DB) {
+		return func(tx *gorm.DB) {
+			err := tx.Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = nil // 未查到记录属于正常业务结果
+			}
+			if start, ok := tx.InstanceGet(instrumentStartKey); ok {
+				metrics.ObserveStorage(metricsBackend, operation, start.(time.Time), err)
+			}
+			if v, ok := tx.InstanceGet(instrumentSpanKey); ok {
+				span := v.(trace.Span)
+				span.SetAttributes(
+					attribute.String("db.statement", tx.Statement.SQL.String()),
+					attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+				)
+				tracing.End(span, err)
+			}
+		}
+	}
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("instrument:before_create", before("create")),
+		cb.Create().After("gorm:create").Register("instrument:after_create", after("create")),
+		cb.Query().Before("gorm:query").Register("instrument:before_query", before("query")),
+		cb.Query().After("gorm:query").Register("instrument:after_query", after("query")),
+		cb.Update().Before("gorm:update").Register("instrument:before_update", before("update")),
+		cb.Update().After("gorm:update").Register("instrument:after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register("instrument:before_delete", before("delete")),
+		cb.Delete().After("gorm:delete").Register("instrument:after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register("instrument:before_row", before("row")),
+		cb.Row().After("gorm:row").Register("instrument:after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register("instrument:before_raw", before("raw")),
+		cb.Raw().After("gorm:raw").Register("instrument:after_raw", after("raw")),
+	)
+}
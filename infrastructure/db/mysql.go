@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"customs/model"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -22,10 +23,25 @@ func NewMySQLClient(dsn string) (*MySQLClient, error) {
 		return nil, err
 	}
 
+	// 注册SQL指标与链路追踪回调
+	if err := registerCallbacks(db); err != nil {
+		return nil, err
+	}
+
 	// 自动创建/更新表结构（基于 Model 定义）
 	err = db.AutoMigrate(
 		&model.DictionaryTask{},
 		&model.DBResource{},
+		&model.User{},
+		&model.AuditLog{},
+		&model.DictionaryBatch{},
+		&model.UploadSession{},
+		&model.DictionaryField{},
+		&model.DriftReport{},
+		&model.GlossaryTerm{},
+		&model.TermLink{},
+		&model.TableRelation{},
+		&model.ColumnMapping{},
 	)
 	if err != nil {
 		return nil, err
@@ -39,6 +55,15 @@ func (c *MySQLClient) GetDB() *gorm.DB {
 	return c.db
 }
 
+// Ping 检查数据库连接是否可用
+func (c *MySQLClient) Ping(ctx context.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
 // WithTransaction 开启事务（可选，复杂业务用）
 func (c *MySQLClient) WithTransaction(fn func(tx *gorm.DB) error) error {
 	tx := c.db.Begin()
@@ -48,6 +73,7 @@ func (c *MySQLClient) WithTransaction(fn func(tx *gorm.DB) error) error {
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
+			panic(r) // 回滚后继续抛出，不能把panic当作成功返回
 		}
 	}()
 	if err := fn(tx); err != nil {
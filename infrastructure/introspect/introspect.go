@@ -0,0 +1,93 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// dsnEnvPrefix 连接串中允许引用的环境变量前缀（避免通过连接串读取其他敏感配置）
+const dsnEnvPrefix = "INTROSPECT_"
+
+// Column 数据库中的一个字段（按表、字段顺序返回）
+type Column struct {
+	Table        string // 表名
+	TableComment string // 表注释
+	Name         string // 字段名
+	Comment      string // 字段注释
+	DataType     string // 字段类型（如varchar(32)）
+	IsPrimaryKey bool   // 是否主键
+}
+
+// Options 内省连接的限制
+type Options struct {
+	SQLiteDir string // 允许内省的SQLite文件所在目录（为空时不允许内省SQLite）
+}
+
+// Introspector 读取数据库表结构（表、字段与注释）
+type Introspector interface {
+	// Columns 读取schema下所有表的字段；schema为空时使用连接的默认库
+	Columns(ctx context.Context, schema string) ([]Column, error)
+	// Close 关闭连接
+	Close() error
+}
+
+// Open 按资源类型（不区分大小写）打开内省连接，支持MySQL与SQLite
+// 连接串中的${INTROSPECT_XXX}会替换为同名环境变量，便于将口令保存在部署环境而非数据库中
+func Open(resourceType, dsn string, opts Options) (Introspector, error) {
+	if err := Validate(resourceType, dsn, opts); err != nil {
+		return nil, err
+	}
+	dsn = ExpandDSN(dsn)
+	switch strings.ToLower(strings.TrimSpace(resourceType)) {
+	case "mysql", "mariadb", "tidb":
+		return openMySQL(dsn)
+	default:
+		return openSQLite(dsn, opts.SQLiteDir)
+	}
+}
+
+// Validate 校验连接串：MySQL口令须以${INTROSPECT_XXX}引用环境变量（不在数据库中明文保存），
+// SQLite文件须位于配置的目录下
+func Validate(resourceType, dsn string, opts Options) error {
+	switch strings.ToLower(strings.TrimSpace(resourceType)) {
+	case "mysql", "mariadb", "tidb":
+		return checkMySQLPassword(dsn)
+	case "sqlite", "sqlite3":
+		_, err := sqliteDSN(ExpandDSN(dsn), opts.SQLiteDir)
+		return err
+	default:
+		return fmt.Errorf("不支持内省的资源类型：%s", resourceType)
+	}
+}
+
+// Supported 资源类型是否支持内省
+func Supported(resourceType string) bool {
+	switch strings.ToLower(strings.TrimSpace(resourceType)) {
+	case "mysql", "mariadb", "tidb", "sqlite", "sqlite3":
+		return true
+	}
+	return false
+}
+
+// ExpandDSN 替换连接串中以INTROSPECT_为前缀的环境变量引用，其他引用替换为空
+func ExpandDSN(dsn string) string {
+	return os.Expand(dsn, func(key string) string {
+		if !strings.HasPrefix(key, dsnEnvPrefix) {
+			return ""
+		}
+		return os.Getenv(key)
+	})
+}
+
+// sqlIntrospector 基于database/sql的内省器公共部分
+type sqlIntrospector struct {
+	db *sql.DB
+}
+
+// Close 关闭连接
+func (i *sqlIntrospector) Close() error {
+	return i.db.Close()
+}
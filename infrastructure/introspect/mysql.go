@@ -0,0 +1,67 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/go-sql-driver/mysql" // MySQL驱动
+)
+
+// passwordRefPattern 口令须为对INTROSPECT_前缀环境变量的引用
+var passwordRefPattern = regexp.MustCompile(`^\$(\{` + dsnEnvPrefix + `\w+\}|` + dsnEnvPrefix + `\w+)$`)
+
+// mysqlColumnsQuery 从information_schema读取表与字段注释（仅基表，不含视图）
+const mysqlColumnsQuery = `
+SELECT c.TABLE_NAME, t.TABLE_COMMENT, c.COLUMN_NAME, c.COLUMN_COMMENT, c.COLUMN_TYPE, c.COLUMN_KEY = 'PRI'
+FROM information_schema.COLUMNS c
+JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+WHERE c.TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND t.TABLE_TYPE = 'BASE TABLE'
+ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`
+
+// mysqlIntrospector MySQL内省器
+type mysqlIntrospector struct {
+	sqlIntrospector
+}
+
+// openMySQL 打开MySQL连接（DSN格式同go-sql-driver/mysql）
+func openMySQL(dsn string) (Introspector, error) {
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlIntrospector{sqlIntrospector{db: db}}, nil
+}
+
+// checkMySQLPassword 校验连接串中的口令为空或为环境变量引用
+func checkMySQLPassword(dsn string) error {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return fmt.Errorf("MySQL连接串格式错误：%w", err)
+	}
+	if cfg.Passwd != "" && !passwordRefPattern.MatchString(cfg.Passwd) {
+		return errors.New("连接串中的口令须以${" + dsnEnvPrefix + "XXX}引用环境变量，不能明文保存")
+	}
+	return nil
+}
+
+// Columns 读取schema下所有基表的字段
+func (i *mysqlIntrospector) Columns(ctx context.Context, schema string) ([]Column, error) {
+	rows, err := i.db.QueryContext(ctx, mysqlColumnsQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []Column
+	for rows.Next() {
+		var c Column
+		if err := rows.Scan(&c.Table, &c.TableComment, &c.Name, &c.Comment, &c.DataType, &c.IsPrimaryKey); err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	return columns, rows.Err()
+}
@@ -0,0 +1,130 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3" // SQLite驱动（需启用cgo，未启用时打开连接返回错误）
+)
+
+// sqliteIntrospector SQLite内省器（SQLite不支持表与字段注释，注释均为空）
+type sqliteIntrospector struct {
+	sqlIntrospector
+}
+
+// openSQLite 以只读方式打开SQLite数据库文件（DSN为文件路径或file: URI，文件须位于dir下）
+func openSQLite(dsn, dir string) (Introspector, error) {
+	dsn, err := sqliteDSN(dsn, dir)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteIntrospector{sqlIntrospector{db: db}}, nil
+}
+
+// sqliteDSN 生成只读的file: URI：忽略调用方指定的mode参数，始终以mode=ro打开；
+// 相对路径按dir解析，解析符号链接后须仍位于dir下
+func sqliteDSN(dsn, dir string) (string, error) {
+	if dir == "" {
+		return "", errors.New("未配置INTROSPECT_SQLITE_DIR，不允许内省SQLite数据库")
+	}
+	path, query, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?")
+	if path == "" || strings.ContainsAny(path, "#%") {
+		return "", fmt.Errorf("SQLite文件路径无效：%q", path)
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return "", fmt.Errorf("SQLite连接参数无效：%w", err)
+	}
+	params.Set("mode", "ro")
+
+	root, err := realPath(dir)
+	if err != nil {
+		return "", fmt.Errorf("SQLite目录无效：%w", err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path, err = realPath(path)
+	if err != nil {
+		return "", fmt.Errorf("SQLite文件路径无效：%w", err)
+	}
+	if rel, err := filepath.Rel(root, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("SQLite文件须位于%s下", dir)
+	}
+	return "file:" + path + "?" + params.Encode(), nil
+}
+
+// realPath 返回解析符号链接后的绝对路径（文件尚不存在时按字面路径）
+func realPath(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, nil
+	}
+	return resolved, err
+}
+
+// Columns 读取所有用户表的字段（schema参数对SQLite无意义，忽略）
+func (i *sqliteIntrospector) Columns(ctx context.Context, _ string) ([]Column, error) {
+	tables, err := i.tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []Column
+	for _, table := range tables {
+		rows, err := i.db.QueryContext(ctx, "SELECT name, type, pk FROM pragma_table_info(?) ORDER BY cid", table)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			c := Column{Table: table}
+			var pk int
+			if err := rows.Scan(&c.Name, &c.DataType, &pk); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			c.IsPrimaryKey = pk > 0
+			columns = append(columns, c)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+// tables 按名称排序返回用户表（排除sqlite_内部表）
+func (i *sqliteIntrospector) tables(ctx context.Context) ([]string, error) {
+	rows, err := i.db.QueryContext(ctx,
+		"SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite\\_%' ESCAPE '\\' ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// 指标命名空间
+const namespace = "customs"
+
+// 任务执行结果
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+var (
+	// HTTPRequestsTotal HTTP请求数（按路由、方法、状态码）
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP请求总数",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration HTTP请求耗时（按路由、方法）
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP请求耗时（秒）",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// UploadSizeBytes 上传的Excel文件大小
+	UploadSizeBytes = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upload_size_bytes",
+		Help:      "上传的Excel文件大小（字节）",
+		Buckets:   prometheus.ExponentialBuckets(16*1024, 4, 8), // 16KB ~ 256MB
+	})
+
+	// TaskDuration 异步任务执行耗时（按任务类型、队列、结果）
+	TaskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "异步任务执行耗时（秒）",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"task_type", "queue", "outcome"})
+
+	// TasksProcessedTotal 异步任务执行次数（按任务类型、队列、结果）
+	TasksProcessedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_processed_total",
+		Help:      "异步任务执行总数",
+	}, []string{"task_type", "queue", "outcome"})
+
+	// RowsParsedTotal Excel解析出的数据行数
+	RowsParsedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rows_parsed_total",
+		Help:      "Excel解析出的数据行总数",
+	})
+
+	// RowsInsertedTotal 入库的数据字典行数
+	RowsInsertedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rows_inserted_total",
+		Help:      "入库的数据字典行总数",
+	})
+
+	// StorageDuration 存储调用耗时（按后端、操作）
+	StorageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "storage_duration_seconds",
+		Help:      "MinIO/Redis/MySQL调用耗时（秒）",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5},
+	}, []string{"backend", "operation"})
+
+	// StorageErrorsTotal 存储调用失败次数（按后端、操作）
+	StorageErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "storage_errors_total",
+		Help:      "MinIO/Redis/MySQL调用失败总数",
+	}, []string{"backend", "operation"})
+
+	// RateLimitedTotal 被限流拒绝的请求数（按限流器）
+	RateLimitedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "被限流拒绝的HTTP请求总数",
+	}, []string{"limiter"})
+)
+
+// ObserveStorage 记录一次存储调用的耗时与结果（ignore中的错误不计为失败，如缓存未命中）
+func ObserveStorage(backend, operation string, start time.Time, err error, ignore ...error) {
+	StorageDuration.WithLabelValues(backend, operation).Observe(time.Since(start).Seconds())
+	if err == nil {
+		return
+	}
+	for _, target := range ignore {
+		if errors.Is(err, target) {
+			return
+		}
+	}
+	StorageErrorsTotal.WithLabelValues(backend, operation).Inc()
+}
+
+// ObserveTask 记录一次异步任务的耗时与结果
+func ObserveTask(taskType, queue string, start time.Time, err error) {
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeFailure
+	}
+	TaskDuration.WithLabelValues(taskType, queue, outcome).Observe(time.Since(start).Seconds())
+	TasksProcessedTotal.WithLabelValues(taskType, queue, outcome).Inc()
+}
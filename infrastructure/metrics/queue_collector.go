@@ -0,0 +1,52 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// QueueDepthFunc 查询各队列各状态的任务数（queue -> state -> count）
+type QueueDepthFunc func() (map[string]map[string]int, error)
+
+// queueCollector 在每次抓取时实时查询队列深度
+type queueCollector struct {
+	depthFunc QueueDepthFunc
+	depthDesc *prometheus.Desc
+	errDesc   *prometheus.Desc
+}
+
+// RegisterQueueCollector 注册队列深度采集器（API与Worker各自注册一次）
+func RegisterQueueCollector(depthFunc QueueDepthFunc) error {
+	return prometheus.Register(&queueCollector{
+		depthFunc: depthFunc,
+		depthDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "queue", "tasks"),
+			"Asynq队列中各状态的任务数",
+			[]string{"queue", "state"}, nil,
+		),
+		errDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "queue", "scrape_error"),
+			"查询队列深度是否失败（1=失败）",
+			nil, nil,
+		),
+	})
+}
+
+// Describe 实现prometheus.Collector
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.depthDesc
+	ch <- c.errDesc
+}
+
+// Collect 实现prometheus.Collector
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	depths, err := c.depthFunc()
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(c.errDesc, prometheus.GaugeValue, 1)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.errDesc, prometheus.GaugeValue, 0)
+
+	for queue, states := range depths {
+		for state, count := range states {
+			ch <- prometheus.MustNewConstMetric(c.depthDesc, prometheus.GaugeValue, float64(count), queue, state)
+		}
+	}
+}
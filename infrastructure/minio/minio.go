@@ -2,21 +2,32 @@ package minio
 
 import (
 	"context"
+	"customs/infrastructure/metrics"
+	"customs/infrastructure/tracing"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"io"
+	"time"
 )
 
+// 指标中的后端名称
+const metricsBackend = "minio"
+
 // Client 通用 MinIO 客户端
 type Client struct {
-	client *minio.Client
-	ctx    context.Context
+	client    *minio.Client
+	core      *minio.Core   // 底层S3接口（分片上传）
+	presigner *minio.Client // 生成预签名URL的客户端（默认与client相同）
+	creds     *credentials.Credentials
 }
 
 // NewMinioClient 初始化 MinIO 连接
 func NewMinioClient(endpoint, accessKey, secretKey string, secure bool) (*Client, error) {
+	creds := credentials.NewStaticV4(accessKey, secretKey, "")
 	client, err := minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Creds:  creds,
 		Secure: secure,
 	})
 	if err != nil {
@@ -24,33 +35,46 @@ func NewMinioClient(endpoint, accessKey, secretKey string, secure bool) (*Client
 	}
 
 	// 测试连接
-	ctx := context.Background()
-	if _, err := client.ListBuckets(ctx); err != nil {
+	if _, err := client.ListBuckets(context.Background()); err != nil {
 		return nil, err
 	}
 
 	return &Client{
-		client: client,
-		ctx:    ctx,
+		client:    client,
+		core:      &minio.Core{Client: client},
+		presigner: client,
+		creds:     creds,
 	}, nil
 }
 
-// UploadFile 上传文件到 MinIO
-func (c *Client) UploadFile(bucketName, objectName string, reader io.Reader, size int64) error {
-	// 先检查桶是否存在，不存在则创建
-	exists, err := c.client.BucketExists(c.ctx, bucketName)
+// UsePublicEndpoint 使用浏览器可访问的地址生成预签名URL（签名包含Host，服务端内网地址签出的URL浏览器无法使用）
+// 需显式指定region，避免生成签名时向公网地址查询桶所在区域
+func (c *Client) UsePublicEndpoint(endpoint string, secure bool, region string) error {
+	presigner, err := minio.New(endpoint, &minio.Options{
+		Creds:  c.creds,
+		Secure: secure,
+		Region: region,
+	})
 	if err != nil {
 		return err
 	}
-	if !exists {
-		if err := c.client.MakeBucket(c.ctx, bucketName, minio.MakeBucketOptions{}); err != nil {
-			return err
-		}
+	c.presigner = presigner
+	return nil
+}
+
+// UploadFile 上传文件到 MinIO
+func (c *Client) UploadFile(ctx context.Context, bucketName, objectName string, reader io.Reader, size int64) (err error) {
+	ctx, done := observe(ctx, "upload", bucketName, objectName)
+	defer func() { done(err) }()
+
+	// 先检查桶是否存在，不存在则创建
+	if err := c.ensureBucket(ctx, bucketName); err != nil {
+		return err
 	}
 
 	// 上传文件
 	_, err = c.client.PutObject(
-		c.ctx,
+		ctx,
 		bucketName,
 		objectName,
 		reader,
@@ -63,15 +87,54 @@ func (c *Client) UploadFile(bucketName, objectName string, reader io.Reader, siz
 }
 
 // DownloadFile 从 MinIO 下载文件
-func (c *Client) DownloadFile(bucketName, objectName string) (io.Reader, error) {
-	obj, err := c.client.GetObject(c.ctx, bucketName, objectName, minio.GetObjectOptions{})
+func (c *Client) DownloadFile(ctx context.Context, bucketName, objectName string) (_ io.Reader, err error) {
+	ctx, done := observe(ctx, "download", bucketName, objectName)
+	defer func() { done(err) }()
+
+	obj, err := c.client.GetObject(ctx, bucketName, objectName, minio.GetObjectOptions{})
 	if err != nil {
 		return nil, err
 	}
 	return obj, nil
 }
 
+// BucketExists 检查桶是否存在
+func (c *Client) BucketExists(ctx context.Context, bucketName string) (_ bool, err error) {
+	ctx, done := observe(ctx, "bucket_exists", bucketName, "")
+	defer func() { done(err) }()
+
+	return c.client.BucketExists(ctx, bucketName)
+}
+
 // DeleteFile 删除 MinIO 文件
-func (c *Client) DeleteFile(bucketName, objectName string) error {
-	return c.client.RemoveObject(c.ctx, bucketName, objectName, minio.RemoveObjectOptions{})
+func (c *Client) DeleteFile(ctx context.Context, bucketName, objectName string) (err error) {
+	ctx, done := observe(ctx, "delete", bucketName, objectName)
+	defer func() { done(err) }()
+
+	return c.client.RemoveObject(ctx, bucketName, objectName, minio.RemoveObjectOptions{})
+}
+
+// ensureBucket 桶不存在时创建
+func (c *Client) ensureBucket(ctx context.Context, bucketName string) error {
+	exists, err := c.client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return c.client.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{})
+	}
+	return nil
+}
+
+// observe 开启一次MinIO调用的Span，返回结束函数（记录耗时指标、错误并结束Span）
+func observe(ctx context.Context, operation, bucketName, objectName string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracing.StartWithKind(ctx, "minio."+operation, trace.SpanKindClient,
+		attribute.String("minio.bucket", bucketName),
+		attribute.String("minio.object", objectName),
+	)
+	return ctx, func(err error) {
+		metrics.ObserveStorage(metricsBackend, operation, start, err)
+		tracing.End(span, err)
+	}
 }
@@ -0,0 +1,146 @@
+package minio
+
+import (
+	"context"
+	"github.com/minio/minio-go/v7"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// UploadedPart 已上传的分片
+type UploadedPart struct {
+	PartNumber int    `json:"part_number"`
+	Size       int64  `json:"size"`
+	ETag       string `json:"-"`
+}
+
+// PresignPutObject 生成单次PUT上传的预签名URL
+func (c *Client) PresignPutObject(ctx context.Context, bucketName, objectName string, expires time.Duration) (_ string, err error) {
+	ctx, done := observe(ctx, "presign_put", bucketName, objectName)
+	defer func() { done(err) }()
+
+	if err := c.ensureBucket(ctx, bucketName); err != nil {
+		return "", err
+	}
+	u, err := c.presigner.PresignedPutObject(ctx, bucketName, objectName, expires)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// NewMultipartUpload 发起分片上传，返回uploadID
+func (c *Client) NewMultipartUpload(ctx context.Context, bucketName, objectName string) (_ string, err error) {
+	ctx, done := observe(ctx, "multipart_create", bucketName, objectName)
+	defer func() { done(err) }()
+
+	if err := c.ensureBucket(ctx, bucketName); err != nil {
+		return "", err
+	}
+	return c.core.NewMultipartUpload(ctx, bucketName, objectName, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+}
+
+// PresignUploadPart 生成上传单个分片的预签名URL（浏览器直接PUT分片内容）
+func (c *Client) PresignUploadPart(
+	ctx context.Context,
+	bucketName, objectName, uploadID string,
+	partNumber int,
+	expires time.Duration,
+) (string, error) {
+	params := url.Values{}
+	params.Set("uploadId", uploadID)
+	params.Set("partNumber", strconv.Itoa(partNumber))
+	u, err := c.presigner.Presign(ctx, "PUT", bucketName, objectName, expires, params)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// ListUploadedParts 查询已上传的分片（按分片号升序）
+func (c *Client) ListUploadedParts(ctx context.Context, bucketName, objectName, uploadID string) (_ []UploadedPart, err error) {
+	ctx, done := observe(ctx, "multipart_list", bucketName, objectName)
+	defer func() { done(err) }()
+
+	var (
+		parts  []UploadedPart
+		marker int
+	)
+	for {
+		result, err := c.core.ListObjectParts(ctx, bucketName, objectName, uploadID, marker, 1000)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range result.ObjectParts {
+			parts = append(parts, UploadedPart{PartNumber: p.PartNumber, Size: p.Size, ETag: p.ETag})
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+// CompleteMultipartUpload 合并分片（parts需按分片号升序）
+func (c *Client) CompleteMultipartUpload(
+	ctx context.Context,
+	bucketName, objectName, uploadID string,
+	parts []UploadedPart,
+) (err error) {
+	ctx, done := observe(ctx, "multipart_complete", bucketName, objectName)
+	defer func() { done(err) }()
+
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, p := range parts {
+		completeParts = append(completeParts, minio.CompletePart{PartNumber: p.PartNumber, ETag: p.ETag})
+	}
+	_, err = c.core.CompleteMultipartUpload(ctx, bucketName, objectName, uploadID, completeParts, minio.PutObjectOptions{})
+	return err
+}
+
+// AbortMultipartUpload 取消分片上传并清理已上传的分片
+func (c *Client) AbortMultipartUpload(ctx context.Context, bucketName, objectName, uploadID string) (err error) {
+	ctx, done := observe(ctx, "multipart_abort", bucketName, objectName)
+	defer func() { done(err) }()
+
+	return c.core.AbortMultipartUpload(ctx, bucketName, objectName, uploadID)
+}
+
+// ObjectSize 查询对象大小
+func (c *Client) ObjectSize(ctx context.Context, bucketName, objectName string) (_ int64, err error) {
+	ctx, done := observe(ctx, "stat", bucketName, objectName)
+	defer func() { done(err) }()
+
+	info, err := c.client.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
+
+// CopyObject 在桶内复制对象（服务端复制，不经过本服务）
+func (c *Client) CopyObject(ctx context.Context, bucketName, srcObject, dstObject string) (err error) {
+	ctx, done := observe(ctx, "copy", bucketName, dstObject)
+	defer func() { done(err) }()
+
+	_, err = c.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucketName, Object: dstObject},
+		minio.CopySrcOptions{Bucket: bucketName, Object: srcObject},
+	)
+	return err
+}
+
+// IsNotFound 是否为对象或桶不存在错误
+func IsNotFound(err error) bool {
+	code := minio.ToErrorResponse(err).Code
+	return code == minio.NoSuchKey || code == minio.NoSuchBucket
+}
+
+// IsServerError 是否为MinIO服务端错误（5xx，通常可重试）
+func IsServerError(err error) bool {
+	return minio.ToErrorResponse(err).StatusCode >= http.StatusInternalServerError
+}
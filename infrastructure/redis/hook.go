@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	"customs/infrastructure/metrics"
+	"customs/infrastructure/tracing"
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// 指标中的后端名称
+const metricsBackend = "redis"
+
+// startKey 在上下文中记录命令开始时间
+type startKey struct{}
+
+// instrumentHook 为Redis命令记录耗时指标与Span（缓存未命中不计为失败）
+type instrumentHook struct{}
+
+func (instrumentHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = tracing.StartWithKind(ctx, "redis."+cmd.Name(), trace.SpanKindClient,
+		attribute.String("db.system", "redis"),
+		attribute.String("db.operation", cmd.Name()),
+	)
+	return context.WithValue(ctx, startKey{}, time.Now()), nil
+}
+
+func (instrumentHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	finish(ctx, cmd.Name(), cmdErr(cmd))
+	return nil
+}
+
+func (instrumentHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	ctx, _ = tracing.StartWithKind(ctx, "redis.pipeline", trace.SpanKindClient,
+		attribute.String("db.system", "redis"),
+		attribute.Int("db.redis.pipeline_length", len(cmds)),
+	)
+	return context.WithValue(ctx, startKey{}, time.Now()), nil
+}
+
+func (instrumentHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if err = cmdErr(cmd); err != nil {
+			break
+		}
+	}
+	finish(ctx, "pipeline", err)
+	return nil
+}
+
+// finish 记录耗时指标并结束Span
+func finish(ctx context.Context, operation string, err error) {
+	if start, ok := ctx.Value(startKey{}).(time.Time); ok {
+		metrics.ObserveStorage(metricsBackend, operation, start, err)
+	}
+	tracing.End(trace.SpanFromContext(ctx), err)
+}
+
+// cmdErr 返回命令错误（缓存未命中视为正常）
+func cmdErr(cmd redis.Cmder) error {
+	if err := cmd.Err(); err != nil && err != redis.Nil {
+		return err
+	}
+	return nil
+}
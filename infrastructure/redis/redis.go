@@ -9,7 +9,6 @@ import (
 // Client 通用 Redis 客户端
 type Client struct {
 	client *redis.Client
-	ctx    context.Context
 }
 
 // NewRedisClient 初始化 Redis 连接
@@ -19,32 +18,39 @@ func NewRedisClient(addr, password string, db int) *Client {
 		Password: password,
 		DB:       db,
 	})
+	client.AddHook(instrumentHook{})
 
 	// Ping 调用方式
-	ctx := context.Background()
-	if err := client.Ping(ctx).Err(); err != nil {
+	if err := client.Ping(context.Background()).Err(); err != nil {
 		panic("Redis 连接失败: " + err.Error())
 	}
 
 	return &Client{
 		client: client,
-		ctx:    ctx,
 	}
 }
 
+// ErrNil 键不存在（Get返回该错误时表示缓存未命中）
+var ErrNil = redis.Nil
+
 // Get 获取缓存
-func (c *Client) Get(key string) (string, error) {
-	return c.client.Get(c.ctx, key).Result()
+func (c *Client) Get(ctx context.Context, key string) (string, error) {
+	return c.client.Get(ctx, key).Result()
 }
 
 // Set 设置缓存
-func (c *Client) Set(key string, value interface{}, expireSeconds int) error {
-	return c.client.Set(c.ctx, key, value, time.Duration(expireSeconds)*time.Second).Err()
+func (c *Client) Set(ctx context.Context, key string, value interface{}, expireSeconds int) error {
+	return c.client.Set(ctx, key, value, time.Duration(expireSeconds)*time.Second).Err()
 }
 
 // Delete 删除缓存
-func (c *Client) Delete(key string) error {
-	return c.client.Del(c.ctx, key).Err()
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, key).Err()
+}
+
+// Ping 检查Redis连接是否可用
+func (c *Client) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
 }
 
 // GetClient 暴露底层客户端
@@ -1,41 +1,76 @@
 package main
 
 import (
+	"context"
 	"customs/api/router"
+	"customs/common/logger"
+	"customs/config"
 	"customs/infrastructure/db"
+	"customs/infrastructure/metrics"
 	"customs/infrastructure/minio"
 	"customs/infrastructure/redis"
+	"customs/infrastructure/tracing"
 	"customs/repository"
 	"customs/service"
 	"customs/task"
-	"log"
+	"log/slog"
+	"os"
 )
 
 func main() {
+	cfg := config.Load()
+	log := logger.New(cfg.Env)
+	slog.SetDefault(log)
+
+	// 初始化链路追踪
+	shutdownTracing, err := tracing.Init(context.Background(), cfg, "customs-api")
+	if err != nil {
+		fatal(log, "链路追踪初始化失败", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error("链路追踪关闭失败", slog.Any("error", err))
+		}
+	}()
+
 	// 1. 初始化基础设施层
-	mysqlClient, err := db.NewMySQLClient("root:123456@tcp(127.0.0.1:3306)/customs?parseTime=true&charset=utf8mb4")
+	mysqlClient, err := db.NewMySQLClient(cfg.MySQL.DSN)
 	if err != nil {
-		log.Fatal("MySQL初始化失败:", err)
+		fatal(log, "MySQL初始化失败", err)
 	}
-	minioClient, err := minio.NewMinioClient("127.0.0.1:9000", "minioadmin", "minioadmin", false)
+	minioClient, err := minio.NewMinioClient(cfg.Minio.Endpoint, cfg.Minio.AccessKey, cfg.Minio.SecretKey, cfg.Minio.Secure)
 	if err != nil {
-		log.Fatal("MinIO初始化失败:", err)
+		fatal(log, "MinIO初始化失败", err)
 	}
-	redisClient := redis.NewRedisClient("127.0.0.1:6379", "", 0)
+	if cfg.Minio.PublicEndpoint != "" {
+		if err := minioClient.UsePublicEndpoint(cfg.Minio.PublicEndpoint, cfg.Minio.PublicSecure, cfg.Minio.Region); err != nil {
+			fatal(log, "MinIO直传地址初始化失败", err)
+		}
+	}
+	redisClient := redis.NewRedisClient(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
 
 	// 2. 初始化Repository
 	repoContainer := repository.NewRepositoryContainer(mysqlClient)
 
 	// 3. 初始化Task
-	taskClient := task.NewClient("127.0.0.1:6379", "", 0)
-	taskInspector := task.NewInspector("127.0.0.1:6379", "", 0)
+	taskClient := task.NewClient(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
+	taskInspector := task.NewInspector(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
 	defer func() {
 		taskClient.Close()
 		taskInspector.Close()
 	}()
 
+	// 注册队列深度指标采集器
+	if err := metrics.RegisterQueueCollector(func() (map[string]map[string]int, error) {
+		return taskInspector.QueueDepths(task.Queues...)
+	}); err != nil {
+		fatal(log, "队列指标注册失败", err)
+	}
+
 	// 4. 初始化Service
 	serviceContainer := service.NewServiceContainer(
+		cfg,
+		log,
 		mysqlClient,
 		minioClient,
 		redisClient,
@@ -44,10 +79,26 @@ func main() {
 		repoContainer,
 	)
 
-	// 5. 初始化路由并启动HTTP服务
-	r := router.NewRouter(serviceContainer)
-	log.Println("HTTP服务启动成功，监听端口: 8080")
-	if err := r.Run(":8080"); err != nil { // 监听8080端口
-		log.Fatal("服务启动失败:", err)
+	// 5. 初始化认证
+	if err := serviceContainer.User.EnsureBootstrapUser(context.Background(), cfg.Auth.BootstrapUser, cfg.Auth.BootstrapPassword); err != nil {
+		fatal(log, "初始用户创建失败", err)
+	}
+	authenticators, err := service.NewAuthenticators(cfg, serviceContainer.User)
+	if err != nil {
+		fatal(log, "认证初始化失败", err)
 	}
+
+	// 6. 初始化路由并启动HTTP服务
+	r := router.NewRouter(
+		cfg, serviceContainer, log, authenticators)
+	log.Info("HTTP服务启动成功", slog.String("addr", cfg.HTTP.Addr))
+	if err := r.Run(cfg.HTTP.Addr); err != nil {
+		fatal(log, "服务启动失败", err)
+	}
+}
+
+// fatal 记录错误日志并退出进程
+func fatal(log *slog.Logger, msg string, err error) {
+	log.Error(msg, slog.Any("error", err))
+	os.Exit(1)
 }
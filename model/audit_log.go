@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrAuditLogImmutable 审计日志只允许追加，禁止修改或删除
+var ErrAuditLogImmutable = errors.New("审计日志只允许追加，禁止修改或删除")
+
+// AuditLog 审计日志表（只追加，不更新不删除）
+type AuditLog struct {
+	ID           string          `gorm:"column:id;primaryKey;comment:日志ID" json:"id"`
+	Action       string          `gorm:"column:action;size:32;index;comment:操作类型" json:"action"`
+	Actor        string          `gorm:"column:actor;size:64;index;comment:操作人" json:"actor"`
+	ClientIP     string          `gorm:"column:client_ip;size:64;comment:客户端IP" json:"client_ip"`
+	DictTaskID   string          `gorm:"column:dict_task_id;size:64;index;comment:关联的字典任务ID" json:"dict_task_id"`
+	DBResourceID string          `gorm:"column:db_resource_id;size:64;index;comment:关联的数据库资源ID" json:"db_resource_id"`
+	RequestID    string          `gorm:"column:request_id;size:64;comment:请求ID" json:"request_id"`
+	Detail       json.RawMessage `gorm:"column:detail;type:json;comment:操作详情（JSON）" json:"detail"`
+	CreatedAt    time.Time       `gorm:"column:created_at;index;comment:操作时间" json:"created_at"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (AuditLog) TableName() string {
+	return TableNameAuditLog
+}
+
+// NewAuditLog 初始化审计日志（detail序列化失败时记录错误信息）
+func NewAuditLog(action, actor, clientIP, dictTaskID, dbResourceID, requestID string, detail interface{}) *AuditLog {
+	raw, err := json.Marshal(detail)
+	if err != nil {
+		raw, _ = json.Marshal(map[string]string{"marshal_error": err.Error()})
+	}
+	return &AuditLog{
+		ID:           uuid.New().String(),
+		Action:       action,
+		Actor:        actor,
+		ClientIP:     clientIP,
+		DictTaskID:   dictTaskID,
+		DBResourceID: dbResourceID,
+		RequestID:    requestID,
+		Detail:       raw,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// BeforeUpdate GORM钩子：禁止更新
+func (AuditLog) BeforeUpdate(*gorm.DB) error {
+	return ErrAuditLogImmutable
+}
+
+// BeforeDelete GORM钩子：禁止删除
+func (AuditLog) BeforeDelete(*gorm.DB) error {
+	return ErrAuditLogImmutable
+}
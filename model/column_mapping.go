@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ColumnMapping 列映射方案（将其他单位模板的表头写法映射到模板列，上传时指定或按表头自动识别）
+type ColumnMapping struct {
+	ID          string              `gorm:"column:id;primaryKey;size:36;comment:方案ID" json:"id"`
+	Name        string              `gorm:"column:name;size:64;uniqueIndex;comment:方案名称" json:"name"`
+	Description string              `gorm:"column:description;size:255;comment:方案说明" json:"description"`
+	Aliases     map[string][]string `gorm:"column:aliases;type:json;serializer:json;comment:模板列名到表头别名的映射" json:"aliases"`
+	Creator     string              `gorm:"column:creator;size:64;comment:创建人" json:"creator"`
+	CreatedAt   time.Time           `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt   time.Time           `gorm:"column:updated_at;autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (ColumnMapping) TableName() string {
+	return TableNameColumnMapping
+}
+
+// NewColumnMapping 初始化列映射方案
+func NewColumnMapping(name, description string, aliases map[string][]string, creator string) *ColumnMapping {
+	return &ColumnMapping{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: description,
+		Aliases:     aliases,
+		Creator:     creator,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
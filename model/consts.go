@@ -10,6 +10,139 @@ const (
 
 // 数据库表名常量
 const (
-	TableNameDictionaryTask = "dictionary_task"
-	TableNameDBResource     = "db_resource"
+	TableNameDictionaryTask  = "dictionary_task"
+	TableNameDBResource      = "db_resource"
+	TableNameUser            = "sys_user"
+	TableNameAuditLog        = "audit_log"
+	TableNameDictionaryBatch = "dictionary_batch"
+	TableNameUploadSession   = "upload_session"
+	TableNameDictionaryField = "data_dictionary_field"
+	TableNameDriftReport     = "drift_report"
+	TableNameGlossaryTerm    = "glossary_term"
+	TableNameTermLink        = "glossary_term_link"
+	TableNameTableRelation   = "table_relation"
+	TableNameColumnMapping   = "column_mapping"
 )
+
+// 字段与业务术语的关联方式
+const (
+	TermLinkManual = "MANUAL" // 通过接口手动关联（重新入库时保留）
+	TermLinkAuto   = "AUTO"   // 解析时按字段中文名自动匹配（重新入库时按最新匹配结果替换）
+)
+
+// 表结构差异检查结果常量
+const (
+	DriftStatusClean   = "CLEAN"   // 与数据字典一致
+	DriftStatusDrifted = "DRIFTED" // 存在差异
+	DriftStatusFailed  = "FAILED"  // 检查失败（连接或读取表结构失败）
+)
+
+// 表结构差异类型常量
+const (
+	DriftKindUndocumentedTable  = "UNDOCUMENTED_TABLE"  // 数据库中存在、字典中没有的表
+	DriftKindUndocumentedColumn = "UNDOCUMENTED_COLUMN" // 数据库中存在、字典中没有的字段
+	DriftKindMissingTable       = "MISSING_TABLE"       // 字典中存在、数据库中没有的表
+	DriftKindMissingColumn      = "MISSING_COLUMN"      // 字典中存在、数据库中没有的字段
+	DriftKindTableComment       = "TABLE_COMMENT"       // 表注释与字典中文名不一致
+	DriftKindColumnComment      = "COLUMN_COMMENT"      // 字段注释与字典中文名/说明不一致
+)
+
+// 表结构差异检查的触发方式
+const (
+	DriftTriggerSchedule = "SCHEDULE" // 定时检查
+	DriftTriggerManual   = "MANUAL"   // 手动触发
+)
+
+// 批次状态常量（由子任务状态与批次审批结论汇总得出）
+const (
+	BatchStatusParsing     = "PARSING"      // 存在解析中的子任务
+	BatchStatusParseFailed = "PARSE_FAILED" // 存在解析失败的子任务
+	BatchStatusReady       = "READY"        // 全部解析成功，待审批
+	BatchStatusIncomplete  = "INCOMPLETE"   // 子任务数与批次记录不符（创建中断或子任务缺失），不能审批
+	BatchStatusApproved    = DecisionApproved
+	BatchStatusRejected    = DecisionRejected
+)
+
+// 直传会话状态常量
+const (
+	UploadSessionActive    = "ACTIVE"    // 上传中
+	UploadSessionCompleted = "COMPLETED" // 已完成并创建解析任务
+	UploadSessionFailed    = "FAILED"    // 校验未通过
+	UploadSessionAborted   = "ABORTED"   // 已取消
+	UploadSessionExpired   = "EXPIRED"   // 已过期（分片与暂存对象已清理）
+)
+
+// 用户角色常量
+const (
+	RoleUploader = "uploader" // 上传人：下载模板、上传Excel
+	RoleReviewer = "reviewer" // 审核人：审批（确认/驳回）入库，不能审批自己上传的任务
+	RoleAdmin    = "admin"    // 管理员：拥有全部角色权限
+)
+
+// Roles 全部可分配的角色
+var Roles = []string{RoleUploader, RoleReviewer, RoleAdmin}
+
+// 入库审批结论常量
+const (
+	DecisionApproved = "APPROVED" // 已批准（确认入库）
+	DecisionRejected = "REJECTED" // 已驳回（取消入库）
+)
+
+// 审计操作类型常量
+const (
+	AuditActionTemplateDownload = "TEMPLATE_DOWNLOAD" // 下载模板
+	AuditActionUpload           = "UPLOAD"            // 上传Excel
+	AuditActionValidationFailed = "VALIDATION_FAILED" // Excel格式校验未通过
+	AuditActionParseSucceeded   = "PARSE_SUCCEEDED"   // 解析完成
+	AuditActionParseFailed      = "PARSE_FAILED"      // 解析失败
+	AuditActionConfirm          = "CONFIRM"           // 确认入库（审批通过）
+	AuditActionReject           = "REJECT"            // 取消入库（审批驳回）
+	AuditActionInsertSucceeded  = "INSERT_SUCCEEDED"  // 入库完成
+	AuditActionInsertFailed     = "INSERT_FAILED"     // 入库失败
+	AuditActionUserCreate       = "USER_CREATE"       // 创建本地用户
+	AuditActionUserRoles        = "USER_ROLES"        // 分配用户角色
+	AuditActionDelete           = "DELETE"            // 删除任务
+	AuditActionBatchUpload      = "BATCH_UPLOAD"      // 批量上传Excel
+	AuditActionBatchConfirm     = "BATCH_CONFIRM"     // 批次整体确认入库
+	AuditActionBatchReject      = "BATCH_REJECT"      // 批次整体驳回
+	AuditActionExport           = "EXPORT"            // 导出已入库的数据字典
+	AuditActionResourceRegister = "RESOURCE_REGISTER" // 登记数据库资源
+	AuditActionConnectionUpdate = "CONNECTION_UPDATE" // 修改数据库资源的内省连接
+	AuditActionIntrospect       = "INTROSPECT"        // 从数据库表结构生成字典草稿
+	AuditActionDriftCheck       = "DRIFT_CHECK"       // 手动触发表结构差异检查
+	AuditActionTermCreate       = "TERM_CREATE"       // 新增业务术语
+	AuditActionTermUpdate       = "TERM_UPDATE"       // 修改业务术语
+	AuditActionTermDelete       = "TERM_DELETE"       // 删除业务术语
+	AuditActionTermLink         = "TERM_LINK"         // 手动关联字段与业务术语
+	AuditActionTermUnlink       = "TERM_UNLINK"       // 取消字段与业务术语的关联
+	AuditActionMappingCreate    = "MAPPING_CREATE"    // 新增列映射方案
+	AuditActionMappingUpdate    = "MAPPING_UPDATE"    // 修改列映射方案
+	AuditActionMappingDelete    = "MAPPING_DELETE"    // 删除列映射方案
+)
+
+// Excel模板标准列名（与Python版本保持一致）
+const (
+	ColumnTableNameEN = "数据表名称（英文）"
+	ColumnTableNameCN = "数据表名称（中文）"
+	ColumnFieldNameEN = "字段/数据项名称（英文）"
+	ColumnFieldNameCN = "字段/数据项名称（中文）"
+	ColumnFieldDesc   = "字段/数据项说明"
+	ColumnPrimaryKey  = "是否主键"
+	ColumnRefTable    = "关联表"
+	ColumnRefField    = "关联字段"
+)
+
+// 是否主键列的取值
+const (
+	PrimaryKeyYes = "是"
+	PrimaryKeyNo  = "否"
+)
+
+// StdColumns 上传Excel必须包含的标准列（按模板列顺序，由TemplateColumns派生，模板与校验共用同一定义）
+var StdColumns = templateColumnNames(false)
+
+// OptionalColumns 上传Excel可选包含的列（主键与关联关系，按模板列顺序）
+var OptionalColumns = templateColumnNames(true)
+
+// TemplateColumnNames 模板的全部列（标准列在前，可选列在后）
+var TemplateColumnNames = append(append([]string{}, StdColumns...), OptionalColumns...)
@@ -11,7 +11,9 @@ import (
 type DBResource struct {
 	ID              string         `gorm:"column:id;primaryKey;comment:资源ID" json:"id"`
 	ResourceComment string         `gorm:"column:resource_comment;index;comment:资源备注（如：署级系统-下发数据）" json:"resource_comment"`
+	SystemName      string         `gorm:"column:system_name;comment:系统名（取自Excel文件名）" json:"system_name"`
 	ResourceType    string         `gorm:"column:resource_type;comment:资源类型（如：MySQL/Oracle）" json:"resource_type"`
+	ConnectionDSN   string         `gorm:"column:connection_dsn;size:1024;comment:内省连接串（口令以${INTROSPECT_XXX}引用环境变量，不保存明文）" json:"-"`
 	DBName          string         `gorm:"column:db_name;comment:数据库名" json:"db_name"`
 	TableNames      string         `gorm:"column:table_names;comment:关联表名（逗号分隔）" json:"table_names"`
 	Creator         string         `gorm:"column:creator;comment:创建人" json:"creator"`
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DictionaryBatch 数据字典批次表（一次上传多个Excel或一个ZIP，子任务通过batch_id关联）
+type DictionaryBatch struct {
+	ID              string         `gorm:"column:id;primaryKey;comment:批次ID" json:"id"`
+	ResourceComment string         `gorm:"column:resource_comment;comment:资源备注" json:"resource_comment"`
+	Uploader        string         `gorm:"column:uploader;index;comment:上传人" json:"uploader"`
+	SourceNames     string         `gorm:"column:source_names;type:text;comment:上传的文件名（逗号分隔，含ZIP包名）" json:"source_names"`
+	TaskCount       int            `gorm:"column:task_count;comment:子任务数" json:"task_count"`
+	Confirmer       string         `gorm:"column:confirmer;comment:审批人" json:"confirmer"`
+	Decision        string         `gorm:"column:decision;comment:审批结论（APPROVED/REJECTED）" json:"decision"`
+	RejectReason    string         `gorm:"column:reject_reason;comment:驳回原因" json:"reject_reason"`
+	DecidedAt       *time.Time     `gorm:"column:decided_at;comment:审批时间" json:"decided_at"`
+	CreatedAt       time.Time      `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt       time.Time      `gorm:"column:updated_at;autoUpdateTime;comment:更新时间" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"column:deleted_at;index;comment:删除时间" json:"deleted_at,omitempty"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (DictionaryBatch) TableName() string {
+	return TableNameDictionaryBatch
+}
+
+// NewDictionaryBatch 初始化批次
+func NewDictionaryBatch(resourceComment, uploader, sourceNames string, taskCount int) *DictionaryBatch {
+	return &DictionaryBatch{
+		ID:              uuid.New().String(),
+		ResourceComment: resourceComment,
+		Uploader:        uploader,
+		SourceNames:     sourceNames,
+		TaskCount:       taskCount,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// Decide 记录批次审批结论
+func (b *DictionaryBatch) Decide(decision, confirmer, reason string) {
+	now := time.Now()
+	b.Decision = decision
+	b.Confirmer = confirmer
+	b.RejectReason = reason
+	b.DecidedAt = &now
+	b.UpdatedAt = now
+}
+
+// Decided 是否已审批
+func (b *DictionaryBatch) Decided() bool {
+	return b.Decision != ""
+}
+
+// Status 汇总批次状态：已审批时为审批结论；子任务不齐时为INCOMPLETE；否则按子任务解析状态（失败优先于解析中）
+func (b *DictionaryBatch) Status(tasks []DictionaryTask) string {
+	if b.Decided() {
+		return b.Decision
+	}
+	if len(tasks) == 0 || len(tasks) != b.TaskCount {
+		return BatchStatusIncomplete
+	}
+	status := BatchStatusReady
+	for _, t := range tasks {
+		switch t.CreateDFTaskStatus {
+		case TaskStatusFailed:
+			return BatchStatusParseFailed
+		case TaskStatusSucceeded:
+		default:
+			status = BatchStatusParsing
+		}
+	}
+	return status
+}
@@ -0,0 +1,34 @@
+package model
+
+import "testing"
+
+func TestDictionaryBatchStatus(t *testing.T) {
+	task := func(status string) DictionaryTask {
+		return DictionaryTask{CreateDFTaskStatus: status}
+	}
+	tests := []struct {
+		name     string
+		batch    DictionaryBatch
+		tasks    []DictionaryTask
+		expected string
+	}{
+		{"全部解析成功", DictionaryBatch{TaskCount: 2},
+			[]DictionaryTask{task(TaskStatusSucceeded), task(TaskStatusSucceeded)}, BatchStatusReady},
+		{"存在解析中的子任务", DictionaryBatch{TaskCount: 2},
+			[]DictionaryTask{task(TaskStatusSucceeded), task(TaskStatusPending)}, BatchStatusParsing},
+		{"解析失败优先于解析中", DictionaryBatch{TaskCount: 3},
+			[]DictionaryTask{task(TaskStatusPending), task(TaskStatusFailed), task(TaskStatusSucceeded)}, BatchStatusParseFailed},
+		{"子任务少于批次记录", DictionaryBatch{TaskCount: 3},
+			[]DictionaryTask{task(TaskStatusSucceeded), task(TaskStatusSucceeded)}, BatchStatusIncomplete},
+		{"没有子任务", DictionaryBatch{TaskCount: 0}, nil, BatchStatusIncomplete},
+		{"已审批时为审批结论", DictionaryBatch{TaskCount: 2, Decision: DecisionApproved},
+			[]DictionaryTask{task(TaskStatusSucceeded)}, BatchStatusApproved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.batch.Status(tt.tasks); got != tt.expected {
+				t.Errorf("Status() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DictionaryField 已入库的数据字典字段（每次入库按 资源备注+库名 整体替换，全文索引随之同步）
+// 表名、字段名与说明共用一个ngram全文索引（支持中文检索，需MySQL 5.7.6+）
+type DictionaryField struct {
+	ID              string    `gorm:"column:id;primaryKey;size:36;comment:字段记录ID" json:"id"`
+	DBResourceID    string    `gorm:"column:db_resource_id;size:36;index;comment:所属数据库资源ID" json:"db_resource_id"`
+	DictTaskID      string    `gorm:"column:dict_task_id;size:36;comment:入库来源任务ID" json:"dict_task_id"`
+	ResourceComment string    `gorm:"column:resource_comment;size:191;index:idx_dict_field_scope,priority:1;comment:资源备注" json:"resource_comment"`
+	SystemName      string    `gorm:"column:system_name;size:191;index;comment:系统名" json:"system_name"`
+	DBName          string    `gorm:"column:db_name;size:191;index:idx_dict_field_scope,priority:2;comment:数据库名" json:"db_name"`
+	TableNameEN     string    `gorm:"column:table_name_en;size:191;index:ft_dictionary_field,class:FULLTEXT,option:WITH PARSER ngram;comment:数据表名称（英文）" json:"table_name_en"`
+	TableNameCN     string    `gorm:"column:table_name_cn;size:191;index:ft_dictionary_field;comment:数据表名称（中文）" json:"table_name_cn"`
+	FieldNameEN     string    `gorm:"column:field_name_en;size:191;index:ft_dictionary_field;comment:字段名称（英文）" json:"field_name_en"`
+	FieldNameCN     string    `gorm:"column:field_name_cn;size:191;index:ft_dictionary_field;comment:字段名称（中文）" json:"field_name_cn"`
+	FieldDesc       string    `gorm:"column:field_desc;type:text;index:ft_dictionary_field;comment:字段说明" json:"field_desc"`
+	PrimaryKey      bool      `gorm:"column:primary_key;comment:是否主键" json:"primary_key"`
+	RowNo           int       `gorm:"column:row_no;comment:在工作簿中的行序（导出时保持原顺序）" json:"row_no"`
+	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (DictionaryField) TableName() string {
+	return TableNameDictionaryField
+}
+
+// NewDictionaryField 初始化字段记录
+func NewDictionaryField(
+	dbResourceID, dictTaskID, resourceComment, systemName, dbName string,
+	tableNameEN, tableNameCN, fieldNameEN, fieldNameCN, fieldDesc string,
+) *DictionaryField {
+	return &DictionaryField{
+		ID:              uuid.New().String(),
+		DBResourceID:    dbResourceID,
+		DictTaskID:      dictTaskID,
+		ResourceComment: resourceComment,
+		SystemName:      systemName,
+		DBName:          dbName,
+		TableNameEN:     tableNameEN,
+		TableNameCN:     tableNameCN,
+		FieldNameEN:     fieldNameEN,
+		FieldNameCN:     fieldNameCN,
+		FieldDesc:       fieldDesc,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// DictionaryFieldSearchColumns 全文索引覆盖的列（MATCH子句须与索引列完全一致）
+const DictionaryFieldSearchColumns = "table_name_en, table_name_cn, field_name_en, field_name_cn, field_desc"
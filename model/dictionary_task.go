@@ -9,21 +9,35 @@ import (
 
 // DictionaryTask 数据字典任务表
 type DictionaryTask struct {
-	ID                    string         `gorm:"column:id;primaryKey;comment:任务ID" json:"id"`
-	CreateDFTaskID        string         `gorm:"column:create_df_task_id;comment:Asynq创建数据帧任务ID" json:"create_df_task_id"`
-	ExcelName             string         `gorm:"column:excel_name;comment:上传的Excel文件名" json:"excel_name"`
-	CreateDFTaskStatus    string         `gorm:"column:create_df_task_status;comment:创建数据帧任务状态" json:"create_df_task_status"`
-	CreateDFTaskRemark    string         `gorm:"column:create_df_task_remark;comment:创建数据帧任务备注（失败原因）" json:"create_df_task_remark"`
-	DBResourceCSVName     string         `gorm:"column:db_resource_csv_name;comment:数据库资源CSV文件名" json:"db_resource_csv_name"`
-	DataDictionaryCSVName string         `gorm:"column:data_dictionary_csv_name;comment:数据字典CSV文件名" json:"data_dictionary_csv_name"`
-	CSVName               string         `gorm:"column:csv_name;comment:通用CSV文件名" json:"csv_name"`
-	InsertDFTaskID        string         `gorm:"column:insert_df_task_id;comment:Asynq插入数据库任务ID" json:"insert_df_task_id"`
-	InsertDFTaskStatus    string         `gorm:"column:insert_df_task_status;comment:插入数据库任务状态" json:"insert_df_task_status"`
-	InsertDFTaskRemark    string         `gorm:"column:insert_df_task_remark;comment:插入数据库任务备注（失败原因）" json:"insert_df_task_remark"`
-	Confirm               bool           `gorm:"column:confirm;default:false;comment:是否确认插入数据库" json:"confirm"`
-	CreatedAt             time.Time      `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
-	UpdatedAt             time.Time      `gorm:"column:updated_at;autoUpdateTime;comment:更新时间" json:"updated_at"`
-	DeletedAt             gorm.DeletedAt `gorm:"column:deleted_at;index;comment:删除时间" json:"deleted_at,omitempty"`
+	ID                    string              `gorm:"column:id;primaryKey;comment:任务ID" json:"id"`
+	CreateDFTaskID        string              `gorm:"column:create_df_task_id;comment:Asynq创建数据帧任务ID" json:"create_df_task_id"`
+	BatchID               string              `gorm:"column:batch_id;index;comment:所属批次ID（单文件上传为空）" json:"batch_id,omitempty"`
+	ExcelName             string              `gorm:"column:excel_name;comment:上传的Excel文件名" json:"excel_name"`
+	ResourceComment       string              `gorm:"column:resource_comment;comment:资源备注" json:"resource_comment"`
+	Uploader              string              `gorm:"column:uploader;index;comment:上传人" json:"uploader"`
+	CreateDFTaskStatus    string              `gorm:"column:create_df_task_status;comment:创建数据帧任务状态" json:"create_df_task_status"`
+	CreateDFTaskRemark    string              `gorm:"column:create_df_task_remark;comment:创建数据帧任务备注（失败原因）" json:"create_df_task_remark"`
+	DBResourceCSVName     string              `gorm:"column:db_resource_csv_name;comment:数据库资源CSV文件名" json:"db_resource_csv_name"`
+	DataDictionaryCSVName string              `gorm:"column:data_dictionary_csv_name;comment:数据字典CSV文件名" json:"data_dictionary_csv_name"`
+	CSVName               string              `gorm:"column:csv_name;comment:通用CSV文件名" json:"csv_name"`
+	NamingReportName      string              `gorm:"column:naming_report_name;comment:命名规范检查结果文件名" json:"naming_report_name"`
+	TermsCSVName          string              `gorm:"column:terms_csv_name;comment:业务术语匹配结果CSV文件名" json:"terms_csv_name"`
+	KeysCSVName           string              `gorm:"column:keys_csv_name;comment:主键与关联关系CSV文件名" json:"keys_csv_name"`
+	ColumnMappingID       string              `gorm:"column:column_mapping_id;size:36;comment:解析使用的列映射方案ID（为空表示标准表头）" json:"column_mapping_id"`
+	ColumnAliases         map[string][]string `gorm:"column:column_aliases;type:json;serializer:json;comment:上传时列映射方案的表头别名快照" json:"column_aliases,omitempty"`
+	NamingErrors          int                 `gorm:"column:naming_errors;default:0;comment:命名规范error级违规数" json:"naming_errors"`
+	NamingWarnings        int                 `gorm:"column:naming_warnings;default:0;comment:命名规范warning级违规数" json:"naming_warnings"`
+	InsertDFTaskID        string              `gorm:"column:insert_df_task_id;comment:Asynq插入数据库任务ID" json:"insert_df_task_id"`
+	InsertDFTaskStatus    string              `gorm:"column:insert_df_task_status;comment:插入数据库任务状态" json:"insert_df_task_status"`
+	InsertDFTaskRemark    string              `gorm:"column:insert_df_task_remark;comment:插入数据库任务备注（失败原因）" json:"insert_df_task_remark"`
+	Confirm               bool                `gorm:"column:confirm;default:false;comment:是否确认插入数据库" json:"confirm"`
+	Confirmer             string              `gorm:"column:confirmer;comment:确认/取消入库的操作人（审批人）" json:"confirmer"`
+	Decision              string              `gorm:"column:decision;comment:审批结论（APPROVED/REJECTED）" json:"decision"`
+	RejectReason          string              `gorm:"column:reject_reason;comment:驳回原因" json:"reject_reason"`
+	DecidedAt             *time.Time          `gorm:"column:decided_at;comment:审批时间" json:"decided_at"`
+	CreatedAt             time.Time           `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt             time.Time           `gorm:"column:updated_at;autoUpdateTime;comment:更新时间" json:"updated_at"`
+	DeletedAt             gorm.DeletedAt      `gorm:"column:deleted_at;index;comment:删除时间" json:"deleted_at,omitempty"`
 }
 
 // TableName 指定GORM映射的数据库表名
@@ -32,10 +46,12 @@ func (DictionaryTask) TableName() string {
 }
 
 // NewDictionaryTask 初始化任务
-func NewDictionaryTask(excelName, createDFTaskID string) *DictionaryTask {
+func NewDictionaryTask(excelName, createDFTaskID, resourceComment, uploader string) *DictionaryTask {
 	return &DictionaryTask{
 		ID:                 uuid.New().String(), // 生成UUID作为主键
 		ExcelName:          excelName,
+		ResourceComment:    resourceComment,
+		Uploader:           uploader,
 		CreateDFTaskID:     createDFTaskID,
 		CreateDFTaskStatus: TaskStatusPending, // 默认待执行
 		CreatedAt:          time.Now(),
@@ -44,6 +60,11 @@ func NewDictionaryTask(excelName, createDFTaskID string) *DictionaryTask {
 	}
 }
 
+// ExcelObjectName Excel在excel-bucket中的对象名（按任务ID分目录，同名文件互不覆盖）
+func (t *DictionaryTask) ExcelObjectName() string {
+	return t.ID + "/" + t.ExcelName
+}
+
 // UpdateCreateDFStatus 更新创建数据帧任务状态
 func (t *DictionaryTask) UpdateCreateDFStatus(status string, remark ...string) {
 	t.CreateDFTaskStatus = status
@@ -55,17 +76,37 @@ func (t *DictionaryTask) UpdateCreateDFStatus(status string, remark ...string) {
 
 // UpdateInsertDFStatus 更新插入数据库任务状态
 func (t *DictionaryTask) UpdateInsertDFStatus(status string, remark ...string) {
-	t.InsertDFTaskID = status
+	t.InsertDFTaskStatus = status
 	if len(remark) > 0 {
 		t.InsertDFTaskRemark = remark[0]
 	}
 	t.UpdatedAt = time.Now()
 }
 
-// ConfirmInsert 标记为确认插入
-func (t *DictionaryTask) ConfirmInsert(insertDFTaskID string) {
+// ConfirmInsert 标记为确认插入（审批通过）
+func (t *DictionaryTask) ConfirmInsert(insertDFTaskID, confirmer string) {
+	now := time.Now()
 	t.Confirm = true
+	t.Confirmer = confirmer
+	t.Decision = DecisionApproved
+	t.DecidedAt = &now
 	t.InsertDFTaskID = insertDFTaskID
 	t.InsertDFTaskStatus = TaskStatusPending
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = now
+}
+
+// Reject 标记为取消插入（审批驳回）
+func (t *DictionaryTask) Reject(confirmer, reason string) {
+	now := time.Now()
+	t.Confirm = false
+	t.Confirmer = confirmer
+	t.Decision = DecisionRejected
+	t.RejectReason = reason
+	t.DecidedAt = &now
+	t.UpdatedAt = now
+}
+
+// Decided 是否已审批（已批准或已驳回）
+func (t *DictionaryTask) Decided() bool {
+	return t.Decision != ""
 }
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DriftItem 单条表结构差异
+type DriftItem struct {
+	Kind       string `json:"kind"`                 // 差异类型（DriftKind*）
+	Table      string `json:"table"`                // 表名
+	Column     string `json:"column,omitempty"`     // 字段名（表级差异为空）
+	Dictionary string `json:"dictionary,omitempty"` // 数据字典中的中文名称/说明
+	Database   string `json:"database,omitempty"`   // 数据库中的注释
+}
+
+// DriftReport 数据字典与数据库实际表结构的差异报告（每次检查追加一条）
+type DriftReport struct {
+	ID                string          `gorm:"column:id;primaryKey;comment:报告ID" json:"id"`
+	DBResourceID      string          `gorm:"column:db_resource_id;size:64;index;comment:数据库资源ID" json:"db_resource_id"`
+	Status            string          `gorm:"column:status;size:16;index;comment:检查结果" json:"status"`
+	Trigger           string          `gorm:"column:trigger_by;size:16;comment:触发方式（SCHEDULE/MANUAL）" json:"trigger"`
+	Operator          string          `gorm:"column:operator;size:64;comment:手动触发人" json:"operator"`
+	UndocumentedCount int             `gorm:"column:undocumented_count;comment:数据库中存在但未入字典的表/字段数" json:"undocumented_count"`
+	MissingCount      int             `gorm:"column:missing_count;comment:字典中存在但数据库缺失的表/字段数" json:"missing_count"`
+	MismatchCount     int             `gorm:"column:mismatch_count;comment:注释与字典不一致的表/字段数" json:"mismatch_count"`
+	Items             json.RawMessage `gorm:"column:items;type:json;comment:差异明细（JSON）" json:"items"`
+	Error             string          `gorm:"column:error;size:1024;comment:检查失败原因" json:"error,omitempty"`
+	CreatedAt         time.Time       `gorm:"column:created_at;index;comment:检查时间" json:"created_at"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (DriftReport) TableName() string {
+	return TableNameDriftReport
+}
+
+// NewDriftReport 按差异明细初始化报告（无差异时为CLEAN）
+func NewDriftReport(dbResourceID, trigger, operator string, items []DriftItem) *DriftReport {
+	report := newDriftReport(dbResourceID, trigger, operator)
+	if items == nil {
+		items = []DriftItem{}
+	}
+	for _, item := range items {
+		switch item.Kind {
+		case DriftKindUndocumentedTable, DriftKindUndocumentedColumn:
+			report.UndocumentedCount++
+		case DriftKindMissingTable, DriftKindMissingColumn:
+			report.MissingCount++
+		default:
+			report.MismatchCount++
+		}
+	}
+	if len(items) > 0 {
+		report.Status = DriftStatusDrifted
+	}
+	report.Items, _ = json.Marshal(items)
+	return report
+}
+
+// NewFailedDriftReport 初始化检查失败的报告（如连接不上数据库）
+func NewFailedDriftReport(dbResourceID, trigger, operator string, cause error) *DriftReport {
+	report := newDriftReport(dbResourceID, trigger, operator)
+	report.Status = DriftStatusFailed
+	report.Error = cause.Error()
+	if len(report.Error) > 1024 {
+		report.Error = report.Error[:1024]
+	}
+	report.Items = json.RawMessage("[]")
+	return report
+}
+
+func newDriftReport(dbResourceID, trigger, operator string) *DriftReport {
+	return &DriftReport{
+		ID:           uuid.New().String(),
+		DBResourceID: dbResourceID,
+		Status:       DriftStatusClean,
+		Trigger:      trigger,
+		Operator:     operator,
+		CreatedAt:    time.Now(),
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GlossaryTerm 业务术语表（标准业务术语及其定义、英文缩写）
+type GlossaryTerm struct {
+	ID           string    `gorm:"column:id;primaryKey;size:36;comment:术语ID" json:"id"`
+	Name         string    `gorm:"column:name;size:128;uniqueIndex;comment:术语名称（中文，与字段中文名匹配）" json:"name"`
+	Abbreviation string    `gorm:"column:abbreviation;size:64;index;comment:标准英文缩写" json:"abbreviation"`
+	Definition   string    `gorm:"column:definition;type:text;comment:定义" json:"definition"`
+	Creator      string    `gorm:"column:creator;size:64;comment:创建人" json:"creator"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (GlossaryTerm) TableName() string {
+	return TableNameGlossaryTerm
+}
+
+// NewGlossaryTerm 初始化术语
+func NewGlossaryTerm(name, abbreviation, definition, creator string) *GlossaryTerm {
+	return &GlossaryTerm{
+		ID:           uuid.New().String(),
+		Name:         name,
+		Abbreviation: abbreviation,
+		Definition:   definition,
+		Creator:      creator,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+// TermLink 字段与业务术语的关联
+// 按 资源+表名+字段名 关联（字段记录在每次入库时整体替换，关联不随之丢失），每个字段最多关联一个术语
+type TermLink struct {
+	ID           string    `gorm:"column:id;primaryKey;size:36;comment:关联ID" json:"id"`
+	TermID       string    `gorm:"column:term_id;size:36;index;comment:术语ID" json:"term_id"`
+	DBResourceID string    `gorm:"column:db_resource_id;size:36;uniqueIndex:idx_term_link_field,priority:1;comment:数据库资源ID" json:"db_resource_id"`
+	TableNameEN  string    `gorm:"column:table_name_en;size:191;uniqueIndex:idx_term_link_field,priority:2;comment:数据表名称（英文）" json:"table_name_en"`
+	FieldNameEN  string    `gorm:"column:field_name_en;size:191;uniqueIndex:idx_term_link_field,priority:3;comment:字段名称（英文）" json:"field_name_en"`
+	Source       string    `gorm:"column:source;size:16;comment:关联方式（MANUAL/AUTO）" json:"source"`
+	Creator      string    `gorm:"column:creator;size:64;comment:关联人（自动关联为入库审批人）" json:"creator"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime;comment:关联时间" json:"created_at"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (TermLink) TableName() string {
+	return TableNameTermLink
+}
+
+// NewTermLink 初始化字段与术语的关联
+func NewTermLink(termID, dbResourceID, tableNameEN, fieldNameEN, source, creator string) *TermLink {
+	return &TermLink{
+		ID:           uuid.New().String(),
+		TermID:       termID,
+		DBResourceID: dbResourceID,
+		TableNameEN:  tableNameEN,
+		FieldNameEN:  fieldNameEN,
+		Source:       source,
+		Creator:      creator,
+		CreatedAt:    time.Now(),
+	}
+}
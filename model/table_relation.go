@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TableRelation 字段间的关联关系（由工作簿的关联表、关联字段列解析，每次入库按数据库资源整体替换）
+// 被引用的表按 系统名+库名+表名 定位，可以属于其他系统的数据库；工作簿未写明系统名时被引用系统名为空，按库名+表名匹配
+type TableRelation struct {
+	ID             string    `gorm:"column:id;primaryKey;size:36;comment:关联关系ID" json:"id"`
+	DBResourceID   string    `gorm:"column:db_resource_id;size:36;index:idx_table_relation_from,priority:1;comment:引用方数据库资源ID" json:"db_resource_id"`
+	DictTaskID     string    `gorm:"column:dict_task_id;size:36;comment:入库来源任务ID" json:"dict_task_id"`
+	SystemName     string    `gorm:"column:system_name;size:191;comment:引用方系统名" json:"system_name"`
+	DBName         string    `gorm:"column:db_name;size:191;comment:引用方数据库名" json:"db_name"`
+	TableNameEN    string    `gorm:"column:table_name_en;size:191;index:idx_table_relation_from,priority:2;comment:引用方数据表名称（英文）" json:"table_name_en"`
+	FieldNameEN    string    `gorm:"column:field_name_en;size:191;comment:引用方字段名称（英文）" json:"field_name_en"`
+	RefSystemName  string    `gorm:"column:ref_system_name;size:191;comment:被引用系统名（为空表示未限定系统）" json:"ref_system_name"`
+	RefDBName      string    `gorm:"column:ref_db_name;size:191;index:idx_table_relation_ref,priority:1;comment:被引用数据库名" json:"ref_db_name"`
+	RefTableNameEN string    `gorm:"column:ref_table_name_en;size:191;index:idx_table_relation_ref,priority:2;comment:被引用数据表名称（英文）" json:"ref_table_name_en"`
+	RefFieldNameEN string    `gorm:"column:ref_field_name_en;size:191;comment:被引用字段名称（英文）" json:"ref_field_name_en"`
+	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (TableRelation) TableName() string {
+	return TableNameTableRelation
+}
+
+// NewTableRelation 初始化关联关系
+func NewTableRelation(
+	dbResourceID, dictTaskID, systemName, dbName string,
+	tableNameEN, fieldNameEN, refSystemName, refDBName, refTableNameEN, refFieldNameEN string,
+) *TableRelation {
+	return &TableRelation{
+		ID:             uuid.New().String(),
+		DBResourceID:   dbResourceID,
+		DictTaskID:     dictTaskID,
+		SystemName:     systemName,
+		DBName:         dbName,
+		TableNameEN:    tableNameEN,
+		FieldNameEN:    fieldNameEN,
+		RefSystemName:  refSystemName,
+		RefDBName:      refDBName,
+		RefTableNameEN: refTableNameEN,
+		RefFieldNameEN: refFieldNameEN,
+		CreatedAt:      time.Now(),
+	}
+}
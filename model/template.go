@@ -0,0 +1,50 @@
+package model
+
+// TemplateColumn Excel模板的列定义（生成模板、校验上传与导出共用）
+type TemplateColumn struct {
+	Name      string   // 列名（表头）
+	Width     float64  // 列宽
+	MaxLength int      // 单元格最大字符数（0表示不限制）
+	Options   []string // 下拉可选值（为空时不生成下拉）
+	Note      string   // 填写说明（模板说明页与单元格输入提示）
+	Required  bool     // 是否必填（同一张表仅首行填写表名时，表名列仍视为必填）
+	Optional  bool     // 可选列（上传的Excel可以不含该列）
+}
+
+// TemplateColumns 模板列定义（按模板列顺序，标准列在前，可选列在后）
+var TemplateColumns = []TemplateColumn{
+	{Name: ColumnTableNameEN, Width: 28, MaxLength: 64, Required: true,
+		Note: "数据库中的物理表名；同一张表的多行可只在首行填写（或合并单元格）"},
+	{Name: ColumnTableNameCN, Width: 24, MaxLength: 100,
+		Note: "表的中文名称；同一张表的多行可只在首行填写"},
+	{Name: ColumnFieldNameEN, Width: 28, MaxLength: 64, Required: true,
+		Note: "数据库中的物理字段名"},
+	{Name: ColumnFieldNameCN, Width: 24, MaxLength: 100, Required: true,
+		Note: "字段的中文名称"},
+	{Name: ColumnFieldDesc, Width: 48,
+		Note: "字段含义、取值范围、代码表等补充说明"},
+	{Name: ColumnPrimaryKey, Width: 12, Options: []string{PrimaryKeyYes, PrimaryKeyNo}, Optional: true,
+		Note: "字段是否为所在表的主键；联合主键在各字段均填\"是\""},
+	{Name: ColumnRefTable, Width: 28, MaxLength: 128, Optional: true,
+		Note: "字段引用的表（外键关系）；同库填表名，其他库的表填\"库名.表名\"，库名在多个系统中重名时填\"系统名-库名.表名\""},
+	{Name: ColumnRefField, Width: 28, MaxLength: 64, Optional: true,
+		Note: "被引用表中的字段；留空时与本字段同名"},
+}
+
+// TemplateExampleRows 模板说明页中的示例行（按TemplateColumns顺序）
+var TemplateExampleRows = [][]string{
+	{"entry_head", "报关单表头", "entry_id", "报关单号", "18位报关单编号", PrimaryKeyYes, "", ""},
+	{"", "", "decl_date", "申报日期", "格式YYYYMMDD", "", "", ""},
+	{"", "", "trade_code", "企业编码", "10位海关注册编码", "", "corp.corp_info", "corp_code"},
+}
+
+// templateColumnNames 按模板列顺序返回列名（optional为false时只返回必须包含的列，为true时只返回可选列）
+func templateColumnNames(optional bool) []string {
+	var names []string
+	for _, col := range TemplateColumns {
+		if col.Optional == optional {
+			names = append(names, col.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadSession 浏览器直传MinIO的上传会话（暂存对象完成校验后再创建解析任务）
+type UploadSession struct {
+	ID              string    `gorm:"column:id;primaryKey;comment:会话ID" json:"id"`
+	Uploader        string    `gorm:"column:uploader;index;comment:上传人" json:"uploader"`
+	ResourceComment string    `gorm:"column:resource_comment;comment:资源备注" json:"resource_comment"`
+	FileName        string    `gorm:"column:file_name;comment:Excel文件名" json:"file_name"`
+	ColumnMapping   string    `gorm:"column:column_mapping;comment:指定的列映射方案名称（为空时按表头自动识别）" json:"column_mapping"`
+	ObjectName      string    `gorm:"column:object_name;comment:暂存对象名" json:"object_name"`
+	Size            int64     `gorm:"column:size;comment:声明的文件大小（字节）" json:"size"`
+	PartSize        int64     `gorm:"column:part_size;comment:分片大小（字节）" json:"part_size"`
+	PartCount       int       `gorm:"column:part_count;comment:分片数（单次PUT上传为1）" json:"part_count"`
+	UploadID        string    `gorm:"column:upload_id;comment:MinIO分片上传ID（单次PUT上传为空）" json:"-"`
+	Merged          bool      `gorm:"column:merged;default:false;comment:分片是否已合并" json:"merged"`
+	Status          string    `gorm:"column:status;comment:会话状态（ACTIVE/COMPLETED/FAILED/ABORTED/EXPIRED）" json:"status"`
+	Remark          string    `gorm:"column:remark;comment:备注（校验失败原因）" json:"remark"`
+	DictTaskID      string    `gorm:"column:dict_task_id;comment:完成后创建的字典任务ID" json:"dict_task_id"`
+	ExpiresAt       time.Time `gorm:"column:expires_at;comment:过期时间" json:"expires_at"`
+	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (UploadSession) TableName() string {
+	return TableNameUploadSession
+}
+
+// NewUploadSession 初始化上传会话（文件不超过一个分片时使用单次PUT上传）
+func NewUploadSession(uploader, resourceComment, fileName string, size, partSize int64, ttl time.Duration) *UploadSession {
+	id := uuid.New().String()
+	partCount := 1
+	if size > partSize {
+		partCount = int((size + partSize - 1) / partSize)
+	}
+	now := time.Now()
+	return &UploadSession{
+		ID:              id,
+		Uploader:        uploader,
+		ResourceComment: resourceComment,
+		FileName:        fileName,
+		ObjectName:      "upload_sessions/" + id + "/" + fileName,
+		Size:            size,
+		PartSize:        partSize,
+		PartCount:       partCount,
+		Status:          UploadSessionActive,
+		ExpiresAt:       now.Add(ttl),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// Multipart 是否为分片上传
+func (s *UploadSession) Multipart() bool {
+	return s.PartCount > 1
+}
+
+// PartLength 第n个分片（从1开始）的字节数
+func (s *UploadSession) PartLength(n int) int64 {
+	if n < s.PartCount {
+		return s.PartSize
+	}
+	return s.Size - s.PartSize*int64(s.PartCount-1)
+}
+
+// Active 会话是否仍可上传
+func (s *UploadSession) Active(now time.Time) bool {
+	return s.Status == UploadSessionActive && now.Before(s.ExpiresAt)
+}
+
+// Close 结束会话
+func (s *UploadSession) Close(status string, remark ...string) {
+	s.Status = status
+	if len(remark) > 0 {
+		s.Remark = remark[0]
+	}
+	s.UpdatedAt = time.Now()
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// User 本地用户表（小规模部署时替代外部身份提供方）
+type User struct {
+	ID           string         `gorm:"column:id;primaryKey;comment:用户ID" json:"id"`
+	Username     string         `gorm:"column:username;size:64;uniqueIndex;comment:登录名" json:"username"`
+	DisplayName  string         `gorm:"column:display_name;comment:显示名称" json:"display_name"`
+	PasswordHash string         `gorm:"column:password_hash;comment:密码哈希（bcrypt）" json:"-"`
+	Roles        string         `gorm:"column:roles;comment:角色（逗号分隔，如uploader,reviewer）" json:"roles"`
+	Disabled     bool           `gorm:"column:disabled;default:false;comment:是否禁用" json:"disabled"`
+	CreatedAt    time.Time      `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt    time.Time      `gorm:"column:updated_at;autoUpdateTime;comment:更新时间" json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index;comment:删除时间" json:"deleted_at,omitempty"`
+}
+
+// TableName 指定GORM映射的数据库表名
+func (User) TableName() string {
+	return TableNameUser
+}
+
+// NewUser 初始化用户（密码需由调用方预先哈希）
+func NewUser(username, displayName, passwordHash string, roles ...string) *User {
+	return &User{
+		ID:           uuid.New().String(),
+		Username:     username,
+		DisplayName:  displayName,
+		PasswordHash: passwordHash,
+		Roles:        strings.Join(roles, ","),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+// SetRoles 替换角色
+func (u *User) SetRoles(roles ...string) {
+	u.Roles = strings.Join(roles, ",")
+	u.UpdatedAt = time.Now()
+}
+
+// RoleList 返回角色列表
+func (u *User) RoleList() []string {
+	var roles []string
+	for _, role := range strings.Split(u.Roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
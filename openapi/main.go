@@ -0,0 +1,336 @@
+// openapi 由api/handler中的swag风格注释生成OpenAPI 3文档
+//
+//	go run ./openapi -out api/docs/openapi.json  生成文档（也可在api/docs下执行go generate）
+//	go run ./openapi -check                      校验文档是否过期、与已注册的gin路由是否一致（一致时无输出）
+package main
+
+import (
+	"bytes"
+	"customs/api/router"
+	"customs/common/auth"
+	"customs/config"
+	"customs/service"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handlerDir 处理器目录（相对仓库根目录）
+const handlerDir = "api/handler"
+
+// specFile 嵌入到服务中的文档（相对仓库根目录）
+const specFile = "api/docs/openapi.json"
+
+// undocumentedRoutes 不纳入文档的基础设施路由
+var undocumentedRoutes = map[string]bool{
+	"GET /metrics":                    true,
+	"GET /api/openapi.json":           true,
+	"GET /api/docs":                   true,
+	"GET /api/docs/assets/{filepath}": true,
+}
+
+func main() {
+	root := flag.String("root", ".", "仓库根目录")
+	out := flag.String("out", "", "输出文件（为空时输出到标准输出）")
+	check := flag.Bool("check", false, "校验文档是否过期以及与路由是否一致")
+	flag.Parse()
+
+	spec, err := generate(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "生成OpenAPI文档失败：", err)
+		os.Exit(1)
+	}
+
+	if *check {
+		if problems := checkDrift(*root, spec); len(problems) > 0 {
+			for _, p := range problems {
+				fmt.Fprintln(os.Stderr, p)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *out == "" {
+		_, _ = os.Stdout.Write(spec)
+		return
+	}
+	if err := os.WriteFile(*out, spec, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "写入文件失败：", err)
+		os.Exit(1)
+	}
+}
+
+// generate 生成OpenAPI文档JSON
+func generate(root string) ([]byte, error) {
+	ops, err := parseHandlers(root, handlerDir)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := newSchemaBuilder(root)
+	paths := object{}
+	for _, op := range ops {
+		operation, err := buildOperation(op, schemas)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op.FuncName, err)
+		}
+		for i, r := range op.Routes {
+			item, _ := paths[r.Path].(object)
+			if item == nil {
+				item = object{}
+				paths[r.Path] = item
+			}
+			o := copyObject(operation)
+			o["operationId"] = op.FuncName
+			if i > 0 {
+				o["operationId"] = fmt.Sprintf("%s%d", op.FuncName, i+1)
+			}
+			item[r.Method] = o
+		}
+	}
+
+	doc := object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":       "数据字典服务接口",
+			"description": "数据字典模板下载、Excel上传解析、审批入库与审计查询",
+			"version":     "1.0",
+		},
+		"paths": paths,
+		"components": object{
+			"schemas": schemas.components,
+			"securitySchemes": object{
+				"BearerAuth": object{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+				"BasicAuth":  object{"type": "http", "scheme": "basic"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// buildOperation 将解析出的注释转换为OpenAPI Operation
+func buildOperation(op *operation, schemas *schemaBuilder) (object, error) {
+	o := object{}
+	if op.Summary != "" {
+		o["summary"] = op.Summary
+	}
+	if op.Description != "" {
+		o["description"] = op.Description
+	}
+	if len(op.Tags) > 0 {
+		o["tags"] = op.Tags
+	}
+	if len(op.Security) > 0 {
+		security := make([]interface{}, 0, len(op.Security))
+		for _, name := range op.Security {
+			security = append(security, object{name: []string{}})
+		}
+		o["security"] = security
+	}
+
+	// 参数：query/path/header为parameters，formData/body合并为requestBody
+	var parameters []interface{}
+	formProps, formRequired := object{}, []string{}
+	for _, p := range op.Params {
+		schema, err := schemas.fromAnnotation(p.Type, op.imports)
+		if err != nil {
+			return nil, err
+		}
+		if p.Default != "" {
+			schema["default"] = defaultValue(p.Default, schema)
+		}
+		switch p.In {
+		case "query", "path", "header":
+			parameters = append(parameters, object{
+				"name":        p.Name,
+				"in":          p.In,
+				"required":    p.Required || p.In == "path",
+				"description": p.Desc,
+				"schema":      schema,
+			})
+		case "formData":
+			formProps[p.Name] = withDescription(schema, p.Desc)
+			if p.Required {
+				formRequired = append(formRequired, p.Name)
+			}
+		case "body":
+			o["requestBody"] = object{
+				"required":    p.Required,
+				"description": p.Desc,
+				"content":     object{firstOr(op.Accept, "application/json"): object{"schema": schema}},
+			}
+		default:
+			return nil, fmt.Errorf("不支持的参数位置：%s", p.In)
+		}
+	}
+	if len(parameters) > 0 {
+		o["parameters"] = parameters
+	}
+	if len(formProps) > 0 {
+		formSchema := object{"type": "object", "properties": formProps}
+		if len(formRequired) > 0 {
+			formSchema["required"] = formRequired
+		}
+		o["requestBody"] = object{
+			"required": len(formRequired) > 0,
+			"content":  object{firstOr(op.Accept, "multipart/form-data"): object{"schema": formSchema}},
+		}
+	}
+
+	responses := object{}
+	for _, r := range op.Responses {
+		resp := object{"description": r.Desc}
+		if r.Desc == "" {
+			resp["description"] = defaultDescription(r.Code)
+		}
+		switch r.Kind {
+		case "":
+			// 无响应体（如204）
+		case "file":
+			resp["content"] = object{
+				firstOr(op.Produce, "application/octet-stream"): object{"schema": object{"type": "string", "format": "binary"}},
+			}
+		default:
+			schema, err := schemas.fromAnnotation(r.Type, op.imports)
+			if err != nil {
+				return nil, err
+			}
+			resp["content"] = object{"application/json": object{"schema": schema}}
+		}
+		responses[r.Code] = resp
+	}
+	if len(responses) == 0 {
+		responses["200"] = object{"description": "OK"}
+	}
+	o["responses"] = responses
+	return o, nil
+}
+
+// checkDrift 校验文档是否需要重新生成，以及已注册路由与文档接口是否一一对应
+func checkDrift(root string, spec []byte) []string {
+	var problems []string
+
+	current, err := os.ReadFile(filepath.Join(root, specFile))
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("读取%s失败：%v", specFile, err))
+	} else if !bytes.Equal(current, spec) {
+		problems = append(problems, fmt.Sprintf("%s已过期，请执行 go generate ./api/docs", specFile))
+	}
+
+	documented := make(map[string]bool)
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return append(problems, "解析生成的文档失败："+err.Error())
+	}
+	for path, methods := range doc.Paths {
+		for method := range methods {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	registered := make(map[string]bool)
+	for _, r := range registeredRoutes() {
+		key := r.Method + " " + ginPathToOpenAPI(r.Path)
+		if !undocumentedRoutes[key] {
+			registered[key] = true
+		}
+	}
+
+	for _, key := range sortedKeys(registered) {
+		if !documented[key] {
+			problems = append(problems, "路由未写入文档（缺少@Router注释）："+key)
+		}
+	}
+	for _, key := range sortedKeys(documented) {
+		if !registered[key] {
+			problems = append(problems, "文档中的接口未注册路由："+key)
+		}
+	}
+	return problems
+}
+
+// registeredRoutes 以启用认证的配置构建路由（不连接任何外部依赖），返回全部路由
+func registeredRoutes() gin.RoutesInfo {
+	gin.SetMode(gin.ReleaseMode)
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	r := router.NewRouter(
+		config.Load(),
+		&service.ServiceContainer{},
+		log,
+		[]auth.Authenticator{service.NewUserService(log, nil, nil)},
+	)
+	return r.Routes()
+}
+
+// ginPathParam gin路径参数（:id、*path）
+var ginPathParam = regexp.MustCompile(`[:*](\w+)`)
+
+// ginPathToOpenAPI 将gin路径参数转换为OpenAPI格式（:id -> {id}）
+func ginPathToOpenAPI(path string) string {
+	return ginPathParam.ReplaceAllString(path, "{$1}")
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func firstOr(values []string, fallback string) string {
+	if len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return fallback
+}
+
+func copyObject(o object) object {
+	c := make(object, len(o))
+	for k, v := range o {
+		c[k] = v
+	}
+	return c
+}
+
+// defaultValue 按Schema类型转换default值
+func defaultValue(value string, schema object) interface{} {
+	var v interface{}
+	if schema["type"] != "string" && json.Unmarshal([]byte(value), &v) == nil {
+		return v
+	}
+	return value
+}
+
+// defaultDescription 未写说明时按状态码给出默认说明
+func defaultDescription(code string) string {
+	switch {
+	case strings.HasPrefix(code, "2"):
+		return "OK"
+	case strings.HasPrefix(code, "4"):
+		return "请求错误"
+	default:
+		return "服务错误"
+	}
+}
@@ -0,0 +1,14 @@
+package main
+
+import "testing"
+
+// TestSpecMatchesRoutes 注册的gin路由与生成的文档须一致，且提交的openapi.json不能过期
+func TestSpecMatchesRoutes(t *testing.T) {
+	spec, err := generate("..")
+	if err != nil {
+		t.Fatalf("生成OpenAPI文档失败：%v", err)
+	}
+	for _, problem := range checkDrift("..", spec) {
+		t.Error(problem)
+	}
+}
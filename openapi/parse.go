@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// modulePath 本仓库的Go模块路径（用于把import路径映射为目录）
+const modulePath = "customs"
+
+var (
+	// @Param name in type required "desc" [default(x)]
+	paramRe = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(true|false)\s+"([^"]*)"(?:\s+default\(([^)]*)\))?`)
+	// @Success/@Failure code [{kind} type] ["desc"]
+	responseRe = regexp.MustCompile(`^(\d{3})(?:\s+\{(\w+)\}\s+(\S+))?(?:\s+"([^"]*)")?`)
+	// @Router /path [method]
+	routerRe = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]`)
+)
+
+// operation 从处理器注释中解析出的接口描述
+type operation struct {
+	FuncName    string
+	Summary     string
+	Description string
+	Tags        []string
+	Accept      []string
+	Produce     []string
+	Params      []param
+	Responses   []responseDef
+	Security    []string
+	Routes      []route
+	imports     map[string]string // 包名 -> 目录（相对仓库根目录）
+}
+
+// param @Param注释
+type param struct {
+	Name, In, Type, Desc, Default string
+	Required                      bool
+}
+
+// responseDef @Success/@Failure注释
+type responseDef struct {
+	Code       string
+	Kind, Type string
+	Desc       string
+}
+
+// route @Router注释
+type route struct {
+	Path, Method string
+}
+
+// parseHandlers 解析处理器目录下所有带@Router注释的函数
+func parseHandlers(root, dir string) ([]*operation, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, filepath.Join(root, dir), nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []*operation
+	for _, pkg := range pkgs {
+		for fileName, file := range pkg.Files {
+			imports := fileImports(file)
+			imports[pkg.Name] = filepath.ToSlash(dir) // 注释中可引用本包类型
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Doc == nil {
+					continue
+				}
+				op, err := parseOperation(fn.Name.Name, fn.Doc, imports)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s: %w", fileName, fn.Name.Name, err)
+				}
+				if len(op.Routes) > 0 {
+					ops = append(ops, op)
+				}
+			}
+		}
+	}
+	// 保证输出稳定
+	sort.Slice(ops, func(i, j int) bool { return ops[i].FuncName < ops[j].FuncName })
+	return ops, nil
+}
+
+// fileImports 返回文件中本模块包的 包名 -> 目录 映射
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		if !strings.HasPrefix(path, modulePath+"/") {
+			continue
+		}
+		name := filepath.Base(path)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = strings.TrimPrefix(path, modulePath+"/")
+	}
+	return imports
+}
+
+// parseOperation 解析单个函数的swag风格注释
+func parseOperation(funcName string, doc *ast.CommentGroup, imports map[string]string) (*operation, error) {
+	op := &operation{FuncName: funcName, imports: imports}
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		tag, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+
+		switch tag {
+		case "@Summary":
+			op.Summary = value
+		case "@Description":
+			op.Description = value
+		case "@Tags":
+			op.Tags = splitList(value)
+		case "@Accept":
+			op.Accept = append(op.Accept, mimeType(value))
+		case "@Produce":
+			op.Produce = append(op.Produce, mimeType(value))
+		case "@Security":
+			op.Security = append(op.Security, value)
+		case "@Param":
+			m := paramRe.FindStringSubmatch(value)
+			if m == nil {
+				return nil, fmt.Errorf("无法解析@Param：%s", value)
+			}
+			op.Params = append(op.Params, param{
+				Name: m[1], In: m[2], Type: m[3], Required: m[4] == "true", Desc: m[5], Default: m[6],
+			})
+		case "@Success", "@Failure":
+			m := responseRe.FindStringSubmatch(value)
+			if m == nil {
+				return nil, fmt.Errorf("无法解析%s：%s", tag, value)
+			}
+			op.Responses = append(op.Responses, responseDef{Code: m[1], Kind: m[2], Type: m[3], Desc: m[4]})
+		case "@Router":
+			m := routerRe.FindStringSubmatch(value)
+			if m == nil {
+				return nil, fmt.Errorf("无法解析@Router：%s", value)
+			}
+			op.Routes = append(op.Routes, route{Path: m[1], Method: strings.ToLower(m[2])})
+		}
+	}
+	return op, nil
+}
+
+// splitList 拆分逗号分隔的列表
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// mimeType 将swag的MIME简写转换为完整类型
+func mimeType(s string) string {
+	switch s {
+	case "json":
+		return "application/json"
+	case "mpfd":
+		return "multipart/form-data"
+	case "octet-stream":
+		return "application/octet-stream"
+	default:
+		return s
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// object OpenAPI文档节点（encoding/json按键排序输出，保证生成结果稳定）
+type object = map[string]interface{}
+
+// schemaBuilder 由Go类型生成OpenAPI Schema，引用到的结构体登记为components.schemas
+type schemaBuilder struct {
+	root       string
+	pkgs       map[string]*pkgTypes // 目录 -> 类型定义
+	components object
+}
+
+// pkgTypes 包内的类型定义
+type pkgTypes struct {
+	name  string
+	specs map[string]*ast.TypeSpec
+	files map[string]map[string]string // 类型名 -> 所在文件的本模块import映射
+}
+
+func newSchemaBuilder(root string) *schemaBuilder {
+	return &schemaBuilder{root: root, pkgs: make(map[string]*pkgTypes), components: object{}}
+}
+
+// fromAnnotation 解析注释中的类型表达式，如 response.Response{data=[]string}、map[string]string
+func (b *schemaBuilder) fromAnnotation(expr string, imports map[string]string) (object, error) {
+	base, overrides, hasOverrides := strings.Cut(expr, "{")
+	schema, err := b.fromTypeString(base, imports)
+	if err != nil || !hasOverrides {
+		return schema, err
+	}
+
+	properties := object{}
+	for _, item := range strings.Split(strings.TrimSuffix(overrides, "}"), ",") {
+		name, typ, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("无法解析类型覆盖：%s", item)
+		}
+		prop, err := b.fromTypeString(typ, imports)
+		if err != nil {
+			return nil, err
+		}
+		properties[name] = prop
+	}
+	return object{"allOf": []interface{}{schema, object{"type": "object", "properties": properties}}}, nil
+}
+
+// fromTypeString 解析简单类型表达式
+func (b *schemaBuilder) fromTypeString(typ string, imports map[string]string) (object, error) {
+	switch {
+	case strings.HasPrefix(typ, "[]"):
+		items, err := b.fromTypeString(typ[2:], imports)
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "array", "items": items}, nil
+	case strings.HasPrefix(typ, "map["):
+		_, value, _ := strings.Cut(typ, "]")
+		values, err := b.fromTypeString(value, imports)
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "object", "additionalProperties": values}, nil
+	}
+	if schema := primitive(typ); schema != nil {
+		return schema, nil
+	}
+	pkg, name, ok := strings.Cut(typ, ".")
+	if !ok {
+		return nil, fmt.Errorf("未知类型：%s", typ)
+	}
+	dir, ok := imports[pkg]
+	if !ok {
+		// 与swag一致：注释中可引用未导入的本模块包，按目录名查找
+		if dir, ok = b.findPackage(pkg); !ok {
+			return nil, fmt.Errorf("未找到包：%s", pkg)
+		}
+	}
+	return b.ref(dir, name)
+}
+
+// ref 返回结构体的$ref，首次引用时生成组件定义
+func (b *schemaBuilder) ref(dir, name string) (object, error) {
+	pkg, err := b.loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	spec, ok := pkg.specs[name]
+	if !ok {
+		return nil, fmt.Errorf("类型不存在：%s.%s", pkg.name, name)
+	}
+
+	// 非结构体类型（如 type Status string）直接展开
+	st, isStruct := spec.Type.(*ast.StructType)
+	if !isStruct {
+		return b.fromExpr(spec.Type, dir, pkg.files[name])
+	}
+
+	key := pkg.name + "." + name
+	refObj := object{"$ref": "#/components/schemas/" + key}
+	if _, exists := b.components[key]; exists {
+		return refObj, nil
+	}
+	b.components[key] = object{} // 先占位，避免递归引用死循环
+
+	properties := object{}
+	if err := b.collectFields(st, dir, pkg.files[name], properties); err != nil {
+		return nil, err
+	}
+	schema := object{"type": "object", "properties": properties}
+	if desc := typeDoc(spec); desc != "" {
+		schema["description"] = desc
+	}
+	b.components[key] = schema
+	return refObj, nil
+}
+
+// collectFields 收集结构体的导出字段（嵌入字段展开）
+func (b *schemaBuilder) collectFields(st *ast.StructType, dir string, imports map[string]string, properties object) error {
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			// 嵌入的同包结构体：展开其字段
+			if ident, ok := field.Type.(*ast.Ident); ok {
+				pkg, _ := b.loadPackage(dir)
+				if spec, ok := pkg.specs[ident.Name]; ok {
+					if embedded, ok := spec.Type.(*ast.StructType); ok {
+						if err := b.collectFields(embedded, dir, imports, properties); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			continue
+		}
+
+		jsonName, skip := jsonFieldName(field)
+		if skip || !field.Names[0].IsExported() {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Names[0].Name
+		}
+		schema, err := b.fromExpr(field.Type, dir, imports)
+		if err != nil {
+			return err
+		}
+		if desc := fieldDoc(field); desc != "" {
+			schema = withDescription(schema, desc)
+		}
+		properties[jsonName] = schema
+	}
+	return nil
+}
+
+// fromExpr 由Go类型表达式生成Schema
+func (b *schemaBuilder) fromExpr(expr ast.Expr, dir string, imports map[string]string) (object, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if schema := primitive(t.Name); schema != nil {
+			return schema, nil
+		}
+		return b.ref(dir, t.Name)
+	case *ast.StarExpr:
+		schema, err := b.fromExpr(t.X, dir, imports)
+		if err != nil {
+			return nil, err
+		}
+		if _, isRef := schema["$ref"]; !isRef {
+			schema["nullable"] = true
+		}
+		return schema, nil
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return object{"type": "string", "format": "byte"}, nil
+		}
+		items, err := b.fromExpr(t.Elt, dir, imports)
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "array", "items": items}, nil
+	case *ast.MapType:
+		values, err := b.fromExpr(t.Value, dir, imports)
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "object", "additionalProperties": values}, nil
+	case *ast.InterfaceType:
+		return object{}, nil
+	case *ast.SelectorExpr:
+		pkg, _ := t.X.(*ast.Ident)
+		name := pkg.Name + "." + t.Sel.Name
+		switch name {
+		case "time.Time", "gorm.DeletedAt":
+			return object{"type": "string", "format": "date-time"}, nil
+		case "time.Duration":
+			return object{"type": "integer", "format": "int64"}, nil
+		case "json.RawMessage":
+			return object{}, nil
+		}
+		if dir, ok := imports[pkg.Name]; ok {
+			return b.ref(dir, t.Sel.Name)
+		}
+		return object{"type": "object"}, nil
+	}
+	return object{}, nil
+}
+
+// findPackage 在仓库中查找目录名为name的包（忽略隐藏目录）
+func (b *schemaBuilder) findPackage(name string) (string, bool) {
+	var found string
+	_ = filepath.WalkDir(b.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || found != "" {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != b.root {
+			return filepath.SkipDir
+		}
+		if d.Name() == name {
+			if rel, err := filepath.Rel(b.root, path); err == nil {
+				found = filepath.ToSlash(rel)
+			}
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return found, found != ""
+}
+
+// loadPackage 解析包内的类型定义（带缓存）
+func (b *schemaBuilder) loadPackage(dir string) (*pkgTypes, error) {
+	if pkg, ok := b.pkgs[dir]; ok {
+		return pkg, nil
+	}
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseDir(fset, filepath.Join(b.root, dir), nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &pkgTypes{specs: make(map[string]*ast.TypeSpec), files: make(map[string]map[string]string)}
+	for name, p := range parsed {
+		pkg.name = name
+		for _, file := range p.Files {
+			imports := fileImports(file)
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, s := range gen.Specs {
+					spec := s.(*ast.TypeSpec)
+					if spec.Doc == nil && len(gen.Specs) == 1 {
+						spec.Doc = gen.Doc
+					}
+					pkg.specs[spec.Name.Name] = spec
+					pkg.files[spec.Name.Name] = imports
+				}
+			}
+		}
+	}
+	b.pkgs[dir] = pkg
+	return pkg, nil
+}
+
+// primitive Go基础类型与注释简写对应的Schema
+func primitive(name string) object {
+	switch name {
+	case "string":
+		return object{"type": "string"}
+	case "bool", "boolean":
+		return object{"type": "boolean"}
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32", "integer":
+		return object{"type": "integer"}
+	case "int64", "uint64":
+		return object{"type": "integer", "format": "int64"}
+	case "float32", "float64", "number":
+		return object{"type": "number"}
+	case "file":
+		return object{"type": "string", "format": "binary"}
+	case "any":
+		return object{}
+	}
+	return nil
+}
+
+// jsonFieldName 读取json标签名（"-"表示跳过）
+func jsonFieldName(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag, _ := strconv.Unquote(field.Tag.Value)
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	return name, name == "-"
+}
+
+// fieldDoc 字段说明（优先行尾注释，其次gorm标签中的comment）
+func fieldDoc(field *ast.Field) string {
+	if field.Comment != nil {
+		return strings.TrimSpace(field.Comment.Text())
+	}
+	if field.Doc != nil {
+		return strings.TrimSpace(field.Doc.Text())
+	}
+	if field.Tag != nil {
+		tag, _ := strconv.Unquote(field.Tag.Value)
+		for _, part := range strings.Split(reflect.StructTag(tag).Get("gorm"), ";") {
+			if comment, ok := strings.CutPrefix(part, "comment:"); ok {
+				return comment
+			}
+		}
+	}
+	return ""
+}
+
+// typeDoc 类型说明（注释首行去掉类型名）
+func typeDoc(spec *ast.TypeSpec) string {
+	if spec.Doc == nil {
+		return ""
+	}
+	first, _, _ := strings.Cut(strings.TrimSpace(spec.Doc.Text()), "\n")
+	return strings.TrimSpace(strings.TrimPrefix(first, spec.Name.Name))
+}
+
+// withDescription 为Schema添加说明（$ref不能与同级字段并存，需包一层allOf）
+func withDescription(schema object, desc string) object {
+	if _, isRef := schema["$ref"]; isRef {
+		return object{"allOf": []interface{}{schema}, "description": desc}
+	}
+	schema["description"] = desc
+	return schema
+}
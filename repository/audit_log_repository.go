@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"time"
+)
+
+// AuditLogFilter 审计日志查询条件（零值字段不参与过滤）
+type AuditLogFilter struct {
+	Action       string    // 操作类型
+	Actor        string    // 操作人
+	DictTaskID   string    // 字典任务ID
+	DBResourceID string    // 数据库资源ID
+	Start        time.Time // 起始时间（含）
+	End          time.Time // 截止时间（不含）
+}
+
+// AuditLogRepository 处理 AuditLog 的追加与查询（不提供更新和删除）
+type AuditLogRepository struct {
+	mysqlClient *db.MySQLClient
+}
+
+// NewAuditLogRepository 初始化仓库
+func NewAuditLogRepository(mysqlClient *db.MySQLClient) *AuditLogRepository {
+	return &AuditLogRepository{mysqlClient: mysqlClient}
+}
+
+// Create 追加审计日志
+func (r *AuditLogRepository) Create(ctx context.Context, log *model.AuditLog) (err error) {
+	ctx, span := tracing.Start(ctx, "AuditLogRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Create(log).Error
+}
+
+// List 按条件分页查询审计日志（按时间倒序），返回当前页数据与总数
+func (r *AuditLogRepository) List(
+	ctx context.Context,
+	filter AuditLogFilter,
+	page, size int,
+) (_ []model.AuditLog, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "AuditLogRepository.List")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.AuditLog{})
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.DictTaskID != "" {
+		query = query.Where("dict_task_id = ?", filter.DictTaskID)
+	}
+	if filter.DBResourceID != "" {
+		query = query.Where("db_resource_id = ?", filter.DBResourceID)
+	}
+	if !filter.Start.IsZero() {
+		query = query.Where("created_at >= ?", filter.Start)
+	}
+	if !filter.End.IsZero() {
+		query = query.Where("created_at < ?", filter.End)
+	}
+
+	var total int64
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var logs []model.AuditLog
+	err = query.Order("created_at DESC").Offset((page - 1) * size).Limit(size).Find(&logs).Error
+	return logs, total, err
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
+	"customs/model"
+)
+
+// ColumnMappingRepository 处理列映射方案的 CRUD
+type ColumnMappingRepository struct {
+	mysqlClient *db.MySQLClient
+}
+
+// NewColumnMappingRepository 初始化仓库
+func NewColumnMappingRepository(mysqlClient *db.MySQLClient) *ColumnMappingRepository {
+	return &ColumnMappingRepository{mysqlClient: mysqlClient}
+}
+
+// Create 新增方案
+func (r *ColumnMappingRepository) Create(ctx context.Context, mapping *model.ColumnMapping) (err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Create(mapping).Error
+}
+
+// Update 更新方案
+func (r *ColumnMappingRepository) Update(ctx context.Context, mapping *model.ColumnMapping) (err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingRepository.Update")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Save(mapping).Error
+}
+
+// Delete 删除方案
+func (r *ColumnMappingRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingRepository.Delete")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Where("id = ?", id).Delete(&model.ColumnMapping{}).Error
+}
+
+// GetByID 根据ID查询方案
+func (r *ColumnMappingRepository) GetByID(ctx context.Context, id string) (_ *model.ColumnMapping, err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingRepository.GetByID")
+	defer func() { tracing.End(span, err) }()
+
+	var mapping model.ColumnMapping
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("id = ?", id).First(&mapping).Error
+	return &mapping, err
+}
+
+// GetByName 根据名称查询方案
+func (r *ColumnMappingRepository) GetByName(ctx context.Context, name string) (_ *model.ColumnMapping, err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingRepository.GetByName")
+	defer func() { tracing.End(span, err) }()
+
+	var mapping model.ColumnMapping
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("name = ?", name).First(&mapping).Error
+	return &mapping, err
+}
+
+// List 查询全部方案（按名称排序，自动识别时按此顺序尝试）
+func (r *ColumnMappingRepository) List(ctx context.Context) (_ []model.ColumnMapping, err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingRepository.List")
+	defer func() { tracing.End(span, err) }()
+
+	var mappings []model.ColumnMapping
+	err = r.mysqlClient.GetDB().WithContext(ctx).Order("name").Find(&mappings).Error
+	return mappings, err
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
 	"customs/model"
 )
 
@@ -17,21 +18,115 @@ func NewDBResourceRepository(mysqlClient *db.MySQLClient) *DBResourceRepository
 }
 
 // GetDistinctResourceComment 查询去重的资源备注（对应 Python 的 get_resource_comment）
-func (r *DBResourceRepository) GetDistinctResourceComment(ctx context.Context) ([]string, error) {
+func (r *DBResourceRepository) GetDistinctResourceComment(ctx context.Context) (_ []string, err error) {
+	ctx, span := tracing.Start(ctx, "DBResourceRepository.GetDistinctResourceComment")
+	defer func() { tracing.End(span, err) }()
+
 	var comments []string
-	err := r.mysqlClient.GetDB().WithContext(ctx).
+	err = r.mysqlClient.GetDB().WithContext(ctx).
 		Model(&model.DBResource{}).
 		Distinct("resource_comment").
 		Find(&comments).Error
 	return comments, err
 }
 
-func (r *DBResourceRepository) Create(ctx context.Context, resource *model.DBResource) error {
+func (r *DBResourceRepository) Create(ctx context.Context, resource *model.DBResource) (err error) {
+	ctx, span := tracing.Start(ctx, "DBResourceRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
 	return r.mysqlClient.GetDB().WithContext(ctx).Create(resource).Error
 }
 
-func (r *DBResourceRepository) GetByComment(ctx context.Context, comment string) (*model.DBResource, error) {
+func (r *DBResourceRepository) GetByComment(ctx context.Context, comment string) (_ *model.DBResource, err error) {
+	ctx, span := tracing.Start(ctx, "DBResourceRepository.GetByComment")
+	defer func() { tracing.End(span, err) }()
+
+	var resource model.DBResource
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("resource_comment = ?", comment).First(&resource).Error
+	return &resource, err
+}
+
+// GetByCommentAndDBName 根据资源备注与库名查询资源
+func (r *DBResourceRepository) GetByCommentAndDBName(ctx context.Context, comment, dbName string) (_ *model.DBResource, err error) {
+	ctx, span := tracing.Start(ctx, "DBResourceRepository.GetByCommentAndDBName")
+	defer func() { tracing.End(span, err) }()
+
+	var resource model.DBResource
+	err = r.mysqlClient.GetDB().WithContext(ctx).
+		Where("resource_comment = ? AND db_name = ?", comment, dbName).
+		First(&resource).Error
+	return &resource, err
+}
+
+// Update 更新资源记录
+func (r *DBResourceRepository) Update(ctx context.Context, resource *model.DBResource) (err error) {
+	ctx, span := tracing.Start(ctx, "DBResourceRepository.Update")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Save(resource).Error
+}
+
+// DBResourceFilter 数据库资源列表过滤条件（零值字段不参与过滤）
+type DBResourceFilter struct {
+	ResourceComment string // 资源备注
+	DBName          string // 数据库名
+}
+
+// GetByID 根据ID查询资源
+func (r *DBResourceRepository) GetByID(ctx context.Context, id string) (_ *model.DBResource, err error) {
+	ctx, span := tracing.Start(ctx, "DBResourceRepository.GetByID")
+	defer func() { tracing.End(span, err) }()
+
 	var resource model.DBResource
-	err := r.mysqlClient.GetDB().WithContext(ctx).Where("resource_comment = ?", comment).First(&resource).Error
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("id = ?", id).First(&resource).Error
 	return &resource, err
 }
+
+// List 按条件分页查询资源（按 资源备注、库名 排序），返回当前页数据与总数
+func (r *DBResourceRepository) List(
+	ctx context.Context,
+	filter DBResourceFilter,
+	page, size int,
+) (_ []model.DBResource, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DBResourceRepository.List")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DBResource{})
+	if filter.ResourceComment != "" {
+		query = query.Where("resource_comment = ?", filter.ResourceComment)
+	}
+	if filter.DBName != "" {
+		query = query.Where("db_name = ?", filter.DBName)
+	}
+
+	var total int64
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var resources []model.DBResource
+	err = query.Order("resource_comment").Order("db_name").Offset((page - 1) * size).Limit(size).Find(&resources).Error
+	return resources, total, err
+}
+
+// CountByDBName 统计同名库登记的资源数（不同资源备注下可以登记同名库）
+func (r *DBResourceRepository) CountByDBName(ctx context.Context, dbName string) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DBResourceRepository.CountByDBName")
+	defer func() { tracing.End(span, err) }()
+
+	var count int64
+	err = r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DBResource{}).Where("db_name = ?", dbName).Count(&count).Error
+	return count, err
+}
+
+// ListWithConnection 查询已配置内省连接的全部资源
+func (r *DBResourceRepository) ListWithConnection(ctx context.Context) (_ []model.DBResource, err error) {
+	ctx, span := tracing.Start(ctx, "DBResourceRepository.ListWithConnection")
+	defer func() { tracing.End(span, err) }()
+
+	var resources []model.DBResource
+	err = r.mysqlClient.GetDB().WithContext(ctx).
+		Where("connection_dsn <> ''").
+		Order("resource_comment").Order("db_name").
+		Find(&resources).Error
+	return resources, err
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
+	"customs/model"
+)
+
+// DictionaryBatchFilter 批次列表查询条件（零值字段不参与过滤）
+type DictionaryBatchFilter struct {
+	Uploader        string // 上传人
+	ResourceComment string // 资源备注
+	Decision        string // 审批结论（APPROVED/REJECTED）
+	Pending         bool   // 仅查询未审批的批次
+}
+
+// DictionaryBatchRepository 处理 DictionaryBatch 的 CRUD
+type DictionaryBatchRepository struct {
+	mysqlClient *db.MySQLClient
+}
+
+// NewDictionaryBatchRepository 初始化仓库
+func NewDictionaryBatchRepository(mysqlClient *db.MySQLClient) *DictionaryBatchRepository {
+	return &DictionaryBatchRepository{mysqlClient: mysqlClient}
+}
+
+// Create 创建批次记录
+func (r *DictionaryBatchRepository) Create(ctx context.Context, batch *model.DictionaryBatch) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryBatchRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Create(batch).Error
+}
+
+// GetByID 根据 ID 查询批次
+func (r *DictionaryBatchRepository) GetByID(ctx context.Context, id string) (_ *model.DictionaryBatch, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryBatchRepository.GetByID")
+	defer func() { tracing.End(span, err) }()
+
+	var batch model.DictionaryBatch
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("id = ?", id).First(&batch).Error
+	return &batch, err
+}
+
+// Update 更新批次记录
+func (r *DictionaryBatchRepository) Update(ctx context.Context, batch *model.DictionaryBatch) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryBatchRepository.Update")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Save(batch).Error
+}
+
+// List 按条件分页查询批次（按创建时间倒序），返回当前页数据与总数
+func (r *DictionaryBatchRepository) List(
+	ctx context.Context,
+	filter DictionaryBatchFilter,
+	page, size int,
+) (_ []model.DictionaryBatch, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryBatchRepository.List")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DictionaryBatch{})
+	if filter.Uploader != "" {
+		query = query.Where("uploader = ?", filter.Uploader)
+	}
+	if filter.ResourceComment != "" {
+		query = query.Where("resource_comment = ?", filter.ResourceComment)
+	}
+	if filter.Decision != "" {
+		query = query.Where("decision = ?", filter.Decision)
+	}
+	if filter.Pending {
+		query = query.Where("decision = ?", "")
+	}
+
+	var total int64
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var batches []model.DictionaryBatch
+	err = query.Order("created_at DESC").Offset((page - 1) * size).Limit(size).Find(&batches).Error
+	return batches, total, err
+}
+
+// Decide 写入批次审批结论（仅在批次未审批时生效），返回是否写入成功（并发审批时只有一个请求成功）
+func (r *DictionaryBatchRepository) Decide(ctx context.Context, batch *model.DictionaryBatch) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryBatchRepository.Decide")
+	defer func() { tracing.End(span, err) }()
+
+	result := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DictionaryBatch{}).
+		Where("id = ? AND decision = ?", batch.ID, "").
+		Updates(map[string]interface{}{
+			"confirmer":     batch.Confirmer,
+			"decision":      batch.Decision,
+			"reject_reason": batch.RejectReason,
+			"decided_at":    batch.DecidedAt,
+			"updated_at":    batch.UpdatedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"gorm.io/gorm"
+)
+
+// insertBatchSize 批量插入每批条数
+const insertBatchSize = 500
+
+// DictionaryFieldFilter 字段检索的范围过滤（零值字段不参与过滤）
+type DictionaryFieldFilter struct {
+	DBResourceID    string // 数据库资源ID
+	ResourceComment string // 资源备注
+	SystemName      string // 系统名
+	DBName          string // 数据库名
+}
+
+// DictionaryFieldMatch 全文检索命中的字段及相关度
+type DictionaryFieldMatch struct {
+	model.DictionaryField `gorm:"embedded"`
+	Score                 float64 `gorm:"column:score"`
+}
+
+// DictionaryFieldRepository 处理 DictionaryField 的入库与检索
+type DictionaryFieldRepository struct {
+	mysqlClient *db.MySQLClient
+}
+
+// NewDictionaryFieldRepository 初始化仓库
+func NewDictionaryFieldRepository(mysqlClient *db.MySQLClient) *DictionaryFieldRepository {
+	return &DictionaryFieldRepository{mysqlClient: mysqlClient}
+}
+
+// ReplaceScope 在同一事务中删除 资源备注+库名 下的旧字段并写入新字段（全文索引随事务提交同步），
+// 同时替换数据库资源声明的全部关联关系，字段与关联关系不会只更新其一
+func (r *DictionaryFieldRepository) ReplaceScope(
+	ctx context.Context,
+	resourceComment, dbName string,
+	fields []*model.DictionaryField,
+	dbResourceID string,
+	relations []*model.TableRelation,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryFieldRepository.ReplaceScope")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.WithTransaction(func(tx *gorm.DB) error {
+		tx = tx.WithContext(ctx)
+		if err := tx.Where("resource_comment = ? AND db_name = ?", resourceComment, dbName).
+			Delete(&model.DictionaryField{}).Error; err != nil {
+			return err
+		}
+		if len(fields) > 0 {
+			if err := tx.CreateInBatches(fields, insertBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		return replaceRelations(tx, dbResourceID, relations)
+	})
+}
+
+// Search 全文检索字段（自然语言模式，按相关度倒序），返回当前页数据与命中总数
+func (r *DictionaryFieldRepository) Search(
+	ctx context.Context,
+	query string,
+	filter DictionaryFieldFilter,
+	page, size int,
+) (_ []DictionaryFieldMatch, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryFieldRepository.Search")
+	defer func() { tracing.End(span, err) }()
+
+	match := "MATCH(" + model.DictionaryFieldSearchColumns + ") AGAINST(? IN NATURAL LANGUAGE MODE)"
+	scope := r.filtered(ctx, filter).Where(match, query)
+
+	var total int64
+	if err = scope.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var matches []DictionaryFieldMatch
+	err = scope.Select("*, "+match+" AS score", query).
+		Order("score DESC").Order("table_name_en").Order("field_name_en").
+		Offset((page - 1) * size).Limit(size).
+		Scan(&matches).Error
+	return matches, total, err
+}
+
+// List 查询范围内的全部字段（按 资源备注、库名、工作簿行序 排序）
+func (r *DictionaryFieldRepository) List(
+	ctx context.Context,
+	filter DictionaryFieldFilter,
+) (_ []model.DictionaryField, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryFieldRepository.List")
+	defer func() { tracing.End(span, err) }()
+
+	var fields []model.DictionaryField
+	err = r.filtered(ctx, filter).
+		Order("resource_comment").Order("db_name").Order("row_no").
+		Find(&fields).Error
+	return fields, err
+}
+
+// filtered 按过滤条件构造查询
+func (r *DictionaryFieldRepository) filtered(ctx context.Context, filter DictionaryFieldFilter) *gorm.DB {
+	scope := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DictionaryField{})
+	if filter.DBResourceID != "" {
+		scope = scope.Where("db_resource_id = ?", filter.DBResourceID)
+	}
+	if filter.ResourceComment != "" {
+		scope = scope.Where("resource_comment = ?", filter.ResourceComment)
+	}
+	if filter.SystemName != "" {
+		scope = scope.Where("system_name = ?", filter.SystemName)
+	}
+	if filter.DBName != "" {
+		scope = scope.Where("db_name = ?", filter.DBName)
+	}
+	return scope
+}
+
+// GetByName 按 资源+表名+字段名 查询字段
+func (r *DictionaryFieldRepository) GetByName(
+	ctx context.Context,
+	dbResourceID, tableNameEN, fieldNameEN string,
+) (_ *model.DictionaryField, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryFieldRepository.GetByName")
+	defer func() { tracing.End(span, err) }()
+
+	var field model.DictionaryField
+	err = r.mysqlClient.GetDB().WithContext(ctx).
+		Where("db_resource_id = ? AND table_name_en = ? AND field_name_en = ?", dbResourceID, tableNameEN, fieldNameEN).
+		First(&field).Error
+	return &field, err
+}
@@ -3,9 +3,19 @@ package repository
 import (
 	"context"
 	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
 	"customs/model"
 )
 
+// DictionaryTaskFilter 任务列表查询条件（零值字段不参与过滤）
+type DictionaryTaskFilter struct {
+	Uploader        string // 上传人
+	ResourceComment string // 资源备注
+	Decision        string // 审批结论（APPROVED/REJECTED）
+	Pending         bool   // 仅查询未审批的任务
+	BatchID         string // 所属批次ID
+}
+
 // DictionaryRepository 处理 DictionaryTask 的 CRUD
 type DictionaryRepository struct {
 	mysqlClient *db.MySQLClient // 依赖 Infrastructure 层的通用 MySQL 能力
@@ -17,32 +27,175 @@ func NewDictionaryRepository(mysqlClient *db.MySQLClient) *DictionaryRepository
 }
 
 // Create 创建任务记录（对应 Python 的 add+commit）
-func (r *DictionaryRepository) Create(ctx context.Context, task *model.DictionaryTask) error {
+func (r *DictionaryRepository) Create(ctx context.Context, task *model.DictionaryTask) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
 	return r.mysqlClient.GetDB().WithContext(ctx).Create(task).Error
 }
 
 // GetByID 根据 ID 查询任务（最常用）
-func (r *DictionaryRepository) GetByID(ctx context.Context, id string) (*model.DictionaryTask, error) {
+func (r *DictionaryRepository) GetByID(ctx context.Context, id string) (_ *model.DictionaryTask, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.GetByID")
+	defer func() { tracing.End(span, err) }()
+
 	var task model.DictionaryTask
-	err := r.mysqlClient.GetDB().WithContext(ctx).Where("id = ?", id).First(&task).Error
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("id = ?", id).First(&task).Error
 	return &task, err
 }
 
 // Update 更新任务记录（如状态、CSV 文件名）
-func (r *DictionaryRepository) Update(ctx context.Context, task *model.DictionaryTask) error {
+func (r *DictionaryRepository) Update(ctx context.Context, task *model.DictionaryTask) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.Update")
+	defer func() { tracing.End(span, err) }()
+
 	return r.mysqlClient.GetDB().WithContext(ctx).Save(task).Error
 }
 
+// UpdateCreateDFStatus 只更新解析任务的状态与备注（不覆盖并发写入的其他字段）
+func (r *DictionaryRepository) UpdateCreateDFStatus(ctx context.Context, task *model.DictionaryTask) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.UpdateCreateDFStatus")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DictionaryTask{}).
+		Where("id = ?", task.ID).
+		Updates(map[string]interface{}{
+			"create_df_task_status": task.CreateDFTaskStatus,
+			"create_df_task_remark": task.CreateDFTaskRemark,
+			"updated_at":            task.UpdatedAt,
+		}).Error
+}
+
+// UpdateInsertDFStatus 只更新入库任务的状态与备注（不覆盖并发写入的审批结论等字段）
+func (r *DictionaryRepository) UpdateInsertDFStatus(ctx context.Context, task *model.DictionaryTask) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.UpdateInsertDFStatus")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DictionaryTask{}).
+		Where("id = ?", task.ID).
+		Updates(map[string]interface{}{
+			"insert_df_task_status": task.InsertDFTaskStatus,
+			"insert_df_task_remark": task.InsertDFTaskRemark,
+			"updated_at":            task.UpdatedAt,
+		}).Error
+}
+
+// SetInsertDFTask 补写入库任务ID及待执行状态（审批结论已由Decide写入，这里不再整行保存）
+func (r *DictionaryRepository) SetInsertDFTask(ctx context.Context, task *model.DictionaryTask) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.SetInsertDFTask")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DictionaryTask{}).
+		Where("id = ?", task.ID).
+		Updates(map[string]interface{}{
+			"insert_df_task_id":     task.InsertDFTaskID,
+			"insert_df_task_status": task.InsertDFTaskStatus,
+			"updated_at":            task.UpdatedAt,
+		}).Error
+}
+
 // GetByCreateDFTaskID 根据 create_df_task_id 查询任务（关联 Asynq 任务）
-func (r *DictionaryRepository) GetByCreateDFTaskID(ctx context.Context, taskID string) (*model.DictionaryTask, error) {
+func (r *DictionaryRepository) GetByCreateDFTaskID(ctx context.Context, taskID string) (_ *model.DictionaryTask, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.GetByCreateDFTaskID")
+	defer func() { tracing.End(span, err) }()
+
 	var task model.DictionaryTask
-	err := r.mysqlClient.GetDB().WithContext(ctx).Where("create_df_task_id = ?", taskID).First(&task).Error
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("create_df_task_id = ?", taskID).First(&task).Error
 	return &task, err
 }
 
 // GetByInsertDFTaskID 根据 insert_df_task_id 查询任务
-func (r *DictionaryRepository) GetByInsertDFTaskID(ctx context.Context, taskID string) (*model.DictionaryTask, error) {
+func (r *DictionaryRepository) GetByInsertDFTaskID(ctx context.Context, taskID string) (_ *model.DictionaryTask, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.GetByInsertDFTaskID")
+	defer func() { tracing.End(span, err) }()
+
 	var task model.DictionaryTask
-	err := r.mysqlClient.GetDB().WithContext(ctx).Where("insert_df_task_id = ?", taskID).First(&task).Error
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("insert_df_task_id = ?", taskID).First(&task).Error
 	return &task, err
 }
+
+// List 按条件分页查询任务（按创建时间倒序），返回当前页数据与总数
+func (r *DictionaryRepository) List(
+	ctx context.Context,
+	filter DictionaryTaskFilter,
+	page, size int,
+) (_ []model.DictionaryTask, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.List")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DictionaryTask{})
+	if filter.Uploader != "" {
+		query = query.Where("uploader = ?", filter.Uploader)
+	}
+	if filter.ResourceComment != "" {
+		query = query.Where("resource_comment = ?", filter.ResourceComment)
+	}
+	if filter.Decision != "" {
+		query = query.Where("decision = ?", filter.Decision)
+	}
+	if filter.Pending {
+		query = query.Where("decision = ?", "")
+	}
+	if filter.BatchID != "" {
+		query = query.Where("batch_id = ?", filter.BatchID)
+	}
+
+	var total int64
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var tasks []model.DictionaryTask
+	err = query.Order("created_at DESC").Offset((page - 1) * size).Limit(size).Find(&tasks).Error
+	return tasks, total, err
+}
+
+// Delete 删除任务记录（软删除）
+func (r *DictionaryRepository) Delete(ctx context.Context, task *model.DictionaryTask) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.Delete")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Delete(task).Error
+}
+
+// ListByBatchID 查询批次下的全部子任务（按文件名排序）
+func (r *DictionaryRepository) ListByBatchID(ctx context.Context, batchID string) (_ []model.DictionaryTask, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.ListByBatchID")
+	defer func() { tracing.End(span, err) }()
+
+	var tasks []model.DictionaryTask
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("batch_id = ?", batchID).Order("excel_name").Find(&tasks).Error
+	return tasks, err
+}
+
+// Decide 写入审批结论（仅在任务未审批时生效），返回是否写入成功（并发审批时只有一个请求成功）
+func (r *DictionaryRepository) Decide(ctx context.Context, task *model.DictionaryTask) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.Decide")
+	defer func() { tracing.End(span, err) }()
+
+	result := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DictionaryTask{}).
+		Where("id = ? AND decision = ?", task.ID, "").
+		Updates(map[string]interface{}{
+			"confirm":       task.Confirm,
+			"confirmer":     task.Confirmer,
+			"decision":      task.Decision,
+			"reject_reason": task.RejectReason,
+			"decided_at":    task.DecidedAt,
+			"updated_at":    task.UpdatedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// UndoDecision 撤销尚未生产入库任务的审批结论（确认后入库任务入队失败时回退，以便重新审批）
+func (r *DictionaryRepository) UndoDecision(ctx context.Context, task *model.DictionaryTask) (err error) {
+	ctx, span := tracing.Start(ctx, "DictionaryRepository.UndoDecision")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DictionaryTask{}).
+		Where("id = ? AND decision = ? AND insert_df_task_id = ?", task.ID, task.Decision, "").
+		Updates(map[string]interface{}{
+			"confirm":    false,
+			"confirmer":  "",
+			"decision":   "",
+			"decided_at": nil,
+		}).Error
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
+	"customs/model"
+)
+
+// DriftReportFilter 差异报告查询条件（零值字段不参与过滤）
+type DriftReportFilter struct {
+	DBResourceID string // 数据库资源ID
+	Status       string // 检查结果
+}
+
+// DriftReportRepository 处理 DriftReport 的追加与查询
+type DriftReportRepository struct {
+	mysqlClient *db.MySQLClient
+}
+
+// NewDriftReportRepository 初始化仓库
+func NewDriftReportRepository(mysqlClient *db.MySQLClient) *DriftReportRepository {
+	return &DriftReportRepository{mysqlClient: mysqlClient}
+}
+
+// Create 追加差异报告
+func (r *DriftReportRepository) Create(ctx context.Context, report *model.DriftReport) (err error) {
+	ctx, span := tracing.Start(ctx, "DriftReportRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Create(report).Error
+}
+
+// Latest 查询资源最近一次的差异报告
+func (r *DriftReportRepository) Latest(ctx context.Context, dbResourceID string) (_ *model.DriftReport, err error) {
+	ctx, span := tracing.Start(ctx, "DriftReportRepository.Latest")
+	defer func() { tracing.End(span, err) }()
+
+	var report model.DriftReport
+	err = r.mysqlClient.GetDB().WithContext(ctx).
+		Where("db_resource_id = ?", dbResourceID).
+		Order("created_at DESC").
+		First(&report).Error
+	return &report, err
+}
+
+// List 按条件分页查询差异报告（按检查时间倒序，不含差异明细），返回当前页数据与总数
+func (r *DriftReportRepository) List(
+	ctx context.Context,
+	filter DriftReportFilter,
+	page, size int,
+) (_ []model.DriftReport, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DriftReportRepository.List")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.DriftReport{})
+	if filter.DBResourceID != "" {
+		query = query.Where("db_resource_id = ?", filter.DBResourceID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var reports []model.DriftReport
+	err = query.Omit("items").Order("created_at DESC").Offset((page - 1) * size).Limit(size).Find(&reports).Error
+	return reports, total, err
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"gorm.io/gorm"
+)
+
+// TermLinkFilter 术语关联查询条件（零值字段不参与过滤）
+type TermLinkFilter struct {
+	TermID       string // 术语ID
+	DBResourceID string // 数据库资源ID
+}
+
+// TermLinkDetail 术语关联及术语、字段信息（字段已不在最新字典中时字段信息为空）
+type TermLinkDetail struct {
+	model.TermLink `gorm:"embedded"`
+	TermName       string `gorm:"column:term_name" json:"term_name"`         // 术语名称
+	Abbreviation   string `gorm:"column:abbreviation" json:"abbreviation"`   // 标准英文缩写
+	SystemName     string `gorm:"column:system_name" json:"system_name"`     // 系统名
+	DBName         string `gorm:"column:db_name" json:"db_name"`             // 数据库名
+	TableNameCN    string `gorm:"column:table_name_cn" json:"table_name_cn"` // 数据表名称（中文）
+	FieldNameCN    string `gorm:"column:field_name_cn" json:"field_name_cn"` // 字段名称（中文）
+	FieldDesc      string `gorm:"column:field_desc" json:"field_desc"`       // 字段说明
+}
+
+// GlossaryRepository 处理业务术语及字段关联的 CRUD
+type GlossaryRepository struct {
+	mysqlClient *db.MySQLClient
+}
+
+// NewGlossaryRepository 初始化仓库
+func NewGlossaryRepository(mysqlClient *db.MySQLClient) *GlossaryRepository {
+	return &GlossaryRepository{mysqlClient: mysqlClient}
+}
+
+// CreateTerm 新增术语
+func (r *GlossaryRepository) CreateTerm(ctx context.Context, term *model.GlossaryTerm) (err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.CreateTerm")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Create(term).Error
+}
+
+// UpdateTerm 更新术语
+func (r *GlossaryRepository) UpdateTerm(ctx context.Context, term *model.GlossaryTerm) (err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.UpdateTerm")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Save(term).Error
+}
+
+// DeleteTerm 在同一事务中删除术语及其全部字段关联
+func (r *GlossaryRepository) DeleteTerm(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.DeleteTerm")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.WithTransaction(func(tx *gorm.DB) error {
+		tx = tx.WithContext(ctx)
+		if err := tx.Where("term_id = ?", id).Delete(&model.TermLink{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&model.GlossaryTerm{}).Error
+	})
+}
+
+// GetTerm 根据ID查询术语
+func (r *GlossaryRepository) GetTerm(ctx context.Context, id string) (_ *model.GlossaryTerm, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.GetTerm")
+	defer func() { tracing.End(span, err) }()
+
+	var term model.GlossaryTerm
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("id = ?", id).First(&term).Error
+	return &term, err
+}
+
+// GetTermByName 根据名称查询术语
+func (r *GlossaryRepository) GetTermByName(ctx context.Context, name string) (_ *model.GlossaryTerm, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.GetTermByName")
+	defer func() { tracing.End(span, err) }()
+
+	var term model.GlossaryTerm
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("name = ?", name).First(&term).Error
+	return &term, err
+}
+
+// ListTerms 分页查询术语（keyword模糊匹配名称与英文缩写，按名称排序），返回当前页数据与总数
+func (r *GlossaryRepository) ListTerms(
+	ctx context.Context,
+	keyword string,
+	page, size int,
+) (_ []model.GlossaryTerm, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.ListTerms")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.GlossaryTerm{})
+	if keyword != "" {
+		like := "%" + keyword + "%"
+		query = query.Where("name LIKE ? OR abbreviation LIKE ?", like, like)
+	}
+
+	var total int64
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var terms []model.GlossaryTerm
+	err = query.Order("name").Offset((page - 1) * size).Limit(size).Find(&terms).Error
+	return terms, total, err
+}
+
+// AllTerms 查询全部术语（解析时自动匹配）
+func (r *GlossaryRepository) AllTerms(ctx context.Context) (_ []model.GlossaryTerm, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.AllTerms")
+	defer func() { tracing.End(span, err) }()
+
+	var terms []model.GlossaryTerm
+	err = r.mysqlClient.GetDB().WithContext(ctx).Order("name").Find(&terms).Error
+	return terms, err
+}
+
+// LinkField 关联字段与术语（字段已有关联时替换）
+func (r *GlossaryRepository) LinkField(ctx context.Context, link *model.TermLink) (err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.LinkField")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.WithTransaction(func(tx *gorm.DB) error {
+		tx = tx.WithContext(ctx)
+		if err := tx.Where("db_resource_id = ? AND table_name_en = ? AND field_name_en = ?",
+			link.DBResourceID, link.TableNameEN, link.FieldNameEN).Delete(&model.TermLink{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(link).Error
+	})
+}
+
+// UnlinkField 取消字段的术语关联，返回删除的条数
+func (r *GlossaryRepository) UnlinkField(ctx context.Context, dbResourceID, tableNameEN, fieldNameEN string) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.UnlinkField")
+	defer func() { tracing.End(span, err) }()
+
+	result := r.mysqlClient.GetDB().WithContext(ctx).
+		Where("db_resource_id = ? AND table_name_en = ? AND field_name_en = ?", dbResourceID, tableNameEN, fieldNameEN).
+		Delete(&model.TermLink{})
+	return result.RowsAffected, result.Error
+}
+
+// ReplaceAutoLinks 在同一事务中替换资源的自动关联（已手动关联的字段保留手动关联）
+func (r *GlossaryRepository) ReplaceAutoLinks(ctx context.Context, dbResourceID string, links []*model.TermLink) (err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.ReplaceAutoLinks")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.WithTransaction(func(tx *gorm.DB) error {
+		tx = tx.WithContext(ctx)
+		if err := tx.Where("db_resource_id = ? AND source = ?", dbResourceID, model.TermLinkAuto).
+			Delete(&model.TermLink{}).Error; err != nil {
+			return err
+		}
+		var manual []model.TermLink
+		if err := tx.Where("db_resource_id = ?", dbResourceID).Find(&manual).Error; err != nil {
+			return err
+		}
+		linked := make(map[[2]string]bool, len(manual))
+		for _, l := range manual {
+			linked[[2]string{l.TableNameEN, l.FieldNameEN}] = true
+		}
+		var auto []*model.TermLink
+		for _, l := range links {
+			if !linked[[2]string{l.TableNameEN, l.FieldNameEN}] {
+				auto = append(auto, l)
+			}
+		}
+		if len(auto) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(auto, insertBatchSize).Error
+	})
+}
+
+// ListLinks 按条件查询术语关联及术语、字段信息（按系统名、库名、表名、字段名排序）
+func (r *GlossaryRepository) ListLinks(ctx context.Context, filter TermLinkFilter) (_ []TermLinkDetail, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryRepository.ListLinks")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.mysqlClient.GetDB().WithContext(ctx).
+		Table(model.TableNameTermLink + " AS l").
+		Select("l.*, t.name AS term_name, t.abbreviation, f.system_name, f.db_name, f.table_name_cn, f.field_name_cn, f.field_desc").
+		Joins("JOIN " + model.TableNameGlossaryTerm + " AS t ON t.id = l.term_id").
+		Joins("LEFT JOIN " + model.TableNameDictionaryField + " AS f ON f.db_resource_id = l.db_resource_id" +
+			" AND f.table_name_en = l.table_name_en AND f.field_name_en = l.field_name_en")
+	if filter.TermID != "" {
+		query = query.Where("l.term_id = ?", filter.TermID)
+	}
+	if filter.DBResourceID != "" {
+		query = query.Where("l.db_resource_id = ?", filter.DBResourceID)
+	}
+
+	var links []TermLinkDetail
+	err = query.Order("f.system_name").Order("f.db_name").Order("l.table_name_en").Order("l.field_name_en").Find(&links).Error
+	return links, err
+}
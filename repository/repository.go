@@ -4,14 +4,32 @@ import "customs/infrastructure/db"
 
 // RepositoryContainer 封装所有仓库实例
 type RepositoryContainer struct {
-	Dictionary *DictionaryRepository // 任务记录仓库
-	DBResource *DBResourceRepository // 资源备注仓库
+	Dictionary *DictionaryRepository      // 任务记录仓库
+	Batch      *DictionaryBatchRepository // 批次记录仓库
+	Upload     *UploadSessionRepository   // 直传会话仓库
+	Field      *DictionaryFieldRepository // 已入库字典字段仓库
+	DBResource *DBResourceRepository      // 资源备注仓库
+	User       *UserRepository            // 本地用户仓库
+	AuditLog   *AuditLogRepository        // 审计日志仓库
+	Drift      *DriftReportRepository     // 表结构差异报告仓库
+	Glossary   *GlossaryRepository        // 业务术语仓库
+	Relation   *TableRelationRepository   // 字段关联关系仓库
+	Mapping    *ColumnMappingRepository   // 列映射方案仓库
 }
 
 // NewRepositoryContainer 初始化所有仓库（注入 Infrastructure 层的 MySQL 客户端）
 func NewRepositoryContainer(mysqlClient *db.MySQLClient) *RepositoryContainer {
 	return &RepositoryContainer{
 		Dictionary: NewDictionaryRepository(mysqlClient),
+		Batch:      NewDictionaryBatchRepository(mysqlClient),
+		Upload:     NewUploadSessionRepository(mysqlClient),
+		Field:      NewDictionaryFieldRepository(mysqlClient),
 		DBResource: NewDBResourceRepository(mysqlClient),
+		User:       NewUserRepository(mysqlClient),
+		AuditLog:   NewAuditLogRepository(mysqlClient),
+		Drift:      NewDriftReportRepository(mysqlClient),
+		Glossary:   NewGlossaryRepository(mysqlClient),
+		Relation:   NewTableRelationRepository(mysqlClient),
+		Mapping:    NewColumnMappingRepository(mysqlClient),
 	}
 }
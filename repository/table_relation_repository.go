@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"gorm.io/gorm"
+)
+
+// TableRelationRepository 处理字段关联关系的入库与查询
+type TableRelationRepository struct {
+	mysqlClient *db.MySQLClient
+}
+
+// NewTableRelationRepository 初始化仓库
+func NewTableRelationRepository(mysqlClient *db.MySQLClient) *TableRelationRepository {
+	return &TableRelationRepository{mysqlClient: mysqlClient}
+}
+
+// replaceRelations 在调用方的事务中替换数据库资源声明的全部关联关系（随字段一起入库，见DictionaryFieldRepository.ReplaceScope）
+func replaceRelations(tx *gorm.DB, dbResourceID string, relations []*model.TableRelation) error {
+	if err := tx.Where("db_resource_id = ?", dbResourceID).Delete(&model.TableRelation{}).Error; err != nil {
+		return err
+	}
+	if len(relations) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(relations, insertBatchSize).Error
+}
+
+// ListByResource 查询数据库资源声明的关联关系（tableNameEN为空时返回全部表，按表名、字段名排序）
+func (r *TableRelationRepository) ListByResource(
+	ctx context.Context,
+	dbResourceID, tableNameEN string,
+) (_ []model.TableRelation, err error) {
+	ctx, span := tracing.Start(ctx, "TableRelationRepository.ListByResource")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.mysqlClient.GetDB().WithContext(ctx).Where("db_resource_id = ?", dbResourceID)
+	if tableNameEN != "" {
+		query = query.Where("table_name_en = ?", tableNameEN)
+	}
+	var relations []model.TableRelation
+	err = query.Order("table_name_en").Order("field_name_en").Find(&relations).Error
+	return relations, err
+}
+
+// ListReferencing 查询引用指定库表的关联关系（跨系统，按系统名、库名、表名、字段名排序）
+// 包含写明被引用系统名且与refSystemName一致的关系，以及未限定系统名的关系
+func (r *TableRelationRepository) ListReferencing(
+	ctx context.Context,
+	refSystemName, refDBName, refTableNameEN string,
+) (_ []model.TableRelation, err error) {
+	ctx, span := tracing.Start(ctx, "TableRelationRepository.ListReferencing")
+	defer func() { tracing.End(span, err) }()
+
+	var relations []model.TableRelation
+	err = r.mysqlClient.GetDB().WithContext(ctx).
+		Where("ref_db_name = ? AND ref_table_name_en = ?", refDBName, refTableNameEN).
+		Where("ref_system_name IN ?", []string{refSystemName, ""}).
+		Order("system_name").Order("db_name").Order("table_name_en").Order("field_name_en").
+		Find(&relations).Error
+	return relations, err
+}
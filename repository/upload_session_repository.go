@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"time"
+)
+
+// UploadSessionRepository 处理 UploadSession 的 CRUD
+type UploadSessionRepository struct {
+	mysqlClient *db.MySQLClient
+}
+
+// NewUploadSessionRepository 初始化仓库
+func NewUploadSessionRepository(mysqlClient *db.MySQLClient) *UploadSessionRepository {
+	return &UploadSessionRepository{mysqlClient: mysqlClient}
+}
+
+// Create 创建上传会话
+func (r *UploadSessionRepository) Create(ctx context.Context, session *model.UploadSession) (err error) {
+	ctx, span := tracing.Start(ctx, "UploadSessionRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Create(session).Error
+}
+
+// GetByID 根据 ID 查询上传会话
+func (r *UploadSessionRepository) GetByID(ctx context.Context, id string) (_ *model.UploadSession, err error) {
+	ctx, span := tracing.Start(ctx, "UploadSessionRepository.GetByID")
+	defer func() { tracing.End(span, err) }()
+
+	var session model.UploadSession
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("id = ?", id).First(&session).Error
+	return &session, err
+}
+
+// Update 更新上传会话
+func (r *UploadSessionRepository) Update(ctx context.Context, session *model.UploadSession) (err error) {
+	ctx, span := tracing.Start(ctx, "UploadSessionRepository.Update")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Save(session).Error
+}
+
+// ListExpired 查询已过期但仍为上传中状态的会话（按过期时间排序，最多limit条）
+func (r *UploadSessionRepository) ListExpired(ctx context.Context, now time.Time, limit int) (_ []model.UploadSession, err error) {
+	ctx, span := tracing.Start(ctx, "UploadSessionRepository.ListExpired")
+	defer func() { tracing.End(span, err) }()
+
+	var sessions []model.UploadSession
+	err = r.mysqlClient.GetDB().WithContext(ctx).
+		Where("status = ? AND expires_at < ?", model.UploadSessionActive, now).
+		Order("expires_at").Limit(limit).Find(&sessions).Error
+	return sessions, err
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/tracing"
+	"customs/model"
+)
+
+// UserRepository 处理 User 的 CRUD
+type UserRepository struct {
+	mysqlClient *db.MySQLClient
+}
+
+// NewUserRepository 初始化仓库
+func NewUserRepository(mysqlClient *db.MySQLClient) *UserRepository {
+	return &UserRepository{mysqlClient: mysqlClient}
+}
+
+// Create 创建用户
+func (r *UserRepository) Create(ctx context.Context, user *model.User) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Create(user).Error
+}
+
+// GetByUsername 根据登录名查询用户
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (_ *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByUsername")
+	defer func() { tracing.End(span, err) }()
+
+	var user model.User
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("username = ?", username).First(&user).Error
+	return &user, err
+}
+
+// Count 查询用户总数
+func (r *UserRepository) Count(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Count")
+	defer func() { tracing.End(span, err) }()
+
+	var count int64
+	err = r.mysqlClient.GetDB().WithContext(ctx).Model(&model.User{}).Count(&count).Error
+	return count, err
+}
+
+// GetByID 根据 ID 查询用户
+func (r *UserRepository) GetByID(ctx context.Context, id string) (_ *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByID")
+	defer func() { tracing.End(span, err) }()
+
+	var user model.User
+	err = r.mysqlClient.GetDB().WithContext(ctx).Where("id = ?", id).First(&user).Error
+	return &user, err
+}
+
+// Update 更新用户
+func (r *UserRepository) Update(ctx context.Context, user *model.User) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Update")
+	defer func() { tracing.End(span, err) }()
+
+	return r.mysqlClient.GetDB().WithContext(ctx).Save(user).Error
+}
+
+// List 分页查询用户（按登录名排序），返回当前页数据与总数
+func (r *UserRepository) List(ctx context.Context, page, size int) (_ []model.User, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.List")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.mysqlClient.GetDB().WithContext(ctx).Model(&model.User{})
+	var total int64
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var users []model.User
+	err = query.Order("username").Offset((page - 1) * size).Limit(size).Find(&users).Error
+	return users, total, err
+}
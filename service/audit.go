@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"customs/common/auth"
+	"customs/common/errno"
+	"customs/common/logger"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"log/slog"
+	"time"
+)
+
+// auditWriteTimeout 写审计日志的独立超时（调用方上下文可能已取消）
+const auditWriteTimeout = 5 * time.Second
+
+// AuditService 审计日志服务（写入失败仅记录错误日志，不阻断业务流程）
+type AuditService struct {
+	logger    *slog.Logger
+	auditRepo *repository.AuditLogRepository
+}
+
+// NewAuditService 初始化审计日志服务
+func NewAuditService(logger *slog.Logger, auditRepo *repository.AuditLogRepository) *AuditService {
+	return &AuditService{logger: logger, auditRepo: auditRepo}
+}
+
+// Record 记录审计日志（操作人、客户端IP、请求ID取自上下文）
+func (s *AuditService) Record(ctx context.Context, action, dictTaskID, dbResourceID string, detail interface{}) {
+	s.RecordAs(ctx, auth.Actor(ctx), action, dictTaskID, dbResourceID, detail)
+}
+
+// RecordAs 以指定操作人记录审计日志（异步任务中无认证上下文时使用）
+func (s *AuditService) RecordAs(ctx context.Context, actor, action, dictTaskID, dbResourceID string, detail interface{}) {
+	entry := model.NewAuditLog(
+		action,
+		actor,
+		logger.ClientIP(ctx),
+		dictTaskID,
+		dbResourceID,
+		logger.RequestID(ctx),
+		detail,
+	)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditWriteTimeout)
+	defer cancel()
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.ErrorContext(ctx, "写入审计日志失败",
+			slog.String("action", action), slog.String("actor", actor), slog.Any("error", err))
+	}
+}
+
+// Query 按条件分页查询审计日志
+func (s *AuditService) Query(
+	ctx context.Context,
+	filter repository.AuditLogFilter,
+	page, size int,
+) (_ []model.AuditLog, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "AuditService.Query")
+	defer func() { tracing.End(span, err) }()
+
+	logs, total, err := s.auditRepo.List(ctx, filter, page, size)
+	if err != nil {
+		return nil, 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return logs, total, nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"customs/common/auth"
+	"customs/config"
+	"fmt"
+)
+
+// 认证方式名称（对应AUTH_PROVIDERS配置）
+const (
+	AuthProviderJWT   = "jwt"
+	AuthProviderLocal = "local"
+)
+
+// NewAuthenticators 按配置顺序组装认证器（为空表示不启用认证）
+func NewAuthenticators(cfg *config.Config, userSvc *UserService) ([]auth.Authenticator, error) {
+	authenticators := make([]auth.Authenticator, 0, len(cfg.Auth.Providers))
+	for _, provider := range cfg.Auth.Providers {
+		switch provider {
+		case AuthProviderJWT:
+			jwtAuth, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+				Issuer:         cfg.Auth.JWTIssuer,
+				Audience:       cfg.Auth.JWTAudience,
+				Secret:         cfg.Auth.JWTSecret,
+				PublicKeyFiles: cfg.Auth.JWTPublicKeyFiles,
+				UsernameClaim:  cfg.Auth.JWTUsernameClaim,
+				RolesClaim:     cfg.Auth.JWTRolesClaim,
+			})
+			if err != nil {
+				return nil, err
+			}
+			authenticators = append(authenticators, jwtAuth)
+		case AuthProviderLocal:
+			authenticators = append(authenticators, userSvc)
+		default:
+			return nil, fmt.Errorf("不支持的认证方式：%s", provider)
+		}
+	}
+	return authenticators, nil
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"customs/common/auth"
+	"customs/common/errno"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"errors"
+	"gorm.io/gorm"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ColumnMappingService 列映射方案服务（维护其他单位模板的表头别名）
+type ColumnMappingService struct {
+	logger      *slog.Logger
+	mappingRepo *repository.ColumnMappingRepository
+	auditSvc    *AuditService
+}
+
+// NewColumnMappingService 初始化列映射方案服务
+func NewColumnMappingService(
+	logger *slog.Logger,
+	mappingRepo *repository.ColumnMappingRepository,
+	auditSvc *AuditService,
+) *ColumnMappingService {
+	return &ColumnMappingService{
+		logger:      logger,
+		mappingRepo: mappingRepo,
+		auditSvc:    auditSvc,
+	}
+}
+
+// ColumnMappingInput 新增或修改方案的参数
+type ColumnMappingInput struct {
+	Name        string              // 方案名称
+	Description string              // 方案说明
+	Aliases     map[string][]string // 模板列名到表头别名
+}
+
+// ListMappings 查询全部方案
+func (s *ColumnMappingService) ListMappings(ctx context.Context) (_ []model.ColumnMapping, err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingService.ListMappings")
+	defer func() { tracing.End(span, err) }()
+
+	mappings, err := s.mappingRepo.List(ctx)
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	if mappings == nil {
+		mappings = []model.ColumnMapping{}
+	}
+	return mappings, nil
+}
+
+// GetMapping 查询方案
+func (s *ColumnMappingService) GetMapping(ctx context.Context, id string) (_ *model.ColumnMapping, err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingService.GetMapping")
+	defer func() { tracing.End(span, err) }()
+
+	return findColumnMapping(s.mappingRepo.GetByID(ctx, id))
+}
+
+// CreateMapping 新增方案（名称唯一）
+func (s *ColumnMappingService) CreateMapping(ctx context.Context, in ColumnMappingInput) (_ *model.ColumnMapping, err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingService.CreateMapping")
+	defer func() { tracing.End(span, err) }()
+
+	in, err = s.normalizeInput(ctx, in, "")
+	if err != nil {
+		return nil, err
+	}
+	mapping := model.NewColumnMapping(in.Name, in.Description, in.Aliases, auth.Actor(ctx))
+	if err := s.mappingRepo.Create(ctx, mapping); err != nil {
+		return nil, errno.ErrDBInsertFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionMappingCreate, "", "", map[string]interface{}{
+		"column_mapping_id": mapping.ID,
+		"name":              mapping.Name,
+		"aliases":           mapping.Aliases,
+	})
+	return mapping, nil
+}
+
+// UpdateMapping 修改方案
+func (s *ColumnMappingService) UpdateMapping(ctx context.Context, id string, in ColumnMappingInput) (_ *model.ColumnMapping, err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingService.UpdateMapping")
+	defer func() { tracing.End(span, err) }()
+
+	mapping, err := findColumnMapping(s.mappingRepo.GetByID(ctx, id))
+	if err != nil {
+		return nil, err
+	}
+	in, err = s.normalizeInput(ctx, in, mapping.ID)
+	if err != nil {
+		return nil, err
+	}
+	before := map[string]interface{}{"name": mapping.Name, "aliases": mapping.Aliases}
+	mapping.Name, mapping.Description, mapping.Aliases = in.Name, in.Description, in.Aliases
+	if err := s.mappingRepo.Update(ctx, mapping); err != nil {
+		return nil, errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionMappingUpdate, "", "", map[string]interface{}{
+		"column_mapping_id": mapping.ID,
+		"before":            before,
+		"after":             map[string]interface{}{"name": mapping.Name, "aliases": mapping.Aliases},
+	})
+	return mapping, nil
+}
+
+// DeleteMapping 删除方案（已上传的任务保存了别名快照，解析不受影响）
+func (s *ColumnMappingService) DeleteMapping(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "ColumnMappingService.DeleteMapping")
+	defer func() { tracing.End(span, err) }()
+
+	mapping, err := findColumnMapping(s.mappingRepo.GetByID(ctx, id))
+	if err != nil {
+		return err
+	}
+	if err := s.mappingRepo.Delete(ctx, mapping.ID); err != nil {
+		return errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionMappingDelete, "", "", map[string]string{
+		"column_mapping_id": mapping.ID,
+		"name":              mapping.Name,
+	})
+	return nil
+}
+
+// normalizeInput 去除首尾空白与重复别名，并校验名称唯一、列名为模板列、别名不与其他列冲突
+func (s *ColumnMappingService) normalizeInput(ctx context.Context, in ColumnMappingInput, selfID string) (ColumnMappingInput, error) {
+	out := ColumnMappingInput{
+		Name:        strings.TrimSpace(in.Name),
+		Description: strings.TrimSpace(in.Description),
+		Aliases:     make(map[string][]string, len(in.Aliases)),
+	}
+	if out.Name == "" {
+		return out, errno.ErrInvalidParam.WithMessage("方案名称不能为空")
+	}
+
+	owner := make(map[string]string) // 规范化表头到模板列名
+	for _, col := range model.TemplateColumnNames {
+		owner[headerKey(col)] = col
+	}
+	for col, aliases := range in.Aliases {
+		if !slices.Contains(model.TemplateColumnNames, col) {
+			return out, errno.ErrInvalidParam.WithMessage("不是模板列：" + col).
+				WithDetails(map[string]interface{}{"columns": model.TemplateColumnNames})
+		}
+		for _, alias := range aliases {
+			alias = strings.TrimSpace(alias)
+			key := headerKey(alias)
+			if key == "" {
+				continue
+			}
+			if prev, ok := owner[key]; ok && prev != col {
+				return out, errno.ErrInvalidParam.WithMessage("别名" + alias + "已对应模板列：" + prev)
+			} else if ok {
+				continue
+			}
+			owner[key] = col
+			out.Aliases[col] = append(out.Aliases[col], alias)
+		}
+	}
+	if len(out.Aliases) == 0 {
+		return out, errno.ErrInvalidParam.WithMessage("至少配置一个表头别名")
+	}
+
+	existing, err := s.mappingRepo.GetByName(ctx, out.Name)
+	if err == nil && existing.ID != selfID {
+		return out, errno.ErrColumnMappingExists.WithDetails(map[string]string{"column_mapping_id": existing.ID})
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return out, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return out, nil
+}
+
+// findColumnMapping 转换方案查询结果（不存在时返回ErrColumnMappingNotFound）
+func findColumnMapping(mapping *model.ColumnMapping, err error) (*model.ColumnMapping, error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrColumnMappingNotFound
+		}
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return mapping, nil
+}
+
+// HeaderMapper 将表头单元格映射为模板列名（模板列名本身始终可识别，比较时忽略空白、大小写与全半角括号）
+type HeaderMapper struct {
+	columns map[string]string // 规范化表头到模板列名
+}
+
+// NewHeaderMapper 按方案生成表头映射（mapping为nil时只识别模板列名）
+func NewHeaderMapper(mapping *model.ColumnMapping) *HeaderMapper {
+	m := &HeaderMapper{columns: make(map[string]string)}
+	for _, col := range model.TemplateColumnNames {
+		m.columns[headerKey(col)] = col
+	}
+	if mapping != nil {
+		for col, aliases := range mapping.Aliases {
+			for _, alias := range aliases {
+				m.columns[headerKey(alias)] = col
+			}
+		}
+	}
+	return m
+}
+
+// Canonical 返回映射为模板列名的表头（无法识别的单元格保持原样）
+func (m *HeaderMapper) Canonical(header []string) []string {
+	out := make([]string, len(header))
+	for i, cell := range header {
+		if col, ok := m.columns[headerKey(cell)]; ok {
+			out[i] = col
+		} else {
+			out[i] = cell
+		}
+	}
+	return out
+}
+
+// Missing 返回表头映射后仍缺少的标准列
+func (m *HeaderMapper) Missing(header []string) []string {
+	canonical := m.Canonical(header)
+	var missing []string
+	for _, col := range model.StdColumns {
+		if !slices.Contains(canonical, col) {
+			missing = append(missing, col)
+		}
+	}
+	return missing
+}
+
+// resolveColumnMapping 确定表头使用的方案：指定了方案时只按该方案识别；
+// 否则标准表头不使用方案，再按顺序尝试候选方案，返回首个能识别全部标准列的方案。无法识别时返回缺少的标准列
+func resolveColumnMapping(
+	header []string,
+	selected *model.ColumnMapping,
+	candidates []model.ColumnMapping,
+) (*model.ColumnMapping, []string) {
+	if selected != nil {
+		return selected, NewHeaderMapper(selected).Missing(header)
+	}
+	missing := NewHeaderMapper(nil).Missing(header)
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	for i := range candidates {
+		if len(NewHeaderMapper(&candidates[i]).Missing(header)) == 0 {
+			return &candidates[i], nil
+		}
+	}
+	return nil, missing
+}
+
+// headerKey 表头比较用的规范化写法（去除空白，括号统一为全角、斜杠统一为半角，字母转小写）
+func headerKey(cell string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return -1
+		case r == '(':
+			return '（'
+		case r == ')':
+			return '）'
+		case r == '／':
+			return '/'
+		default:
+			return unicode.ToLower(r)
+		}
+	}, cell)
+}
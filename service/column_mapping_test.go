@@ -0,0 +1,77 @@
+package service
+
+import (
+	"customs/model"
+	"slices"
+	"testing"
+)
+
+func TestHeaderKey(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{"数据表名称（英文）", "数据表名称（英文）"},
+		{"数据表名称(英文)", "数据表名称（英文）"},
+		{" 数据表 名称\t(英文) ", "数据表名称（英文）"},
+		{"字段／数据项名称（英文）", "字段/数据项名称（英文）"},
+		{"Table_Name", "table_name"},
+	}
+	for _, tt := range tests {
+		if got := headerKey(tt.cell); got != tt.want {
+			t.Errorf("headerKey(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
+
+func TestHeaderMapperCanonical(t *testing.T) {
+	mapper := NewHeaderMapper(&model.ColumnMapping{Aliases: map[string][]string{
+		model.ColumnTableNameEN: {"表名"},
+	}})
+	got := mapper.Canonical([]string{"表名", "数据表名称(中文)", "备注"})
+	want := []string{model.ColumnTableNameEN, model.ColumnTableNameCN, "备注"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Canonical() = %v, want %v", got, want)
+	}
+}
+
+func TestResolveColumnMapping(t *testing.T) {
+	agency := model.ColumnMapping{ID: "agency", Aliases: map[string][]string{
+		model.ColumnTableNameEN: {"表名"},
+		model.ColumnTableNameCN: {"表中文名"},
+		model.ColumnFieldNameEN: {"字段名"},
+		model.ColumnFieldNameCN: {"字段中文名"},
+		model.ColumnFieldDesc:   {"说明"},
+	}}
+	partial := model.ColumnMapping{ID: "partial", Aliases: map[string][]string{
+		model.ColumnTableNameEN: {"表名"},
+	}}
+	standard := slices.Clone(model.StdColumns)
+	agencyHeader := []string{"表名", "表中文名", "字段名", "字段中文名", "说明"}
+
+	t.Run("标准表头不使用方案", func(t *testing.T) {
+		mapping, missing := resolveColumnMapping(standard, nil, []model.ColumnMapping{agency})
+		if mapping != nil || missing != nil {
+			t.Errorf("got %v, %v", mapping, missing)
+		}
+	})
+	t.Run("自动识别首个能识别全部标准列的方案", func(t *testing.T) {
+		mapping, missing := resolveColumnMapping(agencyHeader, nil, []model.ColumnMapping{partial, agency})
+		if mapping == nil || mapping.ID != "agency" || missing != nil {
+			t.Errorf("got %v, %v", mapping, missing)
+		}
+	})
+	t.Run("无法识别时返回缺少的标准列", func(t *testing.T) {
+		mapping, missing := resolveColumnMapping(agencyHeader, nil, []model.ColumnMapping{partial})
+		if mapping != nil || !slices.Equal(missing, model.StdColumns) {
+			t.Errorf("got %v, %v", mapping, missing)
+		}
+	})
+	t.Run("指定方案时只按该方案识别", func(t *testing.T) {
+		mapping, missing := resolveColumnMapping(agencyHeader, &partial, []model.ColumnMapping{agency})
+		want := model.StdColumns[1:]
+		if mapping == nil || mapping.ID != "partial" || !slices.Equal(missing, want) {
+			t.Errorf("got %v, %v, want missing %v", mapping, missing, want)
+		}
+	})
+}
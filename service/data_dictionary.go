@@ -4,70 +4,99 @@ import (
 	"bytes"
 	"context"
 	"customs/common"
+	"customs/common/auth"
 	"customs/common/errno"
+	"customs/common/logger"
+	"customs/config"
 	"customs/infrastructure/minio"
 	"customs/infrastructure/redis"
+	"customs/infrastructure/tracing"
 	"customs/model"
 	"customs/repository"
 	"customs/task"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"github.com/xuri/excelize/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
 	"io"
-	"log"
+	"log/slog"
 	"mime/multipart"
 	"path/filepath"
-	"strings"
 )
 
 // DataDictionaryService 数据字典核心业务服务
 type DataDictionaryService struct {
-	minioClient   *minio.Client                    // MinIO工具（上传/下载Excel）
-	redisClient   *redis.Client                    // Redis工具（缓存解析结果）
-	taskClient    *task.Client                     // 异步任务生产者
-	taskInspector *task.Inspector                  // 任务状态查询器
-	dictRepo      *repository.DictionaryRepository // 任务记录CRUD
-	dbResRepo     *repository.DBResourceRepository // 资源备注CRUD
+	cfg           *config.Config                        // 全局配置（桶名等）
+	logger        *slog.Logger                          // 结构化日志
+	minioClient   *minio.Client                         // MinIO工具（上传/下载Excel）
+	redisClient   *redis.Client                         // Redis工具（缓存解析结果）
+	taskClient    *task.Client                          // 异步任务生产者
+	taskInspector *task.Inspector                       // 任务状态查询器
+	dictRepo      *repository.DictionaryRepository      // 任务记录CRUD
+	batchRepo     *repository.DictionaryBatchRepository // 批次记录CRUD
+	uploadRepo    *repository.UploadSessionRepository   // 直传会话CRUD
+	dbResRepo     *repository.DBResourceRepository      // 资源备注CRUD
+	mappingRepo   *repository.ColumnMappingRepository   // 列映射方案（上传时识别表头）
+	auditSvc      *AuditService                         // 审计日志
 }
 
 // NewDataDictionaryService 初始化核心服务（依赖注入）
 func NewDataDictionaryService(
+	cfg *config.Config,
+	logger *slog.Logger,
 	minioClient *minio.Client,
 	redisClient *redis.Client,
 	taskClient *task.Client,
 	taskInspector *task.Inspector,
 	dictRepo *repository.DictionaryRepository,
+	batchRepo *repository.DictionaryBatchRepository,
+	uploadRepo *repository.UploadSessionRepository,
 	dbResRepo *repository.DBResourceRepository,
+	mappingRepo *repository.ColumnMappingRepository,
+	auditSvc *AuditService,
 ) *DataDictionaryService {
 	return &DataDictionaryService{
+		cfg:           cfg,
+		logger:        logger,
 		minioClient:   minioClient,
 		redisClient:   redisClient,
 		taskClient:    taskClient,
 		taskInspector: taskInspector,
 		dictRepo:      dictRepo,
+		batchRepo:     batchRepo,
+		uploadRepo:    uploadRepo,
 		dbResRepo:     dbResRepo,
+		mappingRepo:   mappingRepo,
+		auditSvc:      auditSvc,
 	}
 }
 
-// DownloadTemplate 从MinIO获取Excel模板文件
-func (s *DataDictionaryService) DownloadTemplate(ctx context.Context) (io.Reader, string, error) {
-	templateName := "system-db.xls" // 模板文件名，与Python原逻辑保持一致
+// DownloadTemplate 按标准列定义生成Excel模板（与上传校验共用model.TemplateColumns，避免模板与校验不一致）
+func (s *DataDictionaryService) DownloadTemplate(ctx context.Context) (_ io.Reader, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.DownloadTemplate")
+	defer func() { tracing.End(span, err) }()
 
-	// 从MinIO下载模板文件
-	fileBytes, err := s.minioClient.DownloadFile("sjdt-update-dictionary-config-excel", templateName)
+	content, err := buildTemplateWorkbook()
 	if err != nil {
-		return nil, "", fmt.Errorf("minio下载失败：%w", err)
+		s.logger.ErrorContext(ctx, "生成Excel模板失败", slog.Any("error", err))
+		return nil, "", errno.ErrInternalServer.WithCause(err)
 	}
+	s.auditSvc.Record(ctx, model.AuditActionTemplateDownload, "", "", map[string]string{"template": templateFileName})
 
-	return fileBytes, templateName, nil
+	return bytes.NewReader(content), templateFileName, nil
 }
 
 // UploadExcel 上传Excel
 func (s *DataDictionaryService) UploadExcel(
 	ctx context.Context,
 	resourceComment string, // 资源备注
+	columnMapping string, // 列映射方案名称（为空时按表头自动识别）
 	file *multipart.FileHeader, // 上传的Excel文件
-) (*model.DictionaryTask, error) {
+) (_ *model.DictionaryTask, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.UploadExcel")
+	defer func() { tracing.End(span, err) }()
+
 	// 步骤1：参数校验
 	if resourceComment == "" || file == nil {
 		return nil, errno.ErrInvalidParam // 自定义错误码：参数无效
@@ -75,86 +104,151 @@ func (s *DataDictionaryService) UploadExcel(
 	if !isExcelFile(file.Filename) { // 简单判断文件类型（可抽入common/utils）
 		return nil, errno.ErrInvalidFileFormat // 自定义错误码：文件格式错误
 	}
+	span.SetAttributes(attribute.String("excel.name", file.Filename), attribute.Int64("excel.size", file.Size))
+	if maxBytes := s.cfg.Upload.MaxFileBytes; maxBytes > 0 && file.Size > maxBytes {
+		return nil, errno.ErrFileTooLarge.WithDetails(map[string]int64{"size": file.Size, "max_bytes": maxBytes})
+	}
 
-	// 步骤2：打开文件并上传到MinIO
+	// 步骤2：打开文件并读取内容
 	src, err := file.Open()
 	if err != nil {
-		return nil, errno.ErrFileOpenFailed.WithMessage(err.Error())
+		return nil, errno.ErrFileOpenFailed.WithCause(err)
 	}
 	defer func() {
 		if err := src.Close(); err != nil {
 			// 记录关闭文件失败的日志，不阻断主流程
-			log.Printf("关闭文件失败: %v, 文件名: %s", err, file.Filename)
+			s.logger.WarnContext(ctx, "关闭文件失败", slog.String("file", file.Filename), slog.Any("error", err))
 		}
 	}()
 
 	// 读取文件内容用于格式验证（提前发现问题，避免无效上传）
 	content, err := io.ReadAll(src)
 	if err != nil {
-		return nil, errno.ErrFileOpenFailed.WithMessage("读取文件内容失败: " + err.Error())
+		return nil, errno.ErrFileOpenFailed.WithMessage("读取文件内容失败").WithCause(err)
 	}
 
 	// 步骤3：预验证Excel格式（文件名+内容结构）
-	if err := judgeExcelFormat(file.Filename, content); err != nil {
+	selected, candidates, err := s.loadColumnMappings(ctx, columnMapping)
+	if err != nil {
 		return nil, err
 	}
-
-	// 重置文件指针，确保后续上传完整
-	if _, err := src.Seek(0, io.SeekStart); err != nil {
-		return nil, errno.ErrFileOpenFailed.WithMessage("重置文件指针失败: " + err.Error())
+	mapping, err := judgeExcelFormat(file.Filename, content, selected, candidates)
+	if err != nil {
+		s.logger.InfoContext(ctx, "Excel格式校验未通过", slog.String("file", file.Filename), slog.Any("error", err))
+		s.auditSvc.Record(ctx, model.AuditActionValidationFailed, "", "", map[string]interface{}{
+			"excel_name":       file.Filename,
+			"resource_comment": resourceComment,
+			"error":            err.Error(),
+		})
+		return nil, err
 	}
-	// 上传到MinIO的excel-bucket，文件名用原文件名
-	err = s.minioClient.UploadFile("sjdt-update-dictionary-config-excel", file.Filename, src, file.Size)
+
+	// 步骤4：上传到MinIO并生产解析任务
+	return s.createTask(ctx, resourceComment, "", mapping, file.Filename, content)
+}
+
+// createTask 上传已校验的Excel到MinIO，创建任务记录并生产解析任务（batchID为空表示单文件上传，mapping为nil表示标准表头）
+func (s *DataDictionaryService) createTask(
+	ctx context.Context,
+	resourceComment, batchID string,
+	mapping *model.ColumnMapping,
+	excelName string,
+	content []byte,
+) (*model.DictionaryTask, error) {
+	dictTask := newDictTask(ctx, resourceComment, batchID, mapping, excelName)
+	// 上传到MinIO的excel-bucket，对象名按任务ID分目录（同名文件互不覆盖）
+	objectName := dictTask.ExcelObjectName()
+	err := s.minioClient.UploadFile(ctx, s.cfg.Minio.ExcelBucket, objectName, bytes.NewReader(content), int64(len(content)))
 	if err != nil {
-		return nil, errno.ErrMinioUploadFailed // 自定义错误码：MinIO上传失败
+		s.logger.ErrorContext(ctx, "上传Excel到MinIO失败", slog.String("object", objectName), slog.Any("error", err))
+		return nil, errno.ErrMinioUploadFailed.WithCause(err) // 自定义错误码：MinIO上传失败
+	}
+	return s.enqueueTask(ctx, dictTask, int64(len(content)))
+}
+
+// newDictTask 初始化任务记录（上传Excel前确定任务ID，作为MinIO对象名前缀）
+// 校验时识别的列映射方案别名随任务保存，解析时不受方案后续修改或删除的影响
+func newDictTask(
+	ctx context.Context,
+	resourceComment, batchID string,
+	mapping *model.ColumnMapping,
+	excelName string,
+) *model.DictionaryTask {
+	dictTask := model.NewDictionaryTask(excelName, "", resourceComment, auth.Actor(ctx)) // 先初始化任务记录（无taskID）
+	dictTask.BatchID = batchID
+	if mapping != nil {
+		dictTask.ColumnMappingID = mapping.ID
+		dictTask.ColumnAliases = mapping.Aliases
 	}
+	return dictTask
+}
 
-	// 步骤4：生产Asynq解析任务
-	dictTask := model.NewDictionaryTask(file.Filename, "") // 先初始化任务记录（无taskID）
+// enqueueTask 为已存入excel-bucket的Excel创建任务记录并生产解析任务
+func (s *DataDictionaryService) enqueueTask(ctx context.Context, dictTask *model.DictionaryTask, size int64) (*model.DictionaryTask, error) {
 	// 先创建数据库任务记录
 	if err := s.dictRepo.Create(ctx, dictTask); err != nil {
-		return nil, errno.ErrDBInsertFailed // 自定义错误码：数据库插入失败
+		s.logger.ErrorContext(ctx, "创建任务记录失败", slog.Any("error", err))
+		return nil, errno.ErrDBInsertFailed.WithCause(err) // 自定义错误码：数据库插入失败
 	}
-	// 生产解析任务（获取Asynq的taskID）
-	taskInfo, err := s.taskClient.CreateDFTask(ctx, resourceComment, file.Filename, dictTask.ID)
+	ctx = logger.WithDictTaskID(ctx, dictTask.ID)
+	// 生产Asynq解析任务（获取Asynq的taskID）
+	taskInfo, err := s.taskClient.CreateDFTask(ctx, dictTask.ResourceComment, dictTask.ExcelName, dictTask.ExcelObjectName(), dictTask.ID)
 	if err != nil {
 		// 任务生产失败，更新数据库状态
+		s.logger.ErrorContext(ctx, "生产解析任务失败", slog.Any("error", err))
 		dictTask.UpdateCreateDFStatus(model.TaskStatusFailed, "生产解析任务失败："+err.Error())
-		err := s.dictRepo.Update(ctx, dictTask)
-		if err != nil {
-			return nil, err
+		if err := s.dictRepo.Update(ctx, dictTask); err != nil {
+			s.logger.ErrorContext(ctx, "更新任务失败状态失败", slog.Any("error", err))
 		}
-		return nil, errno.ErrTaskCreateFailed // 自定义错误码：任务创建失败
+		return nil, errno.ErrTaskCreateFailed.WithCause(err) // 自定义错误码：任务创建失败
 	}
 
-	// 步骤5：更新任务记录的create_df_task_id
+	// 更新任务记录的create_df_task_id
 	dictTask.CreateDFTaskID = taskInfo.ID
 	dictTask.UpdateCreateDFStatus(model.TaskStatusPending) // 状态改为待执行
 	if err := s.dictRepo.Update(ctx, dictTask); err != nil {
-		return nil, errno.ErrDBUpdateFailed // 自定义错误码：数据库更新失败
+		s.logger.ErrorContext(ctx, "更新解析任务ID失败", slog.Any("error", err))
+		return nil, errno.ErrDBUpdateFailed.WithCause(err) // 自定义错误码：数据库更新失败
 	}
+	s.logger.InfoContext(ctx, "Excel上传成功，解析任务已入队",
+		slog.String("file", dictTask.ExcelName), slog.String("create_df_task_id", taskInfo.ID))
+	s.auditSvc.Record(ctx, model.AuditActionUpload, dictTask.ID, "", map[string]interface{}{
+		"excel_name":        dictTask.ExcelName,
+		"size":              size,
+		"resource_comment":  dictTask.ResourceComment,
+		"batch_id":          dictTask.BatchID,
+		"column_mapping_id": dictTask.ColumnMappingID,
+		"create_df_task_id": taskInfo.ID,
+	})
 
 	return dictTask, nil
 }
 
 // GetParseResult 查询解析结果
-func (s *DataDictionaryService) GetParseResult(ctx context.Context, taskID string, page, size int) (interface{}, error) {
+func (s *DataDictionaryService) GetParseResult(ctx context.Context, taskID string, page, size int) (_ interface{}, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.GetParseResult", attribute.String(logger.KeyDictTask, taskID))
+	defer func() { tracing.End(span, err) }()
+
 	// 步骤1：查询任务记录
-	dictTask, err := s.dictRepo.GetByID(ctx, taskID)
+	dictTask, err := s.getDictTask(ctx, taskID)
 	if err != nil {
-		return nil, fmt.Errorf("任务不存在：%w", err)
+		return nil, err
 	}
 
 	// 步骤2：只检查「解析状态是否成功」
 	if dictTask.CreateDFTaskStatus != model.TaskStatusSucceeded {
-		return nil, fmt.Errorf("任务解析未完成，当前状态：%s", dictTask.CreateDFTaskStatus)
+		return nil, errno.ErrPreTaskNotCompleted.WithMessage("任务解析未完成").
+			WithDetails(map[string]string{"create_df_task_status": dictTask.CreateDFTaskStatus})
 	}
 
 	// 步骤3：读取Redis缓存
 	redisKey := "dict_task_" + taskID
-	result, err := s.redisClient.Get(redisKey)
+	result, err := s.redisClient.Get(ctx, redisKey)
+	if errors.Is(err, redis.ErrNil) {
+		return nil, errno.ErrParseResultNotFound.WithCause(err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("缓存中无解析结果：%w", err)
+		return nil, errno.ErrRedisGetFailed.WithCause(err)
 	}
 
 	return map[string]interface{}{
@@ -169,25 +263,137 @@ func (s *DataDictionaryService) ConfirmInsert(
 	ctx context.Context,
 	dictTaskID string,
 	confirm bool,
-) error {
+	rejectReason string,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.ConfirmInsert",
+		attribute.String(logger.KeyDictTask, dictTaskID),
+		attribute.Bool("confirm", confirm),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// 步骤1：查询数据库任务记录
-	dictTask, err := s.dictRepo.GetByID(ctx, dictTaskID)
+	ctx = logger.WithDictTaskID(ctx, dictTaskID)
+	dictTask, err := s.getDictTask(ctx, dictTaskID)
 	if err != nil {
-		return errno.ErrDBQueryFailed
+		return err
 	}
 
 	// 步骤2：校验前置条件：解析任务必须成功
 	if dictTask.CreateDFTaskStatus != model.TaskStatusSucceeded {
-		return errno.ErrPreTaskNotCompleted // 自定义错误码：前置任务未完成
+		return errno.ErrPreTaskNotCompleted.WithDetails( // 自定义错误码：前置任务未完成
+			map[string]string{"create_df_task_status": dictTask.CreateDFTaskStatus})
+	}
+
+	// 步骤3：四眼原则：审批人必须已认证且不能是上传人，已审批的任务不能重复审批
+	approver := auth.Actor(ctx)
+	if approver == "" {
+		return errno.ErrApproverRequired
+	}
+	if approver == dictTask.Uploader {
+		s.logger.WarnContext(ctx, "拒绝上传人自行审批", slog.String("approver", approver))
+		return errno.ErrSelfApproval
 	}
+	if dictTask.Decided() {
+		return errno.ErrTaskAlreadyDecided
+	}
+	if dictTask.BatchID != "" {
+		return errno.ErrTaskInBatch.WithDetails(map[string]string{"batch_id": dictTask.BatchID})
+	}
+
+	// 步骤4：按配置，存在error级命名规范违规时只能驳回
+	if confirm {
+		if err := s.checkNaming(dictTask); err != nil {
+			return err
+		}
+	}
+
+	return s.decideTask(ctx, dictTask, approver, confirm, rejectReason)
+}
+
+// checkNaming 开启NAMING_BLOCK_ON_ERROR时，存在error级命名规范违规的任务不能确认入库
+func (s *DataDictionaryService) checkNaming(dictTask *model.DictionaryTask) error {
+	if !s.cfg.Naming.BlockOnError || dictTask.NamingErrors == 0 {
+		return nil
+	}
+	return errno.ErrNamingViolation.WithDetails(map[string]interface{}{
+		"excel_name":      dictTask.ExcelName,
+		"naming_errors":   dictTask.NamingErrors,
+		"naming_warnings": dictTask.NamingWarnings,
+	})
+}
+
+// GetNamingReport 查询解析时的命名规范检查结果
+func (s *DataDictionaryService) GetNamingReport(ctx context.Context, dictTaskID string) (_ *NamingReport, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.GetNamingReport", attribute.String(logger.KeyDictTask, dictTaskID))
+	defer func() { tracing.End(span, err) }()
+
+	dictTask, err := s.getDictTask(ctx, dictTaskID)
+	if err != nil {
+		return nil, err
+	}
+	if dictTask.CreateDFTaskStatus != model.TaskStatusSucceeded {
+		return nil, errno.ErrPreTaskNotCompleted.WithMessage("任务解析未完成").
+			WithDetails(map[string]string{"create_df_task_status": dictTask.CreateDFTaskStatus})
+	}
+	report := &NamingReport{
+		Errors:     dictTask.NamingErrors,
+		Warnings:   dictTask.NamingWarnings,
+		Blocking:   s.cfg.Naming.BlockOnError && dictTask.NamingErrors > 0,
+		Violations: []NamingViolation{},
+	}
+	if dictTask.NamingReportName == "" { // 启用命名规范检查之前解析的任务
+		return report, nil
+	}
+	content, err := s.minioClient.DownloadFile(ctx, s.cfg.Minio.CSVBucket, dictTask.NamingReportName)
+	if err != nil {
+		return nil, errno.ErrMinioDownloadFailed.WithCause(err)
+	}
+	if err := json.NewDecoder(content).Decode(&report.Violations); err != nil {
+		return nil, errno.ErrInternalServer.WithCause(err)
+	}
+	return report, nil
+}
+
+// decideTask 记录审批结论：驳回仅更新任务记录，确认则生产入库任务并监控其状态
+// 审批结论以条件更新写入（仅未审批时生效），并发审批时只有一个请求成功，其余返回ErrTaskAlreadyDecided
+// 调用方需已完成审批人与任务状态校验
+func (s *DataDictionaryService) decideTask(
+	ctx context.Context,
+	dictTask *model.DictionaryTask,
+	approver string,
+	confirm bool,
+	rejectReason string,
+) error {
+	ctx = logger.WithDictTaskID(ctx, dictTask.ID)
+	dictTaskID := dictTask.ID
 
-	// 步骤3：取消入库（仅更新状态）
+	// 步骤1：写入审批结论（确认入库时入库任务ID在入队后补写）
+	if confirm {
+		dictTask.ConfirmInsert("", approver)
+	} else {
+		dictTask.Reject(approver, rejectReason)
+	}
+	decided, err := s.dictRepo.Decide(ctx, dictTask)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "更新审批结论失败", slog.Any("error", err))
+		return errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	if !decided {
+		s.logger.WarnContext(ctx, "任务已被并发审批", slog.String("approver", approver))
+		return errno.ErrTaskAlreadyDecided
+	}
+
+	// 驳回（仅记录审批结论）
 	if !confirm {
-		dictTask.Confirm = false
-		return s.dictRepo.Update(ctx, dictTask)
+		s.logger.InfoContext(ctx, "已驳回入库", slog.String("approver", approver))
+		s.auditSvc.Record(ctx, model.AuditActionReject, dictTaskID, "", map[string]string{
+			"uploader": dictTask.Uploader,
+			"reason":   rejectReason,
+		})
+		return nil
 	}
 
-	// 步骤4：确认入库：生产Asynq入库任务
+	// 步骤2：确认入库：生产Asynq入库任务（失败时撤销审批结论，以便重新审批）
 	taskInfo, err := s.taskClient.InsertDFTask(
 		ctx,
 		dictTask.DBResourceCSVName,
@@ -195,31 +401,134 @@ func (s *DataDictionaryService) ConfirmInsert(
 		dictTask.ID,
 	)
 	if err != nil {
-		return errno.ErrTaskCreateFailed
+		s.logger.ErrorContext(ctx, "生产入库任务失败", slog.Any("error", err))
+		if undoErr := s.dictRepo.UndoDecision(ctx, dictTask); undoErr != nil {
+			s.logger.ErrorContext(ctx, "撤销审批结论失败", slog.Any("error", undoErr))
+		}
+		return errno.ErrTaskCreateFailed.WithCause(err)
 	}
 
-	// 步骤5：更新任务记录（标记确认+入库任务ID+状态）
-	dictTask.ConfirmInsert(taskInfo.ID) // 调用Model的封装方法
-	if err := s.dictRepo.Update(ctx, dictTask); err != nil {
-		return errno.ErrDBUpdateFailed
+	// 步骤3：补写入库任务ID
+	dictTask.ConfirmInsert(taskInfo.ID, approver) // 调用Model的封装方法
+	if err := s.dictRepo.SetInsertDFTask(ctx, dictTask); err != nil {
+		s.logger.ErrorContext(ctx, "更新入库任务ID失败", slog.Any("error", err))
+		return errno.ErrDBUpdateFailed.WithCause(err)
 	}
+	s.logger.InfoContext(ctx, "已确认入库，入库任务已入队", slog.String("insert_df_task_id", taskInfo.ID))
+	s.auditSvc.Record(ctx, model.AuditActionConfirm, dictTaskID, "", map[string]string{
+		"uploader":          dictTask.Uploader,
+		"insert_df_task_id": taskInfo.ID,
+	})
+
+	// 启动goroutine监控入库任务状态（对应back_task.py）
+	// 请求结束后上下文会被取消，这里保留上下文中的请求ID等值但脱离其生命周期
+	go s.MonitorInsertTask(context.WithoutCancel(ctx), dictTaskID)
+
+	return nil
+}
+
+// ListTasks 分页查询任务列表
+func (s *DataDictionaryService) ListTasks(
+	ctx context.Context,
+	filter repository.DictionaryTaskFilter,
+	page, size int,
+) (_ []model.DictionaryTask, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.ListTasks")
+	defer func() { tracing.End(span, err) }()
 
-	// 步骤6：启动goroutine监控入库任务状态（对应back_task.py）
-	go s.MonitorInsertTask(ctx, dictTaskID)
+	tasks, total, err := s.dictRepo.List(ctx, filter, page, size)
+	if err != nil {
+		return nil, 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return tasks, total, nil
+}
+
+// GetTask 查询任务详情
+func (s *DataDictionaryService) GetTask(ctx context.Context, dictTaskID string) (_ *model.DictionaryTask, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.GetTask", attribute.String(logger.KeyDictTask, dictTaskID))
+	defer func() { tracing.End(span, err) }()
+
+	return s.getDictTask(ctx, dictTaskID)
+}
+
+// DeleteTask 删除任务（仅上传人或管理员；已确认入库的任务不能删除），同时清理解析结果缓存
+func (s *DataDictionaryService) DeleteTask(ctx context.Context, dictTaskID string) (err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.DeleteTask", attribute.String(logger.KeyDictTask, dictTaskID))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logger.WithDictTaskID(ctx, dictTaskID)
+	dictTask, err := s.getDictTask(ctx, dictTaskID)
+	if err != nil {
+		return err
+	}
+
+	// 仅上传人或管理员可删除
+	if err := s.checkOwner(ctx, dictTask.Uploader); err != nil {
+		return err
+	}
+	if dictTask.Confirm {
+		return errno.ErrTaskNotDeletable
+	}
+	// 批次子任务缺失后批次无法汇总审批，只能随批次整体处理
+	if dictTask.BatchID != "" {
+		return errno.ErrTaskInBatchDelete.WithDetails(map[string]string{"batch_id": dictTask.BatchID})
+	}
 
+	if err := s.dictRepo.Delete(ctx, dictTask); err != nil {
+		return errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	if err := s.redisClient.Delete(ctx, "dict_task_"+dictTaskID); err != nil {
+		// 缓存12小时后自动过期，清理失败不影响删除结果
+		s.logger.WarnContext(ctx, "清理解析结果缓存失败", slog.Any("error", err))
+	}
+	s.logger.InfoContext(ctx, "已删除任务")
+	s.auditSvc.Record(ctx, model.AuditActionDelete, dictTaskID, "", map[string]string{
+		"excel_name": dictTask.ExcelName,
+		"uploader":   dictTask.Uploader,
+	})
 	return nil
 }
 
 // GetResourceComments 查询资源备注
-func (s *DataDictionaryService) GetResourceComments(ctx context.Context) ([]string, error) {
+func (s *DataDictionaryService) GetResourceComments(ctx context.Context) (_ []string, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.GetResourceComments")
+	defer func() { tracing.End(span, err) }()
+
 	// 调用Repository查询去重的资源备注
 	comments, err := s.dbResRepo.GetDistinctResourceComment(ctx)
 	if err != nil {
-		return nil, errno.ErrDBQueryFailed
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
 	}
 	return comments, nil
 }
 
+// checkOwner 校验操作人为上传人或管理员（启用认证时必须已认证；未启用认证时不限制）
+func (s *DataDictionaryService) checkOwner(ctx context.Context, uploader string) error {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		if len(s.cfg.Auth.Providers) > 0 {
+			return errno.ErrTaskPermission
+		}
+		return nil
+	}
+	if identity.Username != uploader && !identity.HasAnyRole(model.RoleAdmin) {
+		return errno.ErrTaskPermission
+	}
+	return nil
+}
+
+// getDictTask 查询任务记录（区分不存在与查询失败）
+func (s *DataDictionaryService) getDictTask(ctx context.Context, dictTaskID string) (*model.DictionaryTask, error) {
+	dictTask, err := s.dictRepo.GetByID(ctx, dictTaskID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrTaskNotFound.WithDetails(map[string]string{"task_id": dictTaskID})
+	}
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return dictTask, nil
+}
+
 // isExcelFile 判断是否为Excel文件
 func isExcelFile(filename string) bool {
 	ext := filepath.Ext(filename)
@@ -261,59 +570,72 @@ func paginateResult(jsonStr string, page, size int) (interface{}, error) {
 	}, nil
 }
 
-// judgeExcelFormat 验证Excel文件名格式和列名是否符合规范
+// judgeExcelFormat 验证Excel文件名格式和列名是否符合规范，返回表头使用的列映射方案（标准表头为nil）
 // 文件名要求："系统名-dbname"（用短横线分割为两部分）
-// 列名要求：必须包含指定的5个标准列
-func judgeExcelFormat(fileName string, fileContent []byte) error {
+// 列名要求：必须包含指定的5个标准列（或经列映射方案识别为标准列，见resolveColumnMapping）
+func judgeExcelFormat(
+	fileName string,
+	fileContent []byte,
+	selected *model.ColumnMapping,
+	candidates []model.ColumnMapping,
+) (*model.ColumnMapping, error) {
 	// 1. 验证文件名格式（系统名-dbname）
-	parts := strings.Split(fileName, "-")
-	if len(parts) != 2 {
-		return errno.ErrInvalidFileNameFormat // 自定义错误：文件名格式错误
+	if _, _, ok := common.SplitExcelName(fileName); !ok {
+		return nil, errno.ErrInvalidFileNameFormat // 自定义错误：文件名格式错误
 	}
 
 	// 2. 验证Excel列名是否包含所有标准列
 	// 打开Excel文件（从字节流读取）
 	f, err := excelize.OpenReader(bytes.NewReader(fileContent))
 	if err != nil {
-		return errno.ErrExcelOpenFailed // 自定义错误：打开Excel失败
+		return nil, errno.ErrExcelOpenFailed.WithCause(err) // 自定义错误：打开Excel失败
 	}
 	defer f.Close()
 
 	// 获取第一个sheet的列名（默认读取第一个sheet）
 	sheetList := f.GetSheetList()
 	if len(sheetList) == 0 {
-		return errno.ErrExcelNoSheet // 自定义错误：Excel无工作表
+		return nil, errno.ErrExcelNoSheet // 自定义错误：Excel无工作表
 	}
 	rows, err := f.GetRows(sheetList[0])
 	if err != nil {
-		return errno.ErrExcelReadFailed // 自定义错误：读取Excel失败
+		return nil, errno.ErrExcelReadFailed.WithCause(err) // 自定义错误：读取Excel失败
 	}
 	if len(rows) == 0 {
-		return errno.ErrExcelEmpty // 自定义错误：Excel内容为空
+		return nil, errno.ErrExcelEmpty // 自定义错误：Excel内容为空
 	}
 
-	// 提取第一行作为列名
-	columns := rows[0]
-	columnSet := make(map[string]struct{}, len(columns))
-	for _, col := range columns {
-		columnSet[col] = struct{}{}
+	// 检查第一行是否包含所有标准列（收集全部缺失列，便于一次性修正）
+	mapping, missing := resolveColumnMapping(rows[0], selected, candidates)
+	if len(missing) > 0 {
+		details := map[string]interface{}{ // 自定义错误：缺少必要列
+			"sheet":           sheetList[0],
+			"missing_columns": missing,
+		}
+		if selected != nil {
+			details["column_mapping"] = selected.Name
+		}
+		return nil, errno.ErrExcelColumnMissing.WithDetails(details)
 	}
 
-	// 标准列名集合（与Python版本保持一致）
-	stdColumns := map[string]struct{}{
-		"数据表名称（英文）":    {},
-		"数据表名称（中文）":    {},
-		"字段/数据项名称（英文）": {},
-		"字段/数据项名称（中文）": {},
-		"字段/数据项说明":     {},
-	}
+	return mapping, nil
+}
 
-	// 检查是否包含所有标准列
-	for col := range stdColumns {
-		if _, exists := columnSet[col]; !exists {
-			return errno.ErrExcelColumnMissing // 自定义错误：缺少必要列
+// loadColumnMappings 加载上传时的列映射方案：指定名称时只返回该方案，否则返回全部方案供自动识别
+func (s *DataDictionaryService) loadColumnMappings(
+	ctx context.Context,
+	name string,
+) (*model.ColumnMapping, []model.ColumnMapping, error) {
+	if name != "" {
+		selected, err := findColumnMapping(s.mappingRepo.GetByName(ctx, name))
+		if err != nil {
+			return nil, nil, err
 		}
+		return selected, nil, nil
 	}
-
-	return nil
+	candidates, err := s.mappingRepo.List(ctx)
+	if err != nil {
+		return nil, nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return nil, candidates, nil
 }
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"customs/common/auth"
+	"customs/common/errno"
+	"customs/config"
+	"customs/infrastructure/introspect"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"errors"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+)
+
+// DBResourceInput 登记数据库资源或修改连接的参数
+type DBResourceInput struct {
+	ResourceComment string // 资源备注
+	SystemName      string // 系统名
+	DBName          string // 数据库名
+	ResourceType    string // 资源类型（如MySQL/SQLite）
+	ConnectionDSN   string // 内省连接串（为空表示不配置）
+}
+
+// CreateDBResource 登记数据库资源（用于尚无工作簿、需从数据库表结构生成字典的系统）
+func (s *DataDictionaryService) CreateDBResource(ctx context.Context, in DBResourceInput) (_ *model.DBResource, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.CreateDBResource")
+	defer func() { tracing.End(span, err) }()
+
+	if strings.Contains(in.SystemName, "-") || strings.Contains(in.DBName, "-") {
+		return nil, errno.ErrInvalidParam.WithMessage("系统名与数据库名不能包含短横线（需能组成\"系统名-dbname\"文件名）")
+	}
+	if err := validateConnection(s.cfg.Introspect, in.ResourceType, in.ConnectionDSN); err != nil {
+		return nil, err
+	}
+	_, err = s.dbResRepo.GetByCommentAndDBName(ctx, in.ResourceComment, in.DBName)
+	if err == nil {
+		return nil, errno.ErrDBResourceExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+
+	resource := model.NewDBResource(in.ResourceComment, in.ResourceType, in.DBName, "", auth.Actor(ctx))
+	resource.SystemName = in.SystemName
+	resource.ConnectionDSN = in.ConnectionDSN
+	if err := s.dbResRepo.Create(ctx, resource); err != nil {
+		return nil, errno.ErrDBInsertFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionResourceRegister, "", resource.ID, map[string]interface{}{
+		"resource_comment": in.ResourceComment,
+		"system_name":      in.SystemName,
+		"db_name":          in.DBName,
+		"resource_type":    in.ResourceType,
+	})
+	return resource, nil
+}
+
+// UpdateDBResourceConnection 修改数据库资源的类型与内省连接串（dsn为空表示清除连接）
+func (s *DataDictionaryService) UpdateDBResourceConnection(
+	ctx context.Context,
+	dbResourceID, resourceType, dsn string,
+) (_ *model.DBResource, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.UpdateDBResourceConnection")
+	defer func() { tracing.End(span, err) }()
+
+	if err := validateConnection(s.cfg.Introspect, resourceType, dsn); err != nil {
+		return nil, err
+	}
+	resource, err := s.getDBResource(ctx, dbResourceID)
+	if err != nil {
+		return nil, err
+	}
+	resource.ResourceType = resourceType
+	resource.ConnectionDSN = dsn
+	if err := s.dbResRepo.Update(ctx, resource); err != nil {
+		return nil, errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	// 连接串可能含口令，审计中只记录是否配置
+	s.auditSvc.Record(ctx, model.AuditActionConnectionUpdate, "", resource.ID, map[string]interface{}{
+		"resource_type":  resourceType,
+		"has_connection": dsn != "",
+	})
+	return resource, nil
+}
+
+// IntrospectDBResource 读取数据库资源的表结构（表、字段及注释），生成模板格式的工作簿并按普通上传创建解析任务，
+// 解析结果与上传Excel一致，需经同样的审批流程确认入库；表注释作为表中文名，字段注释作为字段中文名
+func (s *DataDictionaryService) IntrospectDBResource(ctx context.Context, dbResourceID string) (_ *model.DictionaryTask, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.IntrospectDBResource")
+	defer func() { tracing.End(span, err) }()
+
+	resource, err := s.getDBResource(ctx, dbResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if resource.ConnectionDSN == "" {
+		return nil, errno.ErrConnectionMissing
+	}
+	if resource.SystemName == "" {
+		return nil, errno.ErrInvalidParam.WithMessage("数据库资源未设置系统名，无法生成\"系统名-dbname\"文件名")
+	}
+
+	columns, err := readColumns(ctx, s.logger, s.cfg.Introspect, resource)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, errno.ErrDictionaryNotFound.WithMessage("数据库中没有可读取的表")
+	}
+
+	fields := make([]model.DictionaryField, len(columns))
+	tables := make(map[string]struct{})
+	for i, c := range columns {
+		fields[i] = model.DictionaryField{
+			TableNameEN: c.Table,
+			TableNameCN: c.TableComment,
+			FieldNameEN: c.Name,
+			FieldNameCN: c.Comment,
+		}
+		tables[c.Table] = struct{}{}
+	}
+	content, err := buildDictionaryWorkbook(fields, nil)
+	if err != nil {
+		return nil, errno.ErrInternalServer.WithCause(err)
+	}
+
+	// 文件名决定入库的系统名与库名，对象名按任务ID分目录，重复内省不会覆盖之前的草稿
+	excelName := resource.SystemName + "-" + resource.DBName + ".xlsx"
+	dictTask, err := s.createTask(ctx, resource.ResourceComment, "", nil, excelName, content)
+	if err != nil {
+		return nil, err
+	}
+	s.auditSvc.Record(ctx, model.AuditActionIntrospect, dictTask.ID, resource.ID, map[string]interface{}{
+		"resource_type": resource.ResourceType,
+		"tables":        len(tables),
+		"columns":       len(columns),
+	})
+	return dictTask, nil
+}
+
+// readColumns 连接数据库读取字段（受配置的超时限制）
+func readColumns(
+	ctx context.Context,
+	logger *slog.Logger,
+	cfg config.IntrospectConfig,
+	resource *model.DBResource,
+) ([]introspect.Column, error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	inspector, err := introspect.Open(resource.ResourceType, resource.ConnectionDSN, introspect.Options{SQLiteDir: cfg.SQLiteDir})
+	if err != nil {
+		return nil, errno.ErrIntrospectFailed.WithCause(err)
+	}
+	defer inspector.Close()
+
+	columns, err := inspector.Columns(ctx, resource.DBName)
+	if err != nil {
+		logger.WarnContext(ctx, "读取数据库表结构失败",
+			slog.String("db_resource_id", resource.ID), slog.String("resource_type", resource.ResourceType), slog.Any("error", err))
+		return nil, errno.ErrIntrospectFailed.WithCause(err)
+	}
+	return columns, nil
+}
+
+// getDBResource 查询数据库资源（不存在时返回ErrDBResourceNotFound）
+func (s *DataDictionaryService) getDBResource(ctx context.Context, dbResourceID string) (*model.DBResource, error) {
+	return findDBResource(ctx, s.dbResRepo, dbResourceID)
+}
+
+// findDBResource 查询数据库资源（不存在时返回ErrDBResourceNotFound）
+func findDBResource(ctx context.Context, repo *repository.DBResourceRepository, dbResourceID string) (*model.DBResource, error) {
+	resource, err := repo.GetByID(ctx, dbResourceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrDBResourceNotFound
+		}
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return resource, nil
+}
+
+// validateConnection 配置了连接串时，资源类型须支持内省，且连接串符合安全限制（口令引用环境变量、SQLite文件位于指定目录）
+func validateConnection(cfg config.IntrospectConfig, resourceType, dsn string) error {
+	if dsn == "" {
+		return nil
+	}
+	if !introspect.Supported(resourceType) {
+		return errno.ErrInvalidParam.WithMessage("该资源类型不支持内省（支持MySQL、SQLite）：" + resourceType)
+	}
+	if err := introspect.Validate(resourceType, dsn, introspect.Options{SQLiteDir: cfg.SQLiteDir}); err != nil {
+		return errno.ErrInvalidParam.WithMessage(err.Error())
+	}
+	return nil
+}
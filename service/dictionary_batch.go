@@ -0,0 +1,449 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"customs/common/auth"
+	"customs/common/errno"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"gorm.io/gorm"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// BatchDetail 批次详情（状态由子任务汇总）
+type BatchDetail struct {
+	Batch  *model.DictionaryBatch `json:"batch"`
+	Status string                 `json:"status"`          // PARSING/PARSE_FAILED/READY/INCOMPLETE/APPROVED/REJECTED
+	Tasks  []model.DictionaryTask `json:"tasks,omitempty"` // 子任务（列表接口不返回）
+}
+
+// BatchFileError 批量上传中单个文件的校验错误
+type BatchFileError struct {
+	File    string      `json:"file"`              // 文件名（ZIP内的文件为"包名/文件名"）
+	Code    int         `json:"code"`              // 错误码
+	Msg     string      `json:"msg"`               // 错误信息
+	Details interface{} `json:"details,omitempty"` // 错误详情（如缺失的列名）
+}
+
+// batchFile 待创建任务的Excel文件
+type batchFile struct {
+	name    string // Excel文件名（MinIO对象名）
+	source  string // 来源（ZIP内的文件为"包名/文件名"）
+	content []byte
+	mapping *model.ColumnMapping // 校验时识别的列映射方案（标准表头为nil）
+}
+
+// UploadBatch 批量上传Excel（可混合多个Excel与ZIP压缩包），逐个校验后创建批次及子任务
+// 任一文件校验未通过时整批拒绝，不创建任何任务
+func (s *DataDictionaryService) UploadBatch(
+	ctx context.Context,
+	resourceComment string,
+	columnMapping string,
+	files []*multipart.FileHeader,
+) (_ *BatchDetail, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.UploadBatch", attribute.Int("batch.uploads", len(files)))
+	defer func() { tracing.End(span, err) }()
+
+	// 步骤1：参数校验
+	if resourceComment == "" || len(files) == 0 {
+		return nil, errno.ErrInvalidParam
+	}
+
+	// 步骤2：读取全部文件（展开ZIP），收集逐个文件的错误
+	var (
+		excels   []batchFile
+		failures []BatchFileError
+		sources  []string
+	)
+	budget := &batchBudget{maxFiles: s.cfg.Upload.MaxBatchFiles, maxBytes: s.cfg.Upload.MaxBatchBytes}
+	for _, file := range files {
+		sources = append(sources, file.Filename)
+		found, errs, err := s.readBatchUpload(file, budget)
+		if err != nil {
+			return nil, err
+		}
+		excels = append(excels, found...)
+		failures = append(failures, errs...)
+	}
+	if len(excels) == 0 && len(failures) == 0 {
+		return nil, errno.ErrInvalidParam.WithMessage("未找到Excel文件")
+	}
+
+	// 步骤3：逐个校验文件名与列结构（同名文件对应同一数据库，不允许重复；未指定列映射方案时逐个文件自动识别）
+	selected, candidates, err := s.loadColumnMappings(ctx, columnMapping)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]string, len(excels))
+	for i := range excels {
+		f := &excels[i]
+		if prev, ok := seen[f.name]; ok {
+			failures = append(failures, batchFileError(f.source,
+				errno.ErrInvalidParam.WithMessage("文件名重复").WithDetails(map[string]string{"duplicate_of": prev})))
+			continue
+		}
+		seen[f.name] = f.source
+		mapping, err := judgeExcelFormat(f.name, f.content, selected, candidates)
+		if err != nil {
+			failures = append(failures, batchFileError(f.source, err))
+		}
+		f.mapping = mapping
+	}
+	if len(failures) > 0 {
+		s.logger.InfoContext(ctx, "批量上传校验未通过", slog.Int("files", len(excels)), slog.Int("failures", len(failures)))
+		s.auditSvc.Record(ctx, model.AuditActionValidationFailed, "", "", map[string]interface{}{
+			"sources":          sources,
+			"resource_comment": resourceComment,
+			"failures":         failures,
+		})
+		return nil, errno.ErrBatchValidationFailed.WithDetails(failures)
+	}
+
+	// 步骤4：创建批次记录
+	batch := model.NewDictionaryBatch(resourceComment, auth.Actor(ctx), strings.Join(sources, ","), len(excels))
+	if err := s.batchRepo.Create(ctx, batch); err != nil {
+		s.logger.ErrorContext(ctx, "创建批次记录失败", slog.Any("error", err))
+		return nil, errno.ErrDBInsertFailed.WithCause(err)
+	}
+	span.SetAttributes(attribute.String("batch.id", batch.ID), attribute.Int("batch.files", len(excels)))
+	s.auditSvc.Record(ctx, model.AuditActionBatchUpload, "", "", map[string]interface{}{
+		"batch_id":         batch.ID,
+		"sources":          sources,
+		"files":            len(excels),
+		"resource_comment": resourceComment,
+	})
+
+	// 步骤5：逐个创建子任务；中途失败时已创建的子任务保留，批次因子任务不全而无法审批
+	tasks := make([]model.DictionaryTask, 0, len(excels))
+	for _, f := range excels {
+		dictTask, err := s.createTask(ctx, resourceComment, batch.ID, f.mapping, f.name, f.content)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "批次子任务创建失败", slog.String("batch_id", batch.ID),
+				slog.String("file", f.source), slog.Any("error", err))
+			return nil, err
+		}
+		tasks = append(tasks, *dictTask)
+	}
+	s.logger.InfoContext(ctx, "批量上传成功，解析任务已入队",
+		slog.String("batch_id", batch.ID), slog.Int("files", len(tasks)))
+
+	return &BatchDetail{Batch: batch, Status: batch.Status(tasks), Tasks: tasks}, nil
+}
+
+// GetBatch 查询批次详情（含子任务）
+func (s *DataDictionaryService) GetBatch(ctx context.Context, batchID string) (_ *BatchDetail, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.GetBatch", attribute.String("batch.id", batchID))
+	defer func() { tracing.End(span, err) }()
+
+	batch, tasks, err := s.getBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchDetail{Batch: batch, Status: batch.Status(tasks), Tasks: tasks}, nil
+}
+
+// ListBatches 分页查询批次列表（含汇总状态，不含子任务）
+func (s *DataDictionaryService) ListBatches(
+	ctx context.Context,
+	filter repository.DictionaryBatchFilter,
+	page, size int,
+) (_ []BatchDetail, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.ListBatches")
+	defer func() { tracing.End(span, err) }()
+
+	batches, total, err := s.batchRepo.List(ctx, filter, page, size)
+	if err != nil {
+		return nil, 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	items := make([]BatchDetail, 0, len(batches))
+	for i := range batches {
+		batch := &batches[i]
+		status := batch.Decision
+		if !batch.Decided() {
+			tasks, err := s.dictRepo.ListByBatchID(ctx, batch.ID)
+			if err != nil {
+				return nil, 0, errno.ErrDBQueryFailed.WithCause(err)
+			}
+			status = batch.Status(tasks)
+		}
+		items = append(items, BatchDetail{Batch: batch, Status: status})
+	}
+	return items, total, nil
+}
+
+// DecideBatch 批次整体审批：全部子任务解析成功后才能审批，先以条件更新写入批次结论，再逐个子任务记录审批结论
+// 中途失败时已审批的子任务保留结论，以相同结论重试时仅处理未审批的子任务
+func (s *DataDictionaryService) DecideBatch(
+	ctx context.Context,
+	batchID string,
+	confirm bool,
+	rejectReason string,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.DecideBatch",
+		attribute.String("batch.id", batchID),
+		attribute.Bool("confirm", confirm),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	// 步骤1：查询批次与子任务
+	batch, tasks, err := s.getBatch(ctx, batchID)
+	if err != nil {
+		return err
+	}
+
+	// 步骤2：四眼原则：审批人必须已认证且不能是上传人
+	approver := auth.Actor(ctx)
+	if approver == "" {
+		return errno.ErrApproverRequired
+	}
+	if approver == batch.Uploader {
+		s.logger.WarnContext(ctx, "拒绝上传人自行审批批次", slog.String("batch_id", batchID), slog.String("approver", approver))
+		return errno.ErrSelfApproval
+	}
+	decision, action := model.DecisionApproved, model.AuditActionBatchConfirm
+	if !confirm {
+		decision, action = model.DecisionRejected, model.AuditActionBatchReject
+	}
+
+	if batch.Decided() {
+		// 已审批的批次只允许以相同结论补完未审批的子任务
+		if batch.Decision != decision || !slices.ContainsFunc(tasks, func(t model.DictionaryTask) bool { return !t.Decided() }) {
+			return errno.ErrTaskAlreadyDecided
+		}
+	} else {
+		// 步骤3：校验前置条件：子任务齐全且全部解析成功
+		if status := batch.Status(tasks); status != model.BatchStatusReady {
+			notReady := make(map[string]string)
+			for _, t := range tasks {
+				if t.CreateDFTaskStatus != model.TaskStatusSucceeded {
+					notReady[t.ExcelName] = t.CreateDFTaskStatus
+				}
+			}
+			return errno.ErrPreTaskNotCompleted.WithMessage("批次子任务未全部解析成功").WithDetails(map[string]interface{}{
+				"status":     status,
+				"task_count": batch.TaskCount,
+				"found":      len(tasks),
+				"not_ready":  notReady,
+			})
+		}
+
+		// 步骤4：按配置，任一子任务存在error级命名规范违规时批次只能驳回
+		if confirm {
+			for i := range tasks {
+				if err := s.checkNaming(&tasks[i]); err != nil {
+					return err
+				}
+			}
+		}
+
+		// 步骤5：写入批次审批结论（并发审批时只有一个请求成功）
+		batch.Decide(decision, approver, rejectReason)
+		decided, err := s.batchRepo.Decide(ctx, batch)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "更新批次审批结论失败", slog.String("batch_id", batchID), slog.Any("error", err))
+			return errno.ErrDBUpdateFailed.WithCause(err)
+		}
+		if !decided {
+			return errno.ErrTaskAlreadyDecided
+		}
+	}
+
+	// 步骤6：逐个子任务记录审批结论（并发补完时已被其他请求审批的子任务跳过）
+	for i := range tasks {
+		if tasks[i].Decided() {
+			continue
+		}
+		err := s.decideTask(ctx, &tasks[i], approver, confirm, rejectReason)
+		if err != nil && !errors.Is(err, errno.ErrTaskAlreadyDecided) {
+			return err
+		}
+	}
+
+	s.logger.InfoContext(ctx, "批次审批完成", slog.String("batch_id", batchID), slog.String("decision", decision))
+	s.auditSvc.Record(ctx, action, "", "", map[string]interface{}{
+		"batch_id": batchID,
+		"uploader": batch.Uploader,
+		"tasks":    len(tasks),
+		"reason":   rejectReason,
+	})
+	return nil
+}
+
+// getBatch 查询批次及其子任务（区分不存在与查询失败）
+func (s *DataDictionaryService) getBatch(ctx context.Context, batchID string) (*model.DictionaryBatch, []model.DictionaryTask, error) {
+	batch, err := s.batchRepo.GetByID(ctx, batchID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, errno.ErrBatchNotFound.WithDetails(map[string]string{"batch_id": batchID})
+	}
+	if err != nil {
+		return nil, nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	tasks, err := s.dictRepo.ListByBatchID(ctx, batchID)
+	if err != nil {
+		return nil, nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return batch, tasks, nil
+}
+
+// readBatchUpload 读取上传的单个文件：Excel直接返回，ZIP展开其中的Excel（超出批次预算时返回error，整批拒绝）
+func (s *DataDictionaryService) readBatchUpload(file *multipart.FileHeader, budget *batchBudget) ([]batchFile, []BatchFileError, error) {
+	isZip := strings.EqualFold(filepath.Ext(file.Filename), ".zip")
+	if !isZip && !isExcelFile(file.Filename) {
+		return nil, []BatchFileError{batchFileError(file.Filename, errno.ErrInvalidFileFormat)}, nil
+	}
+	// ZIP包本身受请求体上限约束，单个Excel受文件上限约束
+	if maxBytes := s.cfg.Upload.MaxFileBytes; !isZip && maxBytes > 0 && file.Size > maxBytes {
+		return nil, []BatchFileError{batchFileError(file.Filename,
+			errno.ErrFileTooLarge.WithDetails(map[string]int64{"size": file.Size, "max_bytes": maxBytes}))}, nil
+	}
+	if !isZip {
+		if err := budget.admit(file.Size); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, []BatchFileError{batchFileError(file.Filename, errno.ErrFileOpenFailed.WithCause(err))}, nil
+	}
+	defer src.Close()
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return nil, []BatchFileError{batchFileError(file.Filename, errno.ErrFileOpenFailed.WithCause(err))}, nil
+	}
+
+	if !isZip {
+		return []batchFile{{name: file.Filename, source: file.Filename, content: content}}, nil, nil
+	}
+	return s.readZip(file.Filename, content, budget)
+}
+
+// readZip 展开ZIP中的Excel（忽略目录、隐藏文件与macOS元数据），非Excel文件视为错误
+// 每个Excel条目在解压前计入批次预算，超出文件数或总字节数时立即中止
+func (s *DataDictionaryService) readZip(zipName string, content []byte, budget *batchBudget) ([]batchFile, []BatchFileError, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, []BatchFileError{batchFileError(zipName, errno.ErrZipReadFailed.WithCause(err))}, nil
+	}
+
+	var (
+		files    []batchFile
+		failures []BatchFileError
+	)
+	maxBytes := s.cfg.Upload.MaxFileBytes
+	for _, entry := range reader.File {
+		name := zipEntryName(entry)
+		base := path.Base(name)
+		if entry.FileInfo().IsDir() || strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(base, ".") {
+			continue
+		}
+		source := zipName + "/" + name
+		if !isExcelFile(base) {
+			failures = append(failures, batchFileError(source, errno.ErrInvalidFileFormat))
+			continue
+		}
+		declared := int64(entry.UncompressedSize64)
+		if maxBytes > 0 && entry.UncompressedSize64 > uint64(maxBytes) {
+			failures = append(failures, batchFileError(source, errno.ErrFileTooLarge.WithDetails(
+				map[string]int64{"size": declared, "max_bytes": maxBytes})))
+			continue
+		}
+		if err := budget.admit(declared); err != nil {
+			return nil, nil, err
+		}
+		data, err := readZipEntry(entry, maxBytes)
+		if err != nil {
+			failures = append(failures, batchFileError(source, err))
+			continue
+		}
+		// 头部声明的大小可能被伪造，按实际解压字节数修正
+		if err := budget.grow(int64(len(data)) - declared); err != nil {
+			return nil, nil, err
+		}
+		files = append(files, batchFile{name: base, source: source, content: data})
+	}
+	return files, failures, nil
+}
+
+// batchBudget 批量上传的Excel文件数与解压总字节数预算
+type batchBudget struct {
+	maxFiles int
+	maxBytes int64
+	files    int
+	bytes    int64
+}
+
+// admit 计入一个Excel文件，超出文件数或总字节数上限时返回错误
+func (b *batchBudget) admit(size int64) error {
+	b.files++
+	if b.maxFiles > 0 && b.files > b.maxFiles {
+		return errno.ErrBatchTooManyFiles.WithDetails(map[string]int{"max_files": b.maxFiles})
+	}
+	return b.grow(size)
+}
+
+// grow 累加解压字节数，超出总字节数上限时返回错误
+func (b *batchBudget) grow(size int64) error {
+	b.bytes += size
+	if b.maxBytes > 0 && b.bytes > b.maxBytes {
+		return errno.ErrFileTooLarge.WithMessage("批量上传的文件总大小超过上限").
+			WithDetails(map[string]int64{"max_bytes": b.maxBytes})
+	}
+	return nil
+}
+
+// readZipEntry 读取ZIP条目（按实际解压字节数再次限制大小，防止伪造头部的压缩炸弹）
+func readZipEntry(entry *zip.File, maxBytes int64) ([]byte, error) {
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, errno.ErrZipReadFailed.WithCause(err)
+	}
+	defer rc.Close()
+
+	var r io.Reader = rc
+	if maxBytes > 0 {
+		r = io.LimitReader(rc, maxBytes+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errno.ErrZipReadFailed.WithCause(err)
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, errno.ErrFileTooLarge.WithDetails(map[string]int64{"max_bytes": maxBytes})
+	}
+	return data, nil
+}
+
+// zipEntryName 返回ZIP条目名（Windows压缩工具常以GBK编码中文文件名，不是合法UTF-8时按GB18030解码）
+// 很多工具以UTF-8写入文件名却不设置UTF-8标记，因此按内容而非标记判断
+func zipEntryName(entry *zip.File) string {
+	if utf8.ValidString(entry.Name) {
+		return entry.Name
+	}
+	if name, err := simplifiedchinese.GB18030.NewDecoder().String(entry.Name); err == nil {
+		return name
+	}
+	return entry.Name
+}
+
+// batchFileError 将校验错误转换为逐个文件的错误项
+func batchFileError(file string, err error) BatchFileError {
+	var e *errno.Errno
+	if errors.As(err, &e) {
+		return BatchFileError{File: file, Code: e.Code, Msg: e.Msg, Details: e.Details}
+	}
+	return BatchFileError{File: file, Code: errno.ErrInternalServer.Code, Msg: errno.ErrInternalServer.Msg}
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"archive/zip"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"testing"
+)
+
+func TestZipEntryName(t *testing.T) {
+	gbk, err := simplifiedchinese.GB18030.NewEncoder().String("海关-报关单.xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		entry zip.File
+		want  string
+	}{
+		{"UTF-8标记", zip.File{FileHeader: zip.FileHeader{Name: "海关-报关单.xlsx"}}, "海关-报关单.xlsx"},
+		{"UTF-8未标记", zip.File{FileHeader: zip.FileHeader{Name: "海关-报关单.xlsx", NonUTF8: true}}, "海关-报关单.xlsx"},
+		{"GBK", zip.File{FileHeader: zip.FileHeader{Name: gbk, NonUTF8: true}}, "海关-报关单.xlsx"},
+		{"ASCII", zip.File{FileHeader: zip.FileHeader{Name: "customs-entry.xlsx", NonUTF8: true}}, "customs-entry.xlsx"},
+	}
+	for _, tt := range tests {
+		if got := zipEntryName(&tt.entry); got != tt.want {
+			t.Errorf("%s: zipEntryName() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"customs/common/errno"
+	"customs/infrastructure/minio"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	htmltemplate "html/template"
+	"io"
+	"log/slog"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DictionaryTable 按表汇总的字段（保持工作簿中的顺序）
+type DictionaryTable struct {
+	NameEN string                  // 表名（英文）
+	NameCN string                  // 表名（中文）
+	Fields []model.DictionaryField // 字段
+}
+
+// dictionaryDoc 文档渲染数据
+type dictionaryDoc struct {
+	SystemName      string
+	DBName          string
+	ResourceComment string
+	GeneratedAt     string
+	Tables          []DictionaryTable
+}
+
+// groupTables 按英文表名汇总字段（表按首次出现的顺序排列）
+func groupTables(fields []model.DictionaryField) []DictionaryTable {
+	index := make(map[string]int)
+	var tables []DictionaryTable
+	for _, f := range fields {
+		i, ok := index[f.TableNameEN]
+		if !ok {
+			i = len(tables)
+			index[f.TableNameEN] = i
+			tables = append(tables, DictionaryTable{NameEN: f.TableNameEN, NameCN: f.TableNameCN})
+		}
+		tables[i].Fields = append(tables[i].Fields, f)
+	}
+	return tables
+}
+
+// markdownCellReplacer 转义Markdown表格单元格：竖线与换行会破坏表格结构，尖括号会被当作HTML标签
+var markdownCellReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+// markdownCell 转义Markdown表格单元格
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(s)
+}
+
+// templateFuncs 文档模板函数
+var templateFuncs = map[string]interface{}{
+	"inc":  func(i int) int { return i + 1 },
+	"cell": markdownCell,
+}
+
+// markdownDocTemplate Markdown文档：目录后逐表列出字段（锚点使用显式HTML标签，避免中文标题锚点规则差异）
+var markdownDocTemplate = template.Must(template.New("markdown").Funcs(templateFuncs).Parse(
+	`# {{.SystemName}} / {{.DBName}} 数据字典
+
+- 资源备注：{{.ResourceComment}}
+- 生成时间：{{.GeneratedAt}}
+- 表数量：{{len .Tables}}
+
+## 目录
+
+{{range $i, $t := .Tables}}{{inc $i}}. [{{cell $t.NameEN}}{{if $t.NameCN}}（{{cell $t.NameCN}}）{{end}}](#table-{{inc $i}})
+{{end}}{{range $i, $t := .Tables}}
+<a id="table-{{inc $i}}"></a>
+
+## {{inc $i}}. {{cell $t.NameEN}}{{if $t.NameCN}} {{cell $t.NameCN}}{{end}}
+
+| 序号 | 字段名称（英文） | 字段名称（中文） | 字段说明 |
+| ---: | --- | --- | --- |
+{{range $j, $f := $t.Fields}}| {{inc $j}} | {{cell $f.FieldNameEN}} | {{cell $f.FieldNameCN}} | {{cell $f.FieldDesc}} |
+{{end}}{{end}}`))
+
+// htmlDocTemplate 自包含HTML文档（内联样式，无外部依赖，可离线查看）
+var htmlDocTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.SystemName}} / {{.DBName}} 数据字典</title>
+<style>
+body{font-family:-apple-system,"Segoe UI","PingFang SC","Microsoft YaHei",sans-serif;margin:0 auto;max-width:1100px;padding:24px;color:#1f2328;line-height:1.6}
+h1{border-bottom:1px solid #d0d7de;padding-bottom:8px}
+h2{margin-top:32px;border-bottom:1px solid #d0d7de;padding-bottom:4px}
+.meta{color:#59636e}
+.toc ol{columns:2}
+table{border-collapse:collapse;width:100%}
+th,td{border:1px solid #d0d7de;padding:6px 10px;text-align:left;vertical-align:top}
+th{background:#f6f8fa}
+td.no{text-align:right;width:48px;color:#59636e}
+td.desc{white-space:pre-wrap}
+a{color:#0969da;text-decoration:none}
+</style>
+</head>
+<body>
+<h1>{{.SystemName}} / {{.DBName}} 数据字典</h1>
+<p class="meta">资源备注：{{.ResourceComment}}　生成时间：{{.GeneratedAt}}　表数量：{{len .Tables}}</p>
+<nav class="toc">
+<h2>目录</h2>
+<ol>
+{{range $i, $t := .Tables}}<li><a href="#table-{{inc $i}}">{{$t.NameEN}}{{if $t.NameCN}}（{{$t.NameCN}}）{{end}}</a></li>
+{{end}}</ol>
+</nav>
+{{range $i, $t := .Tables}}<section id="table-{{inc $i}}">
+<h2>{{inc $i}}. {{$t.NameEN}}{{if $t.NameCN}} {{$t.NameCN}}{{end}}</h2>
+<table>
+<thead><tr><th>序号</th><th>字段名称（英文）</th><th>字段名称（中文）</th><th>字段说明</th></tr></thead>
+<tbody>
+{{range $j, $f := $t.Fields}}<tr><td class="no">{{inc $j}}</td><td>{{$f.FieldNameEN}}</td><td>{{$f.FieldNameCN}}</td><td class="desc">{{$f.FieldDesc}}</td></tr>
+{{end}}</tbody>
+</table>
+</section>
+{{end}}</body>
+</html>
+`))
+
+// PublishDocs 为数据库资源重新生成Markdown与HTML文档并存入文档桶（入库成功后调用）
+func (s *ExportService) PublishDocs(ctx context.Context, dbResourceID string) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.PublishDocs")
+	defer func() { tracing.End(span, err) }()
+
+	_, err = s.publishDocs(ctx, dbResourceID)
+	return err
+}
+
+// DownloadDocs 获取数据库资源的文档ZIP（含Markdown与HTML）；文档尚未生成时即时生成
+func (s *ExportService) DownloadDocs(ctx context.Context, dbResourceID string) (_ []byte, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.DownloadDocs")
+	defer func() { tracing.End(span, err) }()
+
+	resource, err := s.getDBResource(ctx, dbResourceID)
+	if err != nil {
+		return nil, "", err
+	}
+	fileName := docsFileName(resource)
+
+	content, err := s.readDocs(ctx, dbResourceID)
+	if err != nil && !minio.IsNotFound(err) {
+		return nil, "", errno.ErrMinioDownloadFailed.WithCause(err)
+	}
+	if err != nil {
+		if content, err = s.publishDocs(ctx, dbResourceID); err != nil {
+			return nil, "", err
+		}
+	}
+	s.auditSvc.Record(ctx, model.AuditActionExport, "", dbResourceID, map[string]interface{}{
+		"format": "docs",
+		"file":   fileName,
+	})
+	return content, fileName, nil
+}
+
+// publishDocs 生成文档ZIP并上传，返回ZIP内容
+func (s *ExportService) publishDocs(ctx context.Context, dbResourceID string) ([]byte, error) {
+	fields, err := s.scopeFields(ctx, repository.DictionaryFieldFilter{DBResourceID: dbResourceID})
+	if err != nil {
+		return nil, err
+	}
+	content, err := renderDocsZip(fields)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "生成数据字典文档失败", slog.String("db_resource_id", dbResourceID), slog.Any("error", err))
+		return nil, errno.ErrInternalServer.WithCause(err)
+	}
+	if err := s.minioClient.UploadFile(ctx, s.cfg.Minio.DocsBucket, docsObjectName(dbResourceID),
+		bytes.NewReader(content), int64(len(content))); err != nil {
+		return nil, errno.ErrMinioUploadFailed.WithCause(err)
+	}
+	s.logger.InfoContext(ctx, "数据字典文档已生成", slog.String("db_resource_id", dbResourceID), slog.Int("fields", len(fields)))
+	return content, nil
+}
+
+// readDocs 读取已生成的文档ZIP
+func (s *ExportService) readDocs(ctx context.Context, dbResourceID string) ([]byte, error) {
+	obj, err := s.minioClient.DownloadFile(ctx, s.cfg.Minio.DocsBucket, docsObjectName(dbResourceID))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(obj)
+}
+
+// renderDocsZip 渲染Markdown与HTML文档并打包（文件名为"系统名-dbname.md/.html"）
+func renderDocsZip(fields []model.DictionaryField) ([]byte, error) {
+	doc := dictionaryDoc{
+		SystemName:      fields[0].SystemName,
+		DBName:          fields[0].DBName,
+		ResourceComment: fields[0].ResourceComment,
+		GeneratedAt:     time.Now().Format(time.DateTime),
+		Tables:          groupTables(fields),
+	}
+	baseName := doc.SystemName + "-" + doc.DBName
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range []struct {
+		name   string
+		render func(io.Writer, interface{}) error
+	}{
+		{baseName + ".md", markdownDocTemplate.Execute},
+		{baseName + ".html", htmlDocTemplate.Execute},
+	} {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return nil, err
+		}
+		if err := file.render(w, doc); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// docsObjectName 文档ZIP在文档桶中的对象名
+func docsObjectName(dbResourceID string) string {
+	return dbResourceID + ".zip"
+}
+
+// docsFileName 文档ZIP的下载文件名
+func docsFileName(resource *model.DBResource) string {
+	if resource.SystemName == "" {
+		return resource.DBName + "-docs.zip"
+	}
+	return resource.SystemName + "-" + resource.DBName + "-docs.zip"
+}
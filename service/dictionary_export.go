@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"customs/common/errno"
+	"customs/config"
+	"customs/infrastructure/minio"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"log/slog"
+)
+
+// ExportService 已入库数据字典的导出服务
+type ExportService struct {
+	cfg         *config.Config
+	logger      *slog.Logger
+	minioClient *minio.Client // 存取生成的文档
+	dbResRepo   *repository.DBResourceRepository
+	fieldRepo   *repository.DictionaryFieldRepository
+	relRepo     *repository.TableRelationRepository
+	auditSvc    *AuditService
+}
+
+// NewExportService 初始化导出服务
+func NewExportService(
+	cfg *config.Config,
+	logger *slog.Logger,
+	minioClient *minio.Client,
+	dbResRepo *repository.DBResourceRepository,
+	fieldRepo *repository.DictionaryFieldRepository,
+	relRepo *repository.TableRelationRepository,
+	auditSvc *AuditService,
+) *ExportService {
+	return &ExportService{
+		cfg:         cfg,
+		logger:      logger,
+		minioClient: minioClient,
+		dbResRepo:   dbResRepo,
+		fieldRepo:   fieldRepo,
+		relRepo:     relRepo,
+		auditSvc:    auditSvc,
+	}
+}
+
+// ListDBResources 分页查询已登记的数据库资源
+func (s *ExportService) ListDBResources(
+	ctx context.Context,
+	filter repository.DBResourceFilter,
+	page, size int,
+) (_ []model.DBResource, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ListDBResources")
+	defer func() { tracing.End(span, err) }()
+
+	resources, total, err := s.dbResRepo.List(ctx, filter, page, size)
+	if err != nil {
+		return nil, 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return resources, total, nil
+}
+
+// ExportExcel 将已入库的数据字典导出为模板格式的工作簿，文件名为"系统名-dbname.xlsx"，可直接重新上传
+// filter需指定资源ID，或 系统名+库名（可附加资源备注消除歧义）
+func (s *ExportService) ExportExcel(
+	ctx context.Context,
+	filter repository.DictionaryFieldFilter,
+) (_ []byte, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportExcel")
+	defer func() { tracing.End(span, err) }()
+
+	fields, err := s.scopeFields(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	relations, err := s.relRepo.ListByResource(ctx, fields[0].DBResourceID, "")
+	if err != nil {
+		return nil, "", errno.ErrDBQueryFailed.WithCause(err)
+	}
+
+	content, err := buildDictionaryWorkbook(fields, relations)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "生成导出工作簿失败", slog.Any("error", err))
+		return nil, "", errno.ErrInternalServer.WithCause(err)
+	}
+	fileName := fields[0].SystemName + "-" + fields[0].DBName + ".xlsx"
+	s.auditSvc.Record(ctx, model.AuditActionExport, "", fields[0].DBResourceID, map[string]interface{}{
+		"format": "xlsx",
+		"file":   fileName,
+		"fields": len(fields),
+	})
+	return content, fileName, nil
+}
+
+// scopeFields 查询单个 资源备注+库名 范围内的字段；无数据返回ErrDictionaryNotFound，跨多个范围返回ErrDictionaryAmbiguous
+func (s *ExportService) scopeFields(
+	ctx context.Context,
+	filter repository.DictionaryFieldFilter,
+) ([]model.DictionaryField, error) {
+	if filter.DBResourceID != "" {
+		if _, err := s.getDBResource(ctx, filter.DBResourceID); err != nil {
+			return nil, err
+		}
+	} else if filter.SystemName == "" || filter.DBName == "" {
+		return nil, errno.ErrInvalidParam.WithMessage("请指定资源ID，或同时指定系统名与数据库名")
+	}
+
+	fields, err := s.fieldRepo.List(ctx, filter)
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	if len(fields) == 0 {
+		return nil, errno.ErrDictionaryNotFound
+	}
+
+	var comments []string
+	seen := make(map[string]bool)
+	for _, f := range fields {
+		if !seen[f.ResourceComment] {
+			seen[f.ResourceComment] = true
+			comments = append(comments, f.ResourceComment)
+		}
+	}
+	if len(comments) > 1 {
+		return nil, errno.ErrDictionaryAmbiguous.WithDetails(map[string]interface{}{"resource_comments": comments})
+	}
+	return fields, nil
+}
+
+// getDBResource 查询数据库资源（不存在时返回ErrDBResourceNotFound）
+func (s *ExportService) getDBResource(ctx context.Context, dbResourceID string) (*model.DBResource, error) {
+	return findDBResource(ctx, s.dbResRepo, dbResourceID)
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"customs/common/errno"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// jsonSchemaDraft 生成的JSON Schema版本
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// sqlDialect DDL方言（数据字典不含字段类型，统一使用占位类型，生成后需按实际类型调整）
+type sqlDialect struct {
+	name           string
+	placeholder    string              // 占位字段类型
+	quote          func(string) string // 标识符引用
+	literal        func(string) string // 字符串字面量
+	inlineComments bool                // 注释写在建表语句内（MySQL），否则使用COMMENT ON语句
+}
+
+// sqlDialects 支持的方言（键为规范化后的名称）
+var sqlDialects = map[string]sqlDialect{
+	"mysql": {
+		name:           "mysql",
+		placeholder:    "VARCHAR(255)",
+		quote:          func(s string) string { return "`" + strings.ReplaceAll(s, "`", "``") + "`" },
+		literal:        func(s string) string { return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(s) + "'" },
+		inlineComments: true,
+	},
+	"postgresql": {
+		name:        "postgresql",
+		placeholder: "VARCHAR(255)",
+		quote:       func(s string) string { return `"` + strings.ReplaceAll(s, `"`, `""`) + `"` },
+		literal:     func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" },
+	},
+	"oracle": {
+		name:        "oracle",
+		placeholder: "VARCHAR2(255 CHAR)",
+		quote:       func(s string) string { return `"` + strings.ReplaceAll(s, `"`, `""`) + `"` },
+		literal:     func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" },
+	},
+}
+
+// dialectAliases 资源类型（不区分大小写）到方言的映射
+var dialectAliases = map[string]string{
+	"":           "mysql",
+	"mysql":      "mysql",
+	"mariadb":    "mysql",
+	"tidb":       "mysql",
+	"postgresql": "postgresql",
+	"postgres":   "postgresql",
+	"pg":         "postgresql",
+	"oracle":     "oracle",
+}
+
+// lookupDialect 按名称查找方言，不支持时返回参数错误
+func lookupDialect(name string) (sqlDialect, error) {
+	if key, ok := dialectAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return sqlDialects[key], nil
+	}
+	return sqlDialect{}, errno.ErrInvalidParam.WithMessage("不支持的SQL方言：" + name).
+		WithDetails(map[string]interface{}{"supported": []string{"mysql", "postgresql", "oracle"}})
+}
+
+// ExportDDL 按数据字典生成建表语句，表与字段注释取自中文名称与说明
+// dialect为空时按资源类型（DBResource.ResourceType）选择方言，资源类型为空或没有对应方言（如sqlite）时使用MySQL
+func (s *ExportService) ExportDDL(ctx context.Context, dbResourceID, dialect string) (_ []byte, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportDDL")
+	defer func() { tracing.End(span, err) }()
+
+	resource, err := s.getDBResource(ctx, dbResourceID)
+	if err != nil {
+		return nil, "", err
+	}
+	d := sqlDialects["mysql"]
+	if dialect != "" {
+		// 显式指定的方言不支持时报错
+		if d, err = lookupDialect(dialect); err != nil {
+			return nil, "", err
+		}
+	} else if byType, err := lookupDialect(resource.ResourceType); err == nil {
+		d = byType
+	}
+	fields, err := s.scopeFields(ctx, repository.DictionaryFieldFilter{DBResourceID: dbResourceID})
+	if err != nil {
+		return nil, "", err
+	}
+
+	content := renderDDL(d, fields)
+	fileName := fmt.Sprintf("%s-%s.%s.sql", fields[0].SystemName, fields[0].DBName, d.name)
+	s.auditSvc.Record(ctx, model.AuditActionExport, "", dbResourceID, map[string]interface{}{
+		"format":  "ddl",
+		"dialect": d.name,
+		"file":    fileName,
+	})
+	return content, fileName, nil
+}
+
+// ExportJSONSchema 按数据字典为每张表生成一个JSON Schema文档，打包为ZIP（文件名为"表名.schema.json"，见schemaEntryName）
+func (s *ExportService) ExportJSONSchema(ctx context.Context, dbResourceID string) (_ []byte, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportJSONSchema")
+	defer func() { tracing.End(span, err) }()
+
+	fields, err := s.scopeFields(ctx, repository.DictionaryFieldFilter{DBResourceID: dbResourceID})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	used := make(map[string]bool)
+	for _, table := range groupTables(fields) {
+		doc, err := json.MarshalIndent(tableJSONSchema(fields[0], table), "", "  ")
+		if err != nil {
+			return nil, "", errno.ErrInternalServer.WithCause(err)
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: schemaEntryName(table.NameEN, used), Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return nil, "", errno.ErrInternalServer.WithCause(err)
+		}
+		if _, err := w.Write(doc); err != nil {
+			return nil, "", errno.ErrInternalServer.WithCause(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", errno.ErrInternalServer.WithCause(err)
+	}
+
+	fileName := fields[0].SystemName + "-" + fields[0].DBName + "-schema.zip"
+	s.auditSvc.Record(ctx, model.AuditActionExport, "", dbResourceID, map[string]interface{}{
+		"format": "json_schema",
+		"file":   fileName,
+	})
+	return buf.Bytes(), fileName, nil
+}
+
+// schemaEntryName ZIP条目名：表名中路径分隔符等字符替换为下划线、去除开头的"."，
+// 防止解压时写到目录之外；替换后重名（不区分大小写）时追加序号
+func schemaEntryName(tableName string, used map[string]bool) string {
+	base := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, tableName)
+	base = strings.TrimLeft(base, ".")
+	if base == "" {
+		base = "table"
+	}
+	name := base + ".schema.json"
+	for n := 2; used[strings.ToLower(name)]; n++ {
+		name = fmt.Sprintf("%s_%d.schema.json", base, n)
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+// renderDDL 生成建表语句（每张表一条，字段按工作簿顺序，声明了主键的表附加主键约束）
+func renderDDL(d sqlDialect, fields []model.DictionaryField) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- %s / %s 数据字典建表语句（%s）\n", fields[0].SystemName, fields[0].DBName, d.name)
+	fmt.Fprintf(&b, "-- 资源备注：%s\n", strings.Join(strings.Fields(fields[0].ResourceComment), " "))
+	fmt.Fprintf(&b, "-- 生成时间：%s\n", time.Now().Format(time.DateTime))
+	fmt.Fprintf(&b, "-- 数据字典不含字段类型，字段统一使用占位类型%s，执行前请按实际类型调整\n", d.placeholder)
+
+	for _, table := range groupTables(fields) {
+		b.WriteString("\n")
+		var keys []string
+		for _, f := range table.Fields {
+			if f.PrimaryKey {
+				keys = append(keys, d.quote(f.FieldNameEN))
+			}
+		}
+		fmt.Fprintf(&b, "CREATE TABLE %s (\n", d.quote(table.NameEN))
+		for i, f := range table.Fields {
+			fmt.Fprintf(&b, "  %s %s", d.quote(f.FieldNameEN), d.placeholder)
+			if comment := fieldComment(f); d.inlineComments && comment != "" {
+				fmt.Fprintf(&b, " COMMENT %s", d.literal(comment))
+			}
+			if i < len(table.Fields)-1 || len(keys) > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		if len(keys) > 0 {
+			fmt.Fprintf(&b, "  PRIMARY KEY (%s)\n", strings.Join(keys, ", "))
+		}
+		b.WriteString(")")
+		if d.inlineComments {
+			b.WriteString(" ENGINE=InnoDB DEFAULT CHARSET=utf8mb4")
+			if table.NameCN != "" {
+				fmt.Fprintf(&b, " COMMENT=%s", d.literal(table.NameCN))
+			}
+		}
+		b.WriteString(";\n")
+		if d.inlineComments {
+			continue
+		}
+		if table.NameCN != "" {
+			fmt.Fprintf(&b, "COMMENT ON TABLE %s IS %s;\n", d.quote(table.NameEN), d.literal(table.NameCN))
+		}
+		for _, f := range table.Fields {
+			if comment := fieldComment(f); comment != "" {
+				fmt.Fprintf(&b, "COMMENT ON COLUMN %s.%s IS %s;\n", d.quote(table.NameEN), d.quote(f.FieldNameEN), d.literal(comment))
+			}
+		}
+	}
+	return []byte(b.String())
+}
+
+// fieldComment 字段注释："中文名称：说明"（任一为空时只取另一项）
+func fieldComment(f model.DictionaryField) string {
+	switch {
+	case f.FieldNameCN == "":
+		return f.FieldDesc
+	case f.FieldDesc == "":
+		return f.FieldNameCN
+	default:
+		return f.FieldNameCN + "：" + f.FieldDesc
+	}
+}
+
+// tableJSONSchema 生成单张表的JSON Schema（字段类型未知，属性只包含标题与说明）
+func tableJSONSchema(scope model.DictionaryField, table DictionaryTable) map[string]interface{} {
+	properties := make(map[string]interface{}, len(table.Fields))
+	order := make([]string, 0, len(table.Fields))
+	for _, f := range table.Fields {
+		prop := map[string]interface{}{}
+		if f.FieldNameCN != "" {
+			prop["title"] = f.FieldNameCN
+		}
+		if f.FieldDesc != "" {
+			prop["description"] = f.FieldDesc
+		}
+		properties[f.FieldNameEN] = prop
+		order = append(order, f.FieldNameEN)
+	}
+
+	schema := map[string]interface{}{
+		"$schema":              jsonSchemaDraft,
+		"$id":                  fmt.Sprintf("urn:customs:dictionary:%s:%s:%s", scope.SystemName, scope.DBName, table.NameEN),
+		"title":                table.NameEN,
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+		"x-field-order":        order,
+	}
+	if table.NameCN != "" {
+		schema["description"] = table.NameCN
+	}
+	return schema
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"customs/common/errno"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"html"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minSearchRunes 检索词最少字符数（与MySQL ngram_token_size默认值2一致，更短的词无法命中索引）
+const minSearchRunes = 2
+
+// SearchHit 检索命中的字段
+type SearchHit struct {
+	Field      model.DictionaryField `json:"field"`      // 字段记录
+	Score      float64               `json:"score"`      // 相关度（MySQL全文检索评分）
+	Highlights map[string]string     `json:"highlights"` // 命中列的高亮片段（已HTML转义，命中处以<em>标记），键为JSON字段名
+}
+
+// SearchService 数据字典全文检索服务
+type SearchService struct {
+	logger    *slog.Logger
+	fieldRepo *repository.DictionaryFieldRepository
+}
+
+// NewSearchService 初始化检索服务
+func NewSearchService(logger *slog.Logger, fieldRepo *repository.DictionaryFieldRepository) *SearchService {
+	return &SearchService{logger: logger, fieldRepo: fieldRepo}
+}
+
+// Search 按表名/字段名（中英文）与字段说明全文检索，按相关度倒序分页返回并标注命中片段
+func (s *SearchService) Search(
+	ctx context.Context,
+	query string,
+	filter repository.DictionaryFieldFilter,
+	page, size int,
+) (_ []SearchHit, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "SearchService.Search")
+	defer func() { tracing.End(span, err) }()
+
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) < minSearchRunes {
+		return nil, 0, errno.ErrInvalidParam.WithMessage("检索词至少需要2个字符")
+	}
+
+	matches, total, err := s.fieldRepo.Search(ctx, query, filter, page, size)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "全文检索失败", slog.String("query", query), slog.Any("error", err))
+		return nil, 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+
+	terms := searchTerms(query)
+	hits := make([]SearchHit, 0, len(matches))
+	for _, m := range matches {
+		hits = append(hits, SearchHit{
+			Field:      m.DictionaryField,
+			Score:      m.Score,
+			Highlights: highlightField(&m.DictionaryField, terms),
+		})
+	}
+	return hits, total, nil
+}
+
+// searchTerms 拆分高亮用的检索词：按空白切分；含中文的词另外拆成二元组（与ngram分词一致，部分命中也能标注）
+func searchTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	add := func(t string) {
+		t = strings.ToLower(t)
+		if utf8.RuneCountInString(t) >= minSearchRunes && !seen[t] {
+			seen[t] = true
+			terms = append(terms, t)
+		}
+	}
+	for _, word := range strings.Fields(query) {
+		add(word)
+		runes := []rune(word)
+		if len(runes) <= minSearchRunes || !containsHan(runes) {
+			continue
+		}
+		for i := 0; i+minSearchRunes <= len(runes); i++ {
+			add(string(runes[i : i+minSearchRunes]))
+		}
+	}
+	return terms
+}
+
+// containsHan 是否包含汉字
+func containsHan(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// highlightField 为字段记录中命中检索词的列生成高亮片段（未命中的列不返回）
+func highlightField(f *model.DictionaryField, terms []string) map[string]string {
+	columns := []struct {
+		key, value string
+	}{
+		{"table_name_en", f.TableNameEN},
+		{"table_name_cn", f.TableNameCN},
+		{"field_name_en", f.FieldNameEN},
+		{"field_name_cn", f.FieldNameCN},
+		{"field_desc", f.FieldDesc},
+	}
+	highlights := make(map[string]string)
+	for _, col := range columns {
+		if marked, ok := highlight(col.value, terms); ok {
+			highlights[col.key] = marked
+		}
+	}
+	return highlights
+}
+
+// highlight 将text中命中terms的区间（忽略大小写，重叠区间合并）以<em>标记，其余部分HTML转义
+func highlight(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		// 极少数字符转小写后长度变化，无法按位置对应，退化为区分大小写匹配
+		lower = runes
+	}
+
+	type span struct{ start, end int }
+	var spans []span
+	for _, term := range terms {
+		t := []rune(term)
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == term {
+				spans = append(spans, span{i, i + len(t)})
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	merged := spans[:1]
+	for _, sp := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if sp.start <= last.end {
+			last.end = max(last.end, sp.end)
+			continue
+		}
+		merged = append(merged, sp)
+	}
+
+	var b strings.Builder
+	pos := 0
+	for _, sp := range merged {
+		b.WriteString(html.EscapeString(string(runes[pos:sp.start])))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(string(runes[sp.start:sp.end])))
+		b.WriteString("</em>")
+		pos = sp.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:])))
+	return b.String(), true
+}
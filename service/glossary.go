@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"customs/common/auth"
+	"customs/common/errno"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"errors"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+	"unicode"
+)
+
+// GlossaryService 业务术语服务（术语维护、字段与术语的关联）
+type GlossaryService struct {
+	logger       *slog.Logger
+	glossaryRepo *repository.GlossaryRepository
+	fieldRepo    *repository.DictionaryFieldRepository
+	dbResRepo    *repository.DBResourceRepository
+	auditSvc     *AuditService
+}
+
+// NewGlossaryService 初始化业务术语服务
+func NewGlossaryService(
+	logger *slog.Logger,
+	glossaryRepo *repository.GlossaryRepository,
+	fieldRepo *repository.DictionaryFieldRepository,
+	dbResRepo *repository.DBResourceRepository,
+	auditSvc *AuditService,
+) *GlossaryService {
+	return &GlossaryService{
+		logger:       logger,
+		glossaryRepo: glossaryRepo,
+		fieldRepo:    fieldRepo,
+		dbResRepo:    dbResRepo,
+		auditSvc:     auditSvc,
+	}
+}
+
+// TermInput 新增或修改术语的参数
+type TermInput struct {
+	Name         string // 术语名称（中文）
+	Abbreviation string // 标准英文缩写
+	Definition   string // 定义
+}
+
+// TermFields 术语及关联的字段
+type TermFields struct {
+	Term   *model.GlossaryTerm         `json:"term"`
+	Fields []repository.TermLinkDetail `json:"fields"`
+}
+
+// ListTerms 分页查询术语
+func (s *GlossaryService) ListTerms(ctx context.Context, keyword string, page, size int) (_ []model.GlossaryTerm, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryService.ListTerms")
+	defer func() { tracing.End(span, err) }()
+
+	terms, total, err := s.glossaryRepo.ListTerms(ctx, strings.TrimSpace(keyword), page, size)
+	if err != nil {
+		return nil, 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return terms, total, nil
+}
+
+// CreateTerm 新增术语（名称唯一）
+func (s *GlossaryService) CreateTerm(ctx context.Context, in TermInput) (_ *model.GlossaryTerm, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryService.CreateTerm")
+	defer func() { tracing.End(span, err) }()
+
+	in = normalizeTermInput(in)
+	if err := s.checkTermName(ctx, in.Name, ""); err != nil {
+		return nil, err
+	}
+	term := model.NewGlossaryTerm(in.Name, in.Abbreviation, in.Definition, auth.Actor(ctx))
+	if err := s.glossaryRepo.CreateTerm(ctx, term); err != nil {
+		return nil, errno.ErrDBInsertFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionTermCreate, "", "", map[string]string{
+		"term_id":      term.ID,
+		"name":         term.Name,
+		"abbreviation": term.Abbreviation,
+	})
+	return term, nil
+}
+
+// UpdateTerm 修改术语
+func (s *GlossaryService) UpdateTerm(ctx context.Context, termID string, in TermInput) (_ *model.GlossaryTerm, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryService.UpdateTerm")
+	defer func() { tracing.End(span, err) }()
+
+	term, err := s.getTerm(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	in = normalizeTermInput(in)
+	if err := s.checkTermName(ctx, in.Name, term.ID); err != nil {
+		return nil, err
+	}
+	before := map[string]string{"name": term.Name, "abbreviation": term.Abbreviation}
+	term.Name, term.Abbreviation, term.Definition = in.Name, in.Abbreviation, in.Definition
+	if err := s.glossaryRepo.UpdateTerm(ctx, term); err != nil {
+		return nil, errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionTermUpdate, "", "", map[string]interface{}{
+		"term_id": term.ID,
+		"before":  before,
+		"after":   map[string]string{"name": term.Name, "abbreviation": term.Abbreviation},
+	})
+	return term, nil
+}
+
+// DeleteTerm 删除术语及其全部字段关联
+func (s *GlossaryService) DeleteTerm(ctx context.Context, termID string) (err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryService.DeleteTerm")
+	defer func() { tracing.End(span, err) }()
+
+	term, err := s.getTerm(ctx, termID)
+	if err != nil {
+		return err
+	}
+	if err := s.glossaryRepo.DeleteTerm(ctx, term.ID); err != nil {
+		return errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionTermDelete, "", "", map[string]string{
+		"term_id": term.ID,
+		"name":    term.Name,
+	})
+	return nil
+}
+
+// GetTermFields 查询术语及关联的字段（跨系统对照同一业务概念的描述）
+func (s *GlossaryService) GetTermFields(ctx context.Context, termID string) (_ *TermFields, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryService.GetTermFields")
+	defer func() { tracing.End(span, err) }()
+
+	term, err := s.getTerm(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.glossaryRepo.ListLinks(ctx, repository.TermLinkFilter{TermID: term.ID})
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return &TermFields{Term: term, Fields: links}, nil
+}
+
+// ListResourceLinks 查询数据库资源下字段的术语关联
+func (s *GlossaryService) ListResourceLinks(ctx context.Context, dbResourceID string) (_ []repository.TermLinkDetail, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryService.ListResourceLinks")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := findDBResource(ctx, s.dbResRepo, dbResourceID); err != nil {
+		return nil, err
+	}
+	links, err := s.glossaryRepo.ListLinks(ctx, repository.TermLinkFilter{DBResourceID: dbResourceID})
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return links, nil
+}
+
+// LinkField 手动关联字段与术语（字段须存在于已入库的字典中，已有关联时替换；重新入库时保留）
+func (s *GlossaryService) LinkField(
+	ctx context.Context,
+	dbResourceID, tableNameEN, fieldNameEN, termID string,
+) (_ *model.TermLink, err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryService.LinkField")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := findDBResource(ctx, s.dbResRepo, dbResourceID); err != nil {
+		return nil, err
+	}
+	term, err := s.getTerm(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.fieldRepo.GetByName(ctx, dbResourceID, tableNameEN, fieldNameEN); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrFieldNotFound.WithDetails(map[string]string{
+				"table_name_en": tableNameEN,
+				"field_name_en": fieldNameEN,
+			})
+		}
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+
+	link := model.NewTermLink(term.ID, dbResourceID, tableNameEN, fieldNameEN, model.TermLinkManual, auth.Actor(ctx))
+	if err := s.glossaryRepo.LinkField(ctx, link); err != nil {
+		return nil, errno.ErrDBInsertFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionTermLink, "", dbResourceID, map[string]string{
+		"term_id":       term.ID,
+		"term_name":     term.Name,
+		"table_name_en": tableNameEN,
+		"field_name_en": fieldNameEN,
+	})
+	return link, nil
+}
+
+// UnlinkField 取消字段的术语关联
+func (s *GlossaryService) UnlinkField(ctx context.Context, dbResourceID, tableNameEN, fieldNameEN string) (err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryService.UnlinkField")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := findDBResource(ctx, s.dbResRepo, dbResourceID); err != nil {
+		return err
+	}
+	n, err := s.glossaryRepo.UnlinkField(ctx, dbResourceID, tableNameEN, fieldNameEN)
+	if err != nil {
+		return errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	if n == 0 {
+		return errno.ErrTermLinkNotFound
+	}
+	s.auditSvc.Record(ctx, model.AuditActionTermUnlink, "", dbResourceID, map[string]string{
+		"table_name_en": tableNameEN,
+		"field_name_en": fieldNameEN,
+	})
+	return nil
+}
+
+// NewTermMatcher 加载全部术语用于解析时按字段中文名自动匹配
+func (s *GlossaryService) NewTermMatcher(ctx context.Context) (*TermMatcher, error) {
+	terms, err := s.glossaryRepo.AllTerms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := &TermMatcher{terms: make(map[string]string, len(terms))}
+	for _, t := range terms {
+		m.terms[termKey(t.Name)] = t.ID
+	}
+	return m, nil
+}
+
+// ReplaceAutoLinks 入库后按解析时的匹配结果替换资源的自动关联（手动关联保留）
+func (s *GlossaryService) ReplaceAutoLinks(ctx context.Context, dbResourceID string, links []*model.TermLink) (err error) {
+	ctx, span := tracing.Start(ctx, "GlossaryService.ReplaceAutoLinks")
+	defer func() { tracing.End(span, err) }()
+
+	return s.glossaryRepo.ReplaceAutoLinks(ctx, dbResourceID, links)
+}
+
+// TermMatcher 按字段中文名匹配术语（忽略空白）
+type TermMatcher struct {
+	terms map[string]string // 规范化名称到术语ID
+}
+
+// Match 返回与字段中文名一致的术语ID
+func (m *TermMatcher) Match(fieldNameCN string) (string, bool) {
+	id, ok := m.terms[termKey(fieldNameCN)]
+	return id, ok
+}
+
+// getTerm 查询术语（不存在时返回ErrTermNotFound）
+func (s *GlossaryService) getTerm(ctx context.Context, termID string) (*model.GlossaryTerm, error) {
+	term, err := s.glossaryRepo.GetTerm(ctx, termID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrTermNotFound
+		}
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return term, nil
+}
+
+// checkTermName 术语名称不能为空且不能与其他术语重名
+func (s *GlossaryService) checkTermName(ctx context.Context, name, selfID string) error {
+	if name == "" {
+		return errno.ErrInvalidParam.WithMessage("术语名称不能为空")
+	}
+	existing, err := s.glossaryRepo.GetTermByName(ctx, name)
+	if err == nil && existing.ID != selfID {
+		return errno.ErrTermExists.WithDetails(map[string]string{"term_id": existing.ID})
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return nil
+}
+
+// normalizeTermInput 去除首尾空白
+func normalizeTermInput(in TermInput) TermInput {
+	return TermInput{
+		Name:         strings.TrimSpace(in.Name),
+		Abbreviation: strings.TrimSpace(in.Abbreviation),
+		Definition:   strings.TrimSpace(in.Definition),
+	}
+}
+
+// termKey 匹配用的规范化名称（去除全部空白，含全角空格）
+func termKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, name)
+}
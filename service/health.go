@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"customs/infrastructure/db"
+	"customs/infrastructure/minio"
+	"customs/infrastructure/redis"
+	"customs/task"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 健康状态常量
+const (
+	HealthStatusUp   = "UP"   // 依赖可用
+	HealthStatusDown = "DOWN" // 依赖不可用
+)
+
+// HealthCheck 单个依赖的探活定义
+type HealthCheck struct {
+	Name  string                          // 组件名称（如mysql/redis）
+	Check func(ctx context.Context) error // 探活函数（返回nil表示可用）
+}
+
+// ComponentHealth 单个组件的检查结果（失败原因只记录日志，不在探针响应中暴露）
+type ComponentHealth struct {
+	Status    string `json:"status"`     // UP/DOWN
+	LatencyMs int64  `json:"latency_ms"` // 检查耗时（毫秒）
+}
+
+// HealthReport 整体健康报告
+type HealthReport struct {
+	Status     string                     `json:"status"`     // 全部组件UP时为UP
+	Components map[string]ComponentHealth `json:"components"` // 各组件检查结果
+}
+
+// HealthService 健康检查服务（并发检查各依赖，每项独立超时）
+type HealthService struct {
+	logger  *slog.Logger
+	timeout time.Duration
+	checks  []HealthCheck
+	running []atomic.Bool // 各项检查是否仍在执行（上一次超时未返回时不再重复发起）
+}
+
+// NewHealthService 初始化健康检查服务
+func NewHealthService(logger *slog.Logger, timeout time.Duration, checks ...HealthCheck) *HealthService {
+	return &HealthService{
+		logger:  logger,
+		timeout: timeout,
+		checks:  checks,
+		running: make([]atomic.Bool, len(checks)),
+	}
+}
+
+// Readiness 检查所有依赖，返回各组件状态与耗时
+func (s *HealthService) Readiness(ctx context.Context) *HealthReport {
+	report := &HealthReport{
+		Status:     HealthStatusUp,
+		Components: make(map[string]ComponentHealth, len(s.checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func(check HealthCheck, running *atomic.Bool) {
+			defer wg.Done()
+			result := s.run(ctx, check, running)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[check.Name] = result
+			if result.Status != HealthStatusUp {
+				report.Status = HealthStatusDown
+			}
+		}(check, &s.running[i])
+	}
+	wg.Wait()
+
+	return report
+}
+
+// run 执行单个检查（超时后直接判定失败，不等待探活函数返回；上一次检查仍未返回时直接判定失败）
+func (s *HealthService) run(ctx context.Context, check HealthCheck, running *atomic.Bool) ComponentHealth {
+	start := time.Now()
+	var err error
+	if running.CompareAndSwap(false, true) {
+		// 探活函数受探针上下文约束：超时或探针请求结束时检查随之结束
+		checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+		done := make(chan error, 1)
+		go func() {
+			defer running.Store(false)
+			done <- check.Check(checkCtx)
+		}()
+		select {
+		case err = <-done:
+		case <-checkCtx.Done():
+			err = checkCtx.Err()
+		}
+	} else {
+		err = errors.New("上一次检查尚未返回")
+	}
+
+	result := ComponentHealth{
+		Status:    HealthStatusUp,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = HealthStatusDown
+		s.logger.WarnContext(ctx, "依赖健康检查失败", slog.String("component", check.Name), slog.Any("error", err))
+	}
+	return result
+}
+
+// MySQLHealthCheck MySQL探活（Ping）
+func MySQLHealthCheck(mysqlClient *db.MySQLClient) HealthCheck {
+	return HealthCheck{
+		Name:  "mysql",
+		Check: mysqlClient.Ping,
+	}
+}
+
+// RedisHealthCheck Redis探活（PING）
+func RedisHealthCheck(redisClient *redis.Client) HealthCheck {
+	return HealthCheck{
+		Name:  "redis",
+		Check: redisClient.Ping,
+	}
+}
+
+// MinioHealthCheck MinIO探活（检查业务桶是否存在）
+func MinioHealthCheck(minioClient *minio.Client, buckets ...string) HealthCheck {
+	return HealthCheck{
+		Name: "minio",
+		Check: func(ctx context.Context) error {
+			for _, bucket := range buckets {
+				exists, err := minioClient.BucketExists(ctx, bucket)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return fmt.Errorf("桶%s不存在", bucket)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// QueueHealthCheck Asynq队列探活（队列可访问）
+func QueueHealthCheck(taskInspector *task.Inspector, queues ...string) HealthCheck {
+	return HealthCheck{
+		Name: "queue",
+		Check: func(ctx context.Context) error {
+			return taskInspector.CheckQueues(ctx, queues...)
+		},
+	}
+}
+
+// WorkerHealthCheck Worker心跳探活（至少有一个Worker在线）
+func WorkerHealthCheck(taskInspector *task.Inspector) HealthCheck {
+	return HealthCheck{
+		Name: "worker",
+		Check: func(ctx context.Context) error {
+			active, err := taskInspector.ActiveServers(ctx)
+			if err != nil {
+				return err
+			}
+			if active == 0 {
+				return errors.New("没有在线的Worker")
+			}
+			return nil
+		},
+	}
+}
+
+// WorkerSelfHealthCheck 当前Worker进程的心跳探活（供Worker自身的就绪探针使用）
+func WorkerSelfHealthCheck(taskInspector *task.Inspector) HealthCheck {
+	return HealthCheck{
+		Name: "worker",
+		Check: func(ctx context.Context) error {
+			host, err := os.Hostname()
+			if err != nil {
+				return err
+			}
+			alive, err := taskInspector.HasServer(ctx, host, os.Getpid())
+			if err != nil {
+				return err
+			}
+			if !alive {
+				return errors.New("当前Worker未上报心跳")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"customs/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// 命名规范检查方式
+const (
+	NamingCheckSnakeCase = "snake_case" // 小写字母、数字与下划线，字母开头
+	NamingCheckPrefix    = "prefix"     // 以允许的前缀之一开头
+	NamingCheckNoLatin   = "no_latin"   // 不含英文字母
+	NamingCheckRequired  = "required"   // 不能为空
+	NamingCheckPattern   = "pattern"    // 匹配正则表达式
+)
+
+// 违规级别：error级违规存在时可阻止确认入库
+const (
+	NamingLevelWarning = "warning"
+	NamingLevelError   = "error"
+)
+
+// snakeCasePattern 小写下划线命名
+var snakeCasePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// NamingRule 单条命名规范
+type NamingRule struct {
+	Name     string   `json:"name"`               // 规则名（出现在违规明细中）
+	Column   string   `json:"column"`             // 检查的列（模板列名，如"数据表名称（英文）"）
+	Check    string   `json:"check"`              // 检查方式：snake_case/prefix/no_latin/required/pattern
+	Level    string   `json:"level"`              // 违规级别：warning/error
+	Prefixes []string `json:"prefixes,omitempty"` // check=prefix时允许的前缀
+	Pattern  string   `json:"pattern,omitempty"`  // check=pattern时须匹配的正则
+	When     string   `json:"when,omitempty"`     // 仅检查英文字段名匹配该正则的行（如关键字段"^id$|_id$"）
+	Message  string   `json:"message,omitempty"`  // 违规提示（为空时按检查方式生成）
+
+	pattern *regexp.Regexp
+	when    *regexp.Regexp
+}
+
+// NamingRules 命名规范规则集（解析Excel时逐行检查）
+type NamingRules struct {
+	Rules []NamingRule `json:"rules"`
+}
+
+// NamingViolation 单条违规
+type NamingViolation struct {
+	Sheet   string `json:"sheet"`   // sheet名
+	Row     int    `json:"row"`     // Excel行号（表头为第1行）
+	Column  string `json:"column"`  // 列名
+	Value   string `json:"value"`   // 单元格内容
+	Rule    string `json:"rule"`    // 规则名
+	Level   string `json:"level"`   // 违规级别
+	Message string `json:"message"` // 违规提示
+}
+
+// NamingReport 任务的命名规范检查结果
+type NamingReport struct {
+	Errors     int               `json:"errors"`     // error级违规数
+	Warnings   int               `json:"warnings"`   // warning级违规数
+	Blocking   bool              `json:"blocking"`   // 是否因error级违规禁止确认入库
+	Violations []NamingViolation `json:"violations"` // 违规明细（按sheet与行号顺序）
+}
+
+// NamingRow 待检查的字典行
+type NamingRow struct {
+	Sheet  string            // sheet名
+	Row    int               // Excel行号
+	Values map[string]string // 模板列名到单元格内容
+}
+
+// DefaultNamingRules 未配置规则文件时使用的规则（允许的表名前缀因系统而异，需通过规则文件配置）
+func DefaultNamingRules() *NamingRules {
+	rules := &NamingRules{Rules: []NamingRule{
+		{Name: "table_name_snake_case", Column: model.ColumnTableNameEN, Check: NamingCheckSnakeCase, Level: NamingLevelError},
+		{Name: "field_name_snake_case", Column: model.ColumnFieldNameEN, Check: NamingCheckSnakeCase, Level: NamingLevelError},
+		{Name: "table_name_cn_no_latin", Column: model.ColumnTableNameCN, Check: NamingCheckNoLatin, Level: NamingLevelWarning},
+		{Name: "field_name_cn_no_latin", Column: model.ColumnFieldNameCN, Check: NamingCheckNoLatin, Level: NamingLevelWarning},
+		{Name: "key_field_desc_required", Column: model.ColumnFieldDesc, Check: NamingCheckRequired, Level: NamingLevelWarning, When: `^id$|_id$`},
+	}}
+	_ = rules.compile()
+	return rules
+}
+
+// LoadNamingRules 从JSON文件加载规则集（path为空时使用默认规则）
+func LoadNamingRules(path string) (*NamingRules, error) {
+	if path == "" {
+		return DefaultNamingRules(), nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取命名规范文件失败: %w", err)
+	}
+	var rules NamingRules
+	if err := json.Unmarshal(content, &rules); err != nil {
+		return nil, fmt.Errorf("解析命名规范文件失败: %w", err)
+	}
+	if err := rules.compile(); err != nil {
+		return nil, fmt.Errorf("命名规范文件%s无效: %w", path, err)
+	}
+	return &rules, nil
+}
+
+// compile 校验规则并编译正则
+func (r *NamingRules) compile() error {
+	for i := range r.Rules {
+		rule := &r.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule_%d", i+1)
+		}
+		if !slices.Contains(model.TemplateColumnNames, rule.Column) {
+			return fmt.Errorf("规则%s的列不是模板列：%s", rule.Name, rule.Column)
+		}
+		if rule.Level != NamingLevelWarning && rule.Level != NamingLevelError {
+			return fmt.Errorf("规则%s的级别须为warning或error：%s", rule.Name, rule.Level)
+		}
+		switch rule.Check {
+		case NamingCheckSnakeCase, NamingCheckNoLatin, NamingCheckRequired:
+		case NamingCheckPrefix:
+			if len(rule.Prefixes) == 0 {
+				return fmt.Errorf("规则%s未配置允许的前缀", rule.Name)
+			}
+		case NamingCheckPattern:
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return fmt.Errorf("规则%s的正则无效: %w", rule.Name, err)
+			}
+			rule.pattern = pattern
+		default:
+			return fmt.Errorf("规则%s的检查方式不支持：%s", rule.Name, rule.Check)
+		}
+		if rule.When != "" {
+			when, err := regexp.Compile(rule.When)
+			if err != nil {
+				return fmt.Errorf("规则%s的when正则无效: %w", rule.Name, err)
+			}
+			rule.when = when
+		}
+	}
+	return nil
+}
+
+// Check 逐行检查字典内容；表名列的违规每张表只报告一次
+func (r *NamingRules) Check(rows []NamingRow) []NamingViolation {
+	var violations []NamingViolation
+	reported := make(map[string]bool)
+	for _, row := range rows {
+		for _, rule := range r.Rules {
+			if rule.when != nil && !rule.when.MatchString(row.Values[model.ColumnFieldNameEN]) {
+				continue
+			}
+			value := row.Values[rule.Column]
+			if rule.passes(value) {
+				continue
+			}
+			if rule.Column == model.ColumnTableNameEN || rule.Column == model.ColumnTableNameCN {
+				key := rule.Name + "\x00" + row.Values[model.ColumnTableNameEN] + "\x00" + value
+				if reported[key] {
+					continue
+				}
+				reported[key] = true
+			}
+			violations = append(violations, NamingViolation{
+				Sheet:   row.Sheet,
+				Row:     row.Row,
+				Column:  rule.Column,
+				Value:   value,
+				Rule:    rule.Name,
+				Level:   rule.Level,
+				Message: rule.message(),
+			})
+		}
+	}
+	return violations
+}
+
+// passes 单元格是否符合规则（除required外，空值不检查）
+func (rule *NamingRule) passes(value string) bool {
+	if rule.Check == NamingCheckRequired {
+		return strings.TrimSpace(value) != ""
+	}
+	if value == "" {
+		return true
+	}
+	switch rule.Check {
+	case NamingCheckSnakeCase:
+		return snakeCasePattern.MatchString(value)
+	case NamingCheckPrefix:
+		for _, prefix := range rule.Prefixes {
+			if strings.HasPrefix(value, prefix) {
+				return true
+			}
+		}
+		return false
+	case NamingCheckNoLatin:
+		return strings.IndexFunc(value, func(r rune) bool { return r < unicode.MaxASCII && unicode.IsLetter(r) }) < 0
+	case NamingCheckPattern:
+		return rule.pattern.MatchString(value)
+	}
+	return true
+}
+
+// message 违规提示
+func (rule *NamingRule) message() string {
+	if rule.Message != "" {
+		return rule.Message
+	}
+	switch rule.Check {
+	case NamingCheckSnakeCase:
+		return "须为小写字母、数字与下划线组成的蛇形命名，且以字母开头"
+	case NamingCheckPrefix:
+		return "须以以下前缀之一开头：" + strings.Join(rule.Prefixes, "、")
+	case NamingCheckNoLatin:
+		return "不能包含英文字母"
+	case NamingCheckRequired:
+		return "不能为空"
+	default:
+		return "须匹配：" + rule.Pattern
+	}
+}
+
+// CountNamingViolations 按级别统计违规数
+func CountNamingViolations(violations []NamingViolation) (errors, warnings int) {
+	for _, v := range violations {
+		if v.Level == NamingLevelError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	return errors, warnings
+}
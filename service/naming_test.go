@@ -0,0 +1,114 @@
+package service
+
+import (
+	"customs/model"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultNamingRulesCheck(t *testing.T) {
+	row := func(line int, tableEN, tableCN, fieldEN, fieldCN, desc string) NamingRow {
+		return NamingRow{Sheet: "数据字典", Row: line, Values: map[string]string{
+			model.ColumnTableNameEN: tableEN,
+			model.ColumnTableNameCN: tableCN,
+			model.ColumnFieldNameEN: fieldEN,
+			model.ColumnFieldNameCN: fieldCN,
+			model.ColumnFieldDesc:   desc,
+		}}
+	}
+	violations := DefaultNamingRules().Check([]NamingRow{
+		row(2, "EntryHead", "报关单表头", "entry_id", "报关单号", "18位报关单编号"),
+		row(3, "EntryHead", "报关单表头", "declDate", "申报date", "格式YYYYMMDD"),
+		row(4, "entry_list", "报关单表体", "g_no", "商品序号", ""),
+		row(5, "entry_list", "报关单表体", "head_id", "表头ID", ""),
+	})
+
+	got := make(map[string]int)
+	for _, v := range violations {
+		got[v.Rule]++
+	}
+	want := map[string]int{
+		"table_name_snake_case":   1, // 同一张表只报告一次
+		"field_name_snake_case":   1,
+		"field_name_cn_no_latin":  2, // 申报date、表头ID
+		"key_field_desc_required": 1, // 只检查关键字段（head_id），g_no不检查
+	}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %+v", violations)
+	}
+	for rule, n := range want {
+		if got[rule] != n {
+			t.Errorf("规则%s违规数 = %d, want %d", rule, got[rule], n)
+		}
+	}
+	errs, warnings := CountNamingViolations(violations)
+	if errs != 2 || warnings != 3 {
+		t.Errorf("CountNamingViolations() = %d, %d", errs, warnings)
+	}
+}
+
+func TestNamingRulePasses(t *testing.T) {
+	prefix := NamingRule{Check: NamingCheckPrefix, Prefixes: []string{"t_", "v_"}}
+	required := NamingRule{Check: NamingCheckRequired}
+	tests := []struct {
+		name  string
+		rule  NamingRule
+		value string
+		want  bool
+	}{
+		{"前缀匹配", prefix, "t_entry", true},
+		{"前缀不匹配", prefix, "entry", false},
+		{"空值不检查", prefix, "", true},
+		{"必填为空白", required, "  ", false},
+		{"必填有值", required, "说明", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.passes(tt.value); got != tt.want {
+				t.Errorf("passes(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadNamingRules(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "rules.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("正则规则", func(t *testing.T) {
+		path := write(t, `{"rules":[{"column":"数据表名称（英文）","check":"pattern","pattern":"^t_","level":"error"}]}`)
+		rules, err := LoadNamingRules(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rules.Rules[0].Name != "rule_1" {
+			t.Errorf("未命名规则的默认名称 = %s", rules.Rules[0].Name)
+		}
+		violations := rules.Check([]NamingRow{{Row: 2, Values: map[string]string{model.ColumnTableNameEN: "entry"}}})
+		if len(violations) != 1 || violations[0].Message != "须匹配：^t_" {
+			t.Errorf("violations = %+v", violations)
+		}
+	})
+
+	invalid := map[string]string{
+		"非模板列":     `{"rules":[{"column":"表名","check":"required","level":"error"}]}`,
+		"级别无效":     `{"rules":[{"column":"数据表名称（英文）","check":"required","level":"fatal"}]}`,
+		"前缀为空":     `{"rules":[{"column":"数据表名称（英文）","check":"prefix","level":"error"}]}`,
+		"正则无效":     `{"rules":[{"column":"数据表名称（英文）","check":"pattern","pattern":"(","level":"error"}]}`,
+		"检查方式不支持":  `{"rules":[{"column":"数据表名称（英文）","check":"camel_case","level":"error"}]}`,
+		"JSON格式错误": `{"rules":`,
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadNamingRules(write(t, content)); err == nil {
+				t.Error("应返回错误")
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"customs/common/auth"
+	"customs/common/errno"
+	"customs/config"
+	"customs/infrastructure/introspect"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"customs/task"
+	"errors"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+)
+
+// DriftService 数据字典与数据库实际表结构的差异检查服务
+type DriftService struct {
+	cfg        *config.Config
+	logger     *slog.Logger
+	taskClient *task.Client
+	dbResRepo  *repository.DBResourceRepository
+	fieldRepo  *repository.DictionaryFieldRepository
+	driftRepo  *repository.DriftReportRepository
+	auditSvc   *AuditService
+}
+
+// NewDriftService 初始化差异检查服务
+func NewDriftService(
+	cfg *config.Config,
+	logger *slog.Logger,
+	taskClient *task.Client,
+	dbResRepo *repository.DBResourceRepository,
+	fieldRepo *repository.DictionaryFieldRepository,
+	driftRepo *repository.DriftReportRepository,
+	auditSvc *AuditService,
+) *DriftService {
+	return &DriftService{
+		cfg:        cfg,
+		logger:     logger,
+		taskClient: taskClient,
+		dbResRepo:  dbResRepo,
+		fieldRepo:  fieldRepo,
+		driftRepo:  driftRepo,
+		auditSvc:   auditSvc,
+	}
+}
+
+// TriggerCheck 手动触发一次差异检查（异步执行，结果通过报告查询）
+func (s *DriftService) TriggerCheck(ctx context.Context, dbResourceID string) (err error) {
+	ctx, span := tracing.Start(ctx, "DriftService.TriggerCheck")
+	defer func() { tracing.End(span, err) }()
+
+	resource, err := findDBResource(ctx, s.dbResRepo, dbResourceID)
+	if err != nil {
+		return err
+	}
+	if resource.ConnectionDSN == "" {
+		return errno.ErrConnectionMissing
+	}
+	info, err := s.taskClient.DriftCheckTask(ctx, resource.ID, model.DriftTriggerManual, auth.Actor(ctx))
+	if err != nil {
+		return errno.ErrTaskCreateFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionDriftCheck, "", resource.ID, map[string]string{"queue_task_id": info.ID})
+	return nil
+}
+
+// EnqueueAll 为每个已配置内省连接的资源分别创建检查任务（定时检查入口，runID为定时任务的ID），返回新创建的任务数
+// 部分资源入队失败时返回错误由定时任务重试，同一runID下已入队的资源不会重复创建
+func (s *DriftService) EnqueueAll(ctx context.Context, runID string) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "DriftService.EnqueueAll")
+	defer func() { tracing.End(span, err) }()
+
+	resources, err := s.dbResRepo.ListWithConnection(ctx)
+	if err != nil {
+		return 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	enqueued := 0
+	for _, resource := range resources {
+		ok, err := s.taskClient.ScheduledDriftCheckTask(ctx, resource.ID, runID)
+		if err != nil {
+			return enqueued, errno.ErrTaskCreateFailed.WithCause(err)
+		}
+		if ok {
+			enqueued++
+		}
+	}
+	return enqueued, nil
+}
+
+// Check 读取数据库表结构并与已入库的数据字典比对，保存差异报告
+// 连接或读取数据库失败时保存FAILED报告（不返回错误，避免任务反复重试）
+func (s *DriftService) Check(ctx context.Context, dbResourceID, trigger, operator string) (_ *model.DriftReport, err error) {
+	ctx, span := tracing.Start(ctx, "DriftService.Check")
+	defer func() { tracing.End(span, err) }()
+
+	resource, err := findDBResource(ctx, s.dbResRepo, dbResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if resource.ConnectionDSN == "" {
+		return nil, errno.ErrConnectionMissing
+	}
+	fields, err := s.fieldRepo.List(ctx, repository.DictionaryFieldFilter{DBResourceID: resource.ID})
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+
+	var report *model.DriftReport
+	if columns, err := readColumns(ctx, s.logger, s.cfg.Introspect, resource); err != nil {
+		report = model.NewFailedDriftReport(resource.ID, trigger, operator, err)
+	} else {
+		report = model.NewDriftReport(resource.ID, trigger, operator, compareSchema(fields, columns))
+	}
+	if err := s.driftRepo.Create(ctx, report); err != nil {
+		return nil, errno.ErrDBInsertFailed.WithCause(err)
+	}
+	s.logger.InfoContext(ctx, "表结构差异检查完成",
+		slog.String("db_resource_id", resource.ID),
+		slog.String("status", report.Status),
+		slog.Int("undocumented", report.UndocumentedCount),
+		slog.Int("missing", report.MissingCount),
+		slog.Int("mismatch", report.MismatchCount),
+	)
+	return report, nil
+}
+
+// LatestReport 查询资源最近一次的差异报告（含差异明细）
+func (s *DriftService) LatestReport(ctx context.Context, dbResourceID string) (_ *model.DriftReport, err error) {
+	ctx, span := tracing.Start(ctx, "DriftService.LatestReport")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := findDBResource(ctx, s.dbResRepo, dbResourceID); err != nil {
+		return nil, err
+	}
+	report, err := s.driftRepo.Latest(ctx, dbResourceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrDriftReportNotFound
+		}
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return report, nil
+}
+
+// ListReports 分页查询差异报告（不含差异明细）
+func (s *DriftService) ListReports(
+	ctx context.Context,
+	filter repository.DriftReportFilter,
+	page, size int,
+) (_ []model.DriftReport, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DriftService.ListReports")
+	defer func() { tracing.End(span, err) }()
+
+	reports, total, err := s.driftRepo.List(ctx, filter, page, size)
+	if err != nil {
+		return nil, 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return reports, total, nil
+}
+
+// schemaTable 数据库中的一张表（字段按数据库中的顺序）
+type schemaTable struct {
+	name    string
+	comment string
+	columns []introspect.Column
+}
+
+// compareSchema 比对数据字典与数据库表结构（表名、字段名不区分大小写）
+// 未入字典的表只报告表本身；数据库注释为空时不视为不一致
+func compareSchema(fields []model.DictionaryField, columns []introspect.Column) []model.DriftItem {
+	var (
+		items    []model.DriftItem
+		dbTables []*schemaTable
+		dbIndex  = make(map[string]*schemaTable)
+	)
+	for _, c := range columns {
+		key := strings.ToLower(c.Table)
+		t, ok := dbIndex[key]
+		if !ok {
+			t = &schemaTable{name: c.Table, comment: c.TableComment}
+			dbIndex[key] = t
+			dbTables = append(dbTables, t)
+		}
+		t.columns = append(t.columns, c)
+	}
+
+	dictTables := groupTables(fields)
+	dictIndex := make(map[string]DictionaryTable, len(dictTables))
+	for _, t := range dictTables {
+		dictIndex[strings.ToLower(t.NameEN)] = t
+	}
+
+	for _, dbTable := range dbTables {
+		dictTable, ok := dictIndex[strings.ToLower(dbTable.name)]
+		if !ok {
+			items = append(items, model.DriftItem{
+				Kind:     model.DriftKindUndocumentedTable,
+				Table:    dbTable.name,
+				Database: dbTable.comment,
+			})
+			continue
+		}
+		if comment := strings.TrimSpace(dbTable.comment); comment != "" && comment != strings.TrimSpace(dictTable.NameCN) {
+			items = append(items, model.DriftItem{
+				Kind:       model.DriftKindTableComment,
+				Table:      dictTable.NameEN,
+				Dictionary: dictTable.NameCN,
+				Database:   dbTable.comment,
+			})
+		}
+
+		dictFields := make(map[string]model.DictionaryField, len(dictTable.Fields))
+		for _, f := range dictTable.Fields {
+			dictFields[strings.ToLower(f.FieldNameEN)] = f
+		}
+		dbColumns := make(map[string]bool, len(dbTable.columns))
+		for _, c := range dbTable.columns {
+			key := strings.ToLower(c.Name)
+			dbColumns[key] = true
+			f, ok := dictFields[key]
+			if !ok {
+				items = append(items, model.DriftItem{
+					Kind:     model.DriftKindUndocumentedColumn,
+					Table:    dictTable.NameEN,
+					Column:   c.Name,
+					Database: c.Comment,
+				})
+				continue
+			}
+			if !commentMatches(c.Comment, f) {
+				items = append(items, model.DriftItem{
+					Kind:       model.DriftKindColumnComment,
+					Table:      dictTable.NameEN,
+					Column:     f.FieldNameEN,
+					Dictionary: fieldComment(f),
+					Database:   c.Comment,
+				})
+			}
+		}
+		for _, f := range dictTable.Fields {
+			if !dbColumns[strings.ToLower(f.FieldNameEN)] {
+				items = append(items, model.DriftItem{
+					Kind:       model.DriftKindMissingColumn,
+					Table:      dictTable.NameEN,
+					Column:     f.FieldNameEN,
+					Dictionary: fieldComment(f),
+				})
+			}
+		}
+	}
+
+	for _, t := range dictTables {
+		if _, ok := dbIndex[strings.ToLower(t.NameEN)]; !ok {
+			items = append(items, model.DriftItem{
+				Kind:       model.DriftKindMissingTable,
+				Table:      t.NameEN,
+				Dictionary: t.NameCN,
+			})
+		}
+	}
+	return items
+}
+
+// commentMatches 字段注释为空，或与字典中文名称、"中文名称：说明"之一相同时视为一致
+func commentMatches(comment string, f model.DictionaryField) bool {
+	comment = strings.TrimSpace(comment)
+	return comment == "" ||
+		comment == strings.TrimSpace(f.FieldNameCN) ||
+		comment == strings.TrimSpace(fieldComment(f))
+}
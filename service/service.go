@@ -1,20 +1,33 @@
 package service
 
 import (
+	"customs/config"
 	"customs/infrastructure/db"
 	"customs/infrastructure/minio"
 	"customs/infrastructure/redis"
 	"customs/repository"
 	"customs/task"
+	"log/slog"
 )
 
 // ServiceContainer 封装所有Service实例
 type ServiceContainer struct {
 	DataDictionary *DataDictionaryService // 核心：数据字典业务服务
+	Health         *HealthService         // 健康检查服务
+	User           *UserService           // 本地用户服务
+	Audit          *AuditService          // 审计日志服务
+	Search         *SearchService         // 数据字典全文检索服务
+	Export         *ExportService         // 数据字典导出服务
+	Drift          *DriftService          // 表结构差异检查服务
+	Glossary       *GlossaryService       // 业务术语服务
+	Relation       *RelationService       // 字段关联关系服务
+	ColumnMapping  *ColumnMappingService  // 列映射方案服务
 }
 
 // NewServiceContainer 初始化所有Service
 func NewServiceContainer(
+	cfg *config.Config,
+	logger *slog.Logger,
 	mysqlClient *db.MySQLClient,
 	minioClient *minio.Client,
 	redisClient *redis.Client,
@@ -22,14 +35,54 @@ func NewServiceContainer(
 	taskInspector *task.Inspector,
 	repoContainer *repository.RepositoryContainer,
 ) *ServiceContainer {
+	auditSvc := NewAuditService(logger, repoContainer.AuditLog)
 	return &ServiceContainer{
 		DataDictionary: NewDataDictionaryService(
+			cfg,
+			logger,
 			minioClient,
 			redisClient,
 			taskClient,
 			taskInspector,
 			repoContainer.Dictionary,
+			repoContainer.Batch,
+			repoContainer.Upload,
 			repoContainer.DBResource,
+			repoContainer.Mapping,
+			auditSvc,
+		),
+		User:   NewUserService(logger, repoContainer.User, auditSvc),
+		Audit:  auditSvc,
+		Search: NewSearchService(logger, repoContainer.Field),
+		Export: NewExportService(
+			cfg,
+			logger,
+			minioClient,
+			repoContainer.DBResource,
+			repoContainer.Field,
+			repoContainer.Relation,
+			auditSvc,
+		),
+		Drift: NewDriftService(
+			cfg,
+			logger,
+			taskClient,
+			repoContainer.DBResource,
+			repoContainer.Field,
+			repoContainer.Drift,
+			auditSvc,
+		),
+		Glossary:      NewGlossaryService(logger, repoContainer.Glossary, repoContainer.Field, repoContainer.DBResource, auditSvc),
+		Relation:      NewRelationService(logger, repoContainer.DBResource, repoContainer.Relation),
+		ColumnMapping: NewColumnMappingService(logger, repoContainer.Mapping, auditSvc),
+		Health: NewHealthService(
+			logger,
+			cfg.Health.Timeout,
+			MySQLHealthCheck(mysqlClient),
+			RedisHealthCheck(redisClient),
+			MinioHealthCheck(minioClient, cfg.Minio.Buckets()...),
+			QueueHealthCheck(taskInspector, task.Queues...),
+			WorkerHealthCheck(taskInspector),
 		),
 	}
 }
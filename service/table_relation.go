@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"customs/common/errno"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"log/slog"
+)
+
+// RelationService 字段关联关系查询服务
+type RelationService struct {
+	logger       *slog.Logger
+	dbResRepo    *repository.DBResourceRepository
+	relationRepo *repository.TableRelationRepository
+}
+
+// NewRelationService 初始化关联关系服务
+func NewRelationService(
+	logger *slog.Logger,
+	dbResRepo *repository.DBResourceRepository,
+	relationRepo *repository.TableRelationRepository,
+) *RelationService {
+	return &RelationService{
+		logger:       logger,
+		dbResRepo:    dbResRepo,
+		relationRepo: relationRepo,
+	}
+}
+
+// TableRelations 表的关联关系
+type TableRelations struct {
+	DBResourceID string                `json:"db_resource_id"` // 数据库资源ID
+	DBName       string                `json:"db_name"`        // 数据库名
+	TableNameEN  string                `json:"table_name_en"`  // 数据表名称（英文）
+	Outbound     []model.TableRelation `json:"outbound"`       // 本表字段引用的表
+	Inbound      []model.TableRelation `json:"inbound"`        // 引用本表的字段（含其他系统，按系统名+库名+表名匹配）
+	// Ambiguous 未写明被引用系统名、且库名在多个资源中重名的入向关系（无法确定引用的是否为本表）
+	Ambiguous []model.TableRelation `json:"ambiguous"`
+}
+
+// GetTableRelations 查询表的出向与入向关联关系
+func (s *RelationService) GetTableRelations(ctx context.Context, dbResourceID, tableNameEN string) (_ *TableRelations, err error) {
+	ctx, span := tracing.Start(ctx, "RelationService.GetTableRelations")
+	defer func() { tracing.End(span, err) }()
+
+	resource, err := findDBResource(ctx, s.dbResRepo, dbResourceID)
+	if err != nil {
+		return nil, err
+	}
+	outbound, err := s.relationRepo.ListByResource(ctx, resource.ID, tableNameEN)
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	referencing, err := s.relationRepo.ListReferencing(ctx, resource.SystemName, resource.DBName, tableNameEN)
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	shared, err := s.dbResRepo.CountByDBName(ctx, resource.DBName)
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	if outbound == nil {
+		outbound = []model.TableRelation{}
+	}
+	inbound, ambiguous := []model.TableRelation{}, []model.TableRelation{}
+	for _, rel := range referencing {
+		if rel.RefSystemName == "" && shared > 1 {
+			ambiguous = append(ambiguous, rel)
+			continue
+		}
+		inbound = append(inbound, rel)
+	}
+	return &TableRelations{
+		DBResourceID: resource.ID,
+		DBName:       resource.DBName,
+		TableNameEN:  tableNameEN,
+		Outbound:     outbound,
+		Inbound:      inbound,
+		Ambiguous:    ambiguous,
+	}, nil
+}
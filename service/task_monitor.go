@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"customs/model"
+	"log/slog"
 	"time"
 )
 
@@ -13,6 +14,7 @@ func (s *DataDictionaryService) MonitorInsertTask(ctx context.Context, dictTaskI
 		// 步骤1：查询数据库任务记录
 		dictTask, err := s.dictRepo.GetByID(ctx, dictTaskID)
 		if err != nil {
+			s.logger.WarnContext(ctx, "任务记录不存在，停止监控入库任务", slog.Any("error", err))
 			break // 任务不存在，退出监控
 		}
 		if dictTask.InsertDFTaskID == "" {
@@ -23,16 +25,20 @@ func (s *DataDictionaryService) MonitorInsertTask(ctx context.Context, dictTaskI
 		// 步骤2：查询Asynq任务状态
 		taskStatus, err := s.taskInspector.GetTaskStatus(dictTask.InsertDFTaskID)
 		if err != nil {
+			s.logger.WarnContext(ctx, "查询入库任务状态失败，稍后重试", slog.Any("error", err))
 			time.Sleep(10 * time.Second)
 			continue
 		}
 
 		// 步骤3：更新任务状态
 		dictTask.UpdateInsertDFStatus(taskStatus)
-		s.dictRepo.Update(ctx, dictTask)
+		if err := s.dictRepo.UpdateInsertDFStatus(ctx, dictTask); err != nil {
+			s.logger.ErrorContext(ctx, "更新入库任务状态失败", slog.Any("error", err))
+		}
 
 		// 步骤4：任务完成（成功/失败），退出循环
 		if taskStatus == model.TaskStatusSucceeded || taskStatus == model.TaskStatusFailed {
+			s.logger.InfoContext(ctx, "入库任务已结束", slog.String("status", taskStatus))
 			break
 		}
 
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"customs/common/errno"
+	"customs/config"
+	"customs/infrastructure/minio"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"log/slog"
+	"time"
+)
+
+// uploadCleanupBatch 单次清理的会话数上限（其余会话在下次调度时清理）
+const uploadCleanupBatch = 500
+
+// UploadCleanupService 过期直传会话清理服务（由Worker定时执行）
+type UploadCleanupService struct {
+	cfg         *config.Config
+	logger      *slog.Logger
+	minioClient *minio.Client
+	uploadRepo  *repository.UploadSessionRepository
+}
+
+// NewUploadCleanupService 初始化清理服务
+func NewUploadCleanupService(
+	cfg *config.Config,
+	logger *slog.Logger,
+	minioClient *minio.Client,
+	uploadRepo *repository.UploadSessionRepository,
+) *UploadCleanupService {
+	return &UploadCleanupService{
+		cfg:         cfg,
+		logger:      logger,
+		minioClient: minioClient,
+		uploadRepo:  uploadRepo,
+	}
+}
+
+// CleanupExpired 取消过期会话的分片上传、删除暂存对象并将会话标记为EXPIRED，返回清理的会话数
+// 单个会话清理失败时保留上传中状态，下次调度时重试
+func (s *UploadCleanupService) CleanupExpired(ctx context.Context) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "UploadCleanupService.CleanupExpired")
+	defer func() { tracing.End(span, err) }()
+
+	sessions, err := s.uploadRepo.ListExpired(ctx, time.Now(), uploadCleanupBatch)
+	if err != nil {
+		return 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	bucket := s.cfg.Minio.ExcelBucket
+	cleaned := 0
+	for i := range sessions {
+		session := &sessions[i]
+		if session.Multipart() && !session.Merged {
+			err = s.minioClient.AbortMultipartUpload(ctx, bucket, session.ObjectName, session.UploadID)
+		} else {
+			err = s.minioClient.DeleteFile(ctx, bucket, session.ObjectName)
+		}
+		if err != nil && !minio.IsNotFound(err) {
+			s.logger.WarnContext(ctx, "清理过期上传会话失败", slog.String("upload_session_id", session.ID), slog.Any("error", err))
+			continue
+		}
+		session.Close(model.UploadSessionExpired)
+		if err := s.uploadRepo.Update(ctx, session); err != nil {
+			s.logger.WarnContext(ctx, "更新上传会话状态失败", slog.String("upload_session_id", session.ID), slog.Any("error", err))
+			continue
+		}
+		cleaned++
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"customs/common"
+	"customs/common/auth"
+	"customs/common/errno"
+	"customs/infrastructure/minio"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+)
+
+// maxUploadParts S3分片上传允许的最大分片数
+const maxUploadParts = 10000
+
+// UploadSessionDetail 直传会话详情（含已上传分片与待上传分片的预签名URL，可据此断点续传）
+type UploadSessionDetail struct {
+	Session       *model.UploadSession `json:"session"`
+	UploadedParts []minio.UploadedPart `json:"uploaded_parts"`           // 已上传的分片
+	PendingParts  []PresignedPart      `json:"pending_parts"`            // 待上传的分片（会话结束后为空）
+	URLExpiresAt  *time.Time           `json:"url_expires_at,omitempty"` // 预签名URL过期时间
+}
+
+// PresignedPart 待上传分片（浏览器按URL直接PUT对应字节区间）
+type PresignedPart struct {
+	PartNumber int    `json:"part_number"` // 分片号（从1开始）
+	Offset     int64  `json:"offset"`      // 在文件中的起始偏移
+	Size       int64  `json:"size"`        // 分片字节数
+	Method     string `json:"method"`      // 请求方法（PUT）
+	URL        string `json:"url"`         // 预签名URL
+}
+
+// CreateUploadSession 创建直传会话：文件名与大小预校验后签发预签名URL
+// 不超过一个分片的文件使用单次PUT，否则发起MinIO分片上传
+func (s *DataDictionaryService) CreateUploadSession(
+	ctx context.Context,
+	resourceComment, fileName, columnMapping string,
+	size int64,
+) (_ *UploadSessionDetail, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.CreateUploadSession",
+		attribute.String("excel.name", fileName), attribute.Int64("excel.size", size))
+	defer func() { tracing.End(span, err) }()
+
+	// 步骤1：参数校验（内容结构在完成上传后校验）
+	if resourceComment == "" || fileName == "" || size <= 0 {
+		return nil, errno.ErrInvalidParam
+	}
+	if !isExcelFile(fileName) {
+		return nil, errno.ErrInvalidFileFormat
+	}
+	// 文件名会拼入对象名，不能包含路径
+	if path.Base(fileName) != fileName || strings.ContainsAny(fileName, `/\`) || strings.Contains(fileName, "..") {
+		return nil, errno.ErrInvalidFileNameFormat
+	}
+	if _, _, ok := common.SplitExcelName(fileName); !ok {
+		return nil, errno.ErrInvalidFileNameFormat
+	}
+	if maxBytes := s.sessionMaxBytes(); maxBytes > 0 && size > maxBytes {
+		return nil, errno.ErrFileTooLarge.WithDetails(map[string]int64{"size": size, "max_bytes": maxBytes})
+	}
+	if columnMapping != "" {
+		if _, _, err := s.loadColumnMappings(ctx, columnMapping); err != nil {
+			return nil, err
+		}
+	}
+
+	// 步骤2：创建会话（分片上传需先向MinIO申请uploadID）
+	session := model.NewUploadSession(auth.Actor(ctx), resourceComment, fileName, size,
+		s.cfg.Upload.SessionPartBytes, s.cfg.Upload.SessionTTL)
+	session.ColumnMapping = columnMapping
+	if session.PartCount > maxUploadParts {
+		return nil, errno.ErrFileTooLarge.WithMessage("分片数超过上限，请调大分片大小")
+	}
+	if session.Multipart() {
+		uploadID, err := s.minioClient.NewMultipartUpload(ctx, s.cfg.Minio.ExcelBucket, session.ObjectName)
+		if err != nil {
+			return nil, errno.ErrMinioUploadFailed.WithCause(err)
+		}
+		session.UploadID = uploadID
+	}
+	if err := s.uploadRepo.Create(ctx, session); err != nil {
+		s.logger.ErrorContext(ctx, "创建上传会话失败", slog.Any("error", err))
+		return nil, errno.ErrDBInsertFailed.WithCause(err)
+	}
+	s.logger.InfoContext(ctx, "已创建上传会话", slog.String("upload_session_id", session.ID),
+		slog.String("file", fileName), slog.Int("parts", session.PartCount))
+
+	// 步骤3：签发全部分片的预签名URL
+	return s.uploadSessionDetail(ctx, session, nil)
+}
+
+// GetUploadSession 查询直传会话进度，为未上传的分片重新签发预签名URL（断点续传）
+func (s *DataDictionaryService) GetUploadSession(ctx context.Context, sessionID string) (_ *UploadSessionDetail, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.GetUploadSession", attribute.String("upload_session.id", sessionID))
+	defer func() { tracing.End(span, err) }()
+
+	session, err := s.getUploadSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Active(time.Now()) || session.Merged {
+		return &UploadSessionDetail{Session: session}, nil
+	}
+	uploaded, err := s.uploadedParts(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return s.uploadSessionDetail(ctx, session, uploaded)
+}
+
+// CompleteUploadSession 完成直传：合并分片、校验Excel后转存为正式对象并生产解析任务
+// 校验未通过时删除暂存对象并结束会话
+func (s *DataDictionaryService) CompleteUploadSession(ctx context.Context, sessionID string) (_ *model.DictionaryTask, err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.CompleteUploadSession", attribute.String("upload_session.id", sessionID))
+	defer func() { tracing.End(span, err) }()
+
+	// 步骤1：查询会话并校验状态
+	session, err := s.getUploadSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Active(time.Now()) {
+		return nil, errno.ErrUploadSessionClosed.WithDetails(map[string]interface{}{
+			"status":     session.Status,
+			"expires_at": session.ExpiresAt,
+		})
+	}
+	bucket := s.cfg.Minio.ExcelBucket
+
+	// 步骤2：校验分片齐全且大小与声明一致，分片上传需合并（合并后重试时跳过）
+	if session.Multipart() && !session.Merged {
+		if err := s.mergeParts(ctx, session); err != nil {
+			return nil, err
+		}
+	} else if _, err := s.minioClient.ObjectSize(ctx, bucket, session.ObjectName); minio.IsNotFound(err) {
+		return nil, errno.ErrUploadIncomplete.WithDetails(map[string]interface{}{"missing_parts": []int{1}})
+	} else if err != nil {
+		return nil, errno.ErrMinioDownloadFailed.WithCause(err)
+	}
+
+	// 步骤3：读取暂存对象校验Excel格式
+	reader, err := s.minioClient.DownloadFile(ctx, bucket, session.ObjectName)
+	if err != nil {
+		return nil, errno.ErrMinioDownloadFailed.WithCause(err)
+	}
+	content, err := io.ReadAll(io.LimitReader(reader, session.Size+1))
+	if err != nil {
+		return nil, errno.ErrMinioDownloadFailed.WithCause(err)
+	}
+	if int64(len(content)) != session.Size {
+		return nil, errno.ErrUploadSizeMismatch.WithDetails(map[string]int64{"size": session.Size, "uploaded_bytes": int64(len(content))})
+	}
+	selected, candidates, err := s.loadColumnMappings(ctx, session.ColumnMapping)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := judgeExcelFormat(session.FileName, content, selected, candidates)
+	if err != nil {
+		s.logger.InfoContext(ctx, "直传Excel格式校验未通过", slog.String("upload_session_id", sessionID), slog.Any("error", err))
+		s.auditSvc.Record(ctx, model.AuditActionValidationFailed, "", "", map[string]interface{}{
+			"excel_name":        session.FileName,
+			"resource_comment":  session.ResourceComment,
+			"upload_session_id": sessionID,
+			"error":             err.Error(),
+		})
+		s.removeStagedObject(ctx, session)
+		session.Close(model.UploadSessionFailed, err.Error())
+		if updateErr := s.uploadRepo.Update(ctx, session); updateErr != nil {
+			s.logger.ErrorContext(ctx, "更新上传会话状态失败", slog.Any("error", updateErr))
+		}
+		return nil, err
+	}
+
+	// 步骤4：转存为正式对象（按任务ID分目录，同名文件互不覆盖）并生产解析任务
+	dictTask := newDictTask(ctx, session.ResourceComment, "", mapping, session.FileName)
+	if err := s.minioClient.CopyObject(ctx, bucket, session.ObjectName, dictTask.ExcelObjectName()); err != nil {
+		return nil, errno.ErrMinioUploadFailed.WithCause(err)
+	}
+	dictTask, err = s.enqueueTask(ctx, dictTask, session.Size)
+	if err != nil {
+		return nil, err
+	}
+	s.removeStagedObject(ctx, session) // 任务创建成功后再删除，失败时可重试完成
+
+	// 步骤5：结束会话
+	session.DictTaskID = dictTask.ID
+	session.Close(model.UploadSessionCompleted)
+	if err := s.uploadRepo.Update(ctx, session); err != nil {
+		// 任务已创建，会话状态更新失败仅记录日志
+		s.logger.ErrorContext(ctx, "更新上传会话状态失败", slog.String("upload_session_id", sessionID), slog.Any("error", err))
+	}
+	return dictTask, nil
+}
+
+// sessionMaxBytes 直传会话允许的文件上限：完成上传时需将文件整体读入内存校验，因此不超过单个文件上限
+func (s *DataDictionaryService) sessionMaxBytes() int64 {
+	maxBytes, fileMax := s.cfg.Upload.SessionMaxBytes, s.cfg.Upload.MaxFileBytes
+	if fileMax > 0 && (maxBytes <= 0 || maxBytes > fileMax) {
+		return fileMax
+	}
+	return maxBytes
+}
+
+// mergeParts 校验分片齐全、各分片及总大小与声明一致后合并分片
+func (s *DataDictionaryService) mergeParts(ctx context.Context, session *model.UploadSession) error {
+	uploaded, err := s.uploadedParts(ctx, session)
+	if err != nil {
+		return err
+	}
+	var (
+		missing       []int
+		uploadedBytes int64
+	)
+	done := make(map[int]bool, len(uploaded))
+	for _, p := range uploaded {
+		// 每个分片须与会话划分的分片大小一致，防止单个分片超出预签名时声明的范围
+		if p.PartNumber < 1 || p.PartNumber > session.PartCount || p.Size != session.PartLength(p.PartNumber) {
+			return errno.ErrUploadSizeMismatch.WithDetails(map[string]int64{
+				"part_number": int64(p.PartNumber),
+				"size":        p.Size,
+				"expected":    session.PartLength(p.PartNumber),
+			})
+		}
+		done[p.PartNumber] = true
+		uploadedBytes += p.Size
+	}
+	for n := 1; n <= session.PartCount; n++ {
+		if !done[n] {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		return errno.ErrUploadIncomplete.WithDetails(map[string]interface{}{"missing_parts": missing})
+	}
+	if uploadedBytes != session.Size {
+		return errno.ErrUploadSizeMismatch.WithDetails(map[string]int64{"size": session.Size, "uploaded_bytes": uploadedBytes})
+	}
+
+	err = s.minioClient.CompleteMultipartUpload(ctx, s.cfg.Minio.ExcelBucket, session.ObjectName, session.UploadID, uploaded)
+	if err != nil {
+		return errno.ErrMinioUploadFailed.WithMessage("合并分片失败").WithCause(err)
+	}
+	// 合并后uploadID失效，记录合并状态以便后续步骤失败时可重试完成
+	session.Merged = true
+	if err := s.uploadRepo.Update(ctx, session); err != nil {
+		return errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	return nil
+}
+
+// AbortUploadSession 取消直传会话并清理已上传的分片
+func (s *DataDictionaryService) AbortUploadSession(ctx context.Context, sessionID string) (err error) {
+	ctx, span := tracing.Start(ctx, "DataDictionaryService.AbortUploadSession", attribute.String("upload_session.id", sessionID))
+	defer func() { tracing.End(span, err) }()
+
+	session, err := s.getUploadSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.Status != model.UploadSessionActive {
+		return errno.ErrUploadSessionClosed.WithDetails(map[string]string{"status": session.Status})
+	}
+
+	if session.Multipart() && !session.Merged {
+		if err := s.minioClient.AbortMultipartUpload(ctx, s.cfg.Minio.ExcelBucket, session.ObjectName, session.UploadID); err != nil {
+			s.logger.WarnContext(ctx, "取消分片上传失败", slog.String("upload_session_id", sessionID), slog.Any("error", err))
+		}
+	} else {
+		s.removeStagedObject(ctx, session)
+	}
+	session.Close(model.UploadSessionAborted)
+	if err := s.uploadRepo.Update(ctx, session); err != nil {
+		return errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	s.logger.InfoContext(ctx, "已取消上传会话", slog.String("upload_session_id", sessionID))
+	return nil
+}
+
+// getUploadSession 查询会话并校验归属（仅上传人或管理员）
+func (s *DataDictionaryService) getUploadSession(ctx context.Context, sessionID string) (*model.UploadSession, error) {
+	session, err := s.uploadRepo.GetByID(ctx, sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrUploadSessionNotFound.WithDetails(map[string]string{"upload_session_id": sessionID})
+	}
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	if err := s.checkOwner(ctx, session.Uploader); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// uploadedParts 查询已上传的分片（单次PUT上传时以对象是否存在判断）
+func (s *DataDictionaryService) uploadedParts(ctx context.Context, session *model.UploadSession) ([]minio.UploadedPart, error) {
+	bucket := s.cfg.Minio.ExcelBucket
+	if session.Multipart() {
+		parts, err := s.minioClient.ListUploadedParts(ctx, bucket, session.ObjectName, session.UploadID)
+		if err != nil {
+			return nil, errno.ErrMinioDownloadFailed.WithMessage("查询已上传分片失败").WithCause(err)
+		}
+		return parts, nil
+	}
+	size, err := s.minioClient.ObjectSize(ctx, bucket, session.ObjectName)
+	if minio.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errno.ErrMinioDownloadFailed.WithCause(err)
+	}
+	return []minio.UploadedPart{{PartNumber: 1, Size: size}}, nil
+}
+
+// uploadSessionDetail 组装会话详情，为未上传的分片签发预签名URL
+func (s *DataDictionaryService) uploadSessionDetail(
+	ctx context.Context,
+	session *model.UploadSession,
+	uploaded []minio.UploadedPart,
+) (*UploadSessionDetail, error) {
+	bucket, expiry := s.cfg.Minio.ExcelBucket, s.cfg.Upload.PresignExpiry
+	done := make(map[int]bool, len(uploaded))
+	for _, p := range uploaded {
+		done[p.PartNumber] = true
+	}
+
+	pending := make([]PresignedPart, 0, session.PartCount-len(uploaded))
+	for n := 1; n <= session.PartCount; n++ {
+		if done[n] {
+			continue
+		}
+		var (
+			url string
+			err error
+		)
+		if session.Multipart() {
+			url, err = s.minioClient.PresignUploadPart(ctx, bucket, session.ObjectName, session.UploadID, n, expiry)
+		} else {
+			url, err = s.minioClient.PresignPutObject(ctx, bucket, session.ObjectName, expiry)
+		}
+		if err != nil {
+			return nil, errno.ErrMinioUploadFailed.WithMessage("签发预签名URL失败").WithCause(err)
+		}
+		pending = append(pending, PresignedPart{
+			PartNumber: n,
+			Offset:     session.PartSize * int64(n-1),
+			Size:       session.PartLength(n),
+			Method:     "PUT",
+			URL:        url,
+		})
+	}
+
+	expiresAt := time.Now().Add(expiry)
+	if uploaded == nil {
+		uploaded = []minio.UploadedPart{}
+	}
+	return &UploadSessionDetail{
+		Session:       session,
+		UploadedParts: uploaded,
+		PendingParts:  pending,
+		URLExpiresAt:  &expiresAt,
+	}, nil
+}
+
+// removeStagedObject 删除暂存对象（失败仅记录日志）
+func (s *DataDictionaryService) removeStagedObject(ctx context.Context, session *model.UploadSession) {
+	if err := s.minioClient.DeleteFile(ctx, s.cfg.Minio.ExcelBucket, session.ObjectName); err != nil {
+		s.logger.WarnContext(ctx, "删除暂存对象失败", slog.String("object", session.ObjectName), slog.Any("error", err))
+	}
+}
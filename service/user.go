@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"customs/common/auth"
+	"customs/common/errno"
+	"customs/infrastructure/tracing"
+	"customs/model"
+	"customs/repository"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// UserService 本地用户服务（同时作为基于用户表的Basic认证器）
+type UserService struct {
+	logger    *slog.Logger
+	userRepo  *repository.UserRepository
+	auditSvc  *AuditService
+	dummyHash []byte // 用户不存在时用于比对的哈希，使耗时与密码错误一致，避免通过响应时间探测用户名
+}
+
+// NewUserService 初始化本地用户服务
+func NewUserService(logger *slog.Logger, userRepo *repository.UserRepository, auditSvc *AuditService) *UserService {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("customs-dummy-password"), bcrypt.DefaultCost)
+	return &UserService{logger: logger, userRepo: userRepo, auditSvc: auditSvc, dummyHash: dummyHash}
+}
+
+// UserInput 创建用户的参数
+type UserInput struct {
+	Username    string   // 登录名
+	DisplayName string   // 显示名称
+	Password    string   // 密码
+	Roles       []string // 角色
+}
+
+// Scheme 实现auth.Authenticator
+func (s *UserService) Scheme() string {
+	return `Basic realm="customs"`
+}
+
+// Authenticate 实现auth.Authenticator（校验Authorization: Basic，与本地用户表比对）
+func (s *UserService) Authenticate(r *http.Request) (*auth.Identity, error) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, auth.ErrNoCredentials
+	}
+
+	user, err := s.verifyPassword(r.Context(), username, password)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Identity{
+		UserID:      user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Source:      auth.SourceLocal,
+		Roles:       user.RoleList(),
+	}, nil
+}
+
+// CreateUser 创建本地用户（密码以bcrypt哈希存储）
+func (s *UserService) CreateUser(
+	ctx context.Context,
+	username, displayName, password string,
+	roles ...string,
+) (_ *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer func() { tracing.End(span, err) }()
+
+	if username == "" || password == "" {
+		return nil, errors.New("用户名和密码不能为空")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	user := model.NewUser(username, displayName, string(hash), roles...)
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListUsers 分页查询本地用户
+func (s *UserService) ListUsers(ctx context.Context, page, size int) (_ []model.User, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListUsers")
+	defer func() { tracing.End(span, err) }()
+
+	users, total, err := s.userRepo.List(ctx, page, size)
+	if err != nil {
+		return nil, 0, errno.ErrDBQueryFailed.WithCause(err)
+	}
+	return users, total, nil
+}
+
+// RegisterUser 管理员创建本地用户（登录名唯一，角色须为已定义的角色）
+func (s *UserService) RegisterUser(ctx context.Context, in UserInput) (_ *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.RegisterUser")
+	defer func() { tracing.End(span, err) }()
+
+	in.Username = strings.TrimSpace(in.Username)
+	if in.Username == "" || in.Password == "" {
+		return nil, errno.ErrInvalidParam.WithMessage("用户名和密码不能为空")
+	}
+	roles, err := normalizeRoles(in.Roles)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.userRepo.GetByUsername(ctx, in.Username)
+	if err == nil {
+		return nil, errno.ErrUserExists.WithDetails(map[string]string{"user_id": existing.ID})
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+
+	user, err := s.CreateUser(ctx, in.Username, in.DisplayName, in.Password, roles...)
+	if err != nil {
+		return nil, errno.ErrDBInsertFailed.WithCause(err)
+	}
+	s.logger.InfoContext(ctx, "已创建用户", slog.String("username", user.Username))
+	s.auditSvc.Record(ctx, model.AuditActionUserCreate, "", "", map[string]interface{}{
+		"user_id":  user.ID,
+		"username": user.Username,
+		"roles":    roles,
+	})
+	return user, nil
+}
+
+// AssignRoles 替换用户角色
+func (s *UserService) AssignRoles(ctx context.Context, id string, roles []string) (_ *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.AssignRoles")
+	defer func() { tracing.End(span, err) }()
+
+	roles, err = normalizeRoles(roles)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrUserNotFound.WithDetails(map[string]string{"user_id": id})
+	}
+	if err != nil {
+		return nil, errno.ErrDBQueryFailed.WithCause(err)
+	}
+
+	before := user.RoleList()
+	user.SetRoles(roles...)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, errno.ErrDBUpdateFailed.WithCause(err)
+	}
+	s.auditSvc.Record(ctx, model.AuditActionUserRoles, "", "", map[string]interface{}{
+		"user_id":  user.ID,
+		"username": user.Username,
+		"before":   before,
+		"after":    roles,
+	})
+	return user, nil
+}
+
+// normalizeRoles 去除重复角色并校验角色已定义
+func normalizeRoles(roles []string) ([]string, error) {
+	var out []string
+	for _, role := range roles {
+		role = strings.TrimSpace(role)
+		if !slices.Contains(model.Roles, role) {
+			return nil, errno.ErrInvalidParam.WithMessage("未定义的角色：" + role).
+				WithDetails(map[string]interface{}{"roles": model.Roles})
+		}
+		if !slices.Contains(out, role) {
+			out = append(out, role)
+		}
+	}
+	return out, nil
+}
+
+// EnsureBootstrapUser 用户表为空时创建初始管理员用户（便于首次部署登录，此后由管理员通过用户管理接口创建用户）
+func (s *UserService) EnsureBootstrapUser(ctx context.Context, username, password string) error {
+	count, err := s.userRepo.Count(ctx)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	if username == "" || password == "" {
+		s.logger.WarnContext(ctx, "用户表为空且未配置AUTH_BOOTSTRAP_USER/AUTH_BOOTSTRAP_PASSWORD，本地用户无法登录")
+		return nil
+	}
+	if _, err := s.CreateUser(ctx, username, username, password, model.RoleAdmin); err != nil {
+		return fmt.Errorf("创建初始用户失败：%w", err)
+	}
+	s.logger.InfoContext(ctx, "已创建初始用户", slog.String("username", username))
+	return nil
+}
+
+// verifyPassword 校验用户名与密码（用户不存在、已禁用、密码错误均返回ErrInvalidCredentials）
+func (s *UserService) verifyPassword(ctx context.Context, username, password string) (_ *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.verifyPassword")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = bcrypt.CompareHashAndPassword(s.dummyHash, []byte(password))
+			return nil, auth.ErrInvalidCredentials
+		}
+		return nil, errno.ErrDBQueryFailed.WithCause(err) // 查询失败不是凭证无效，不能返回401
+	}
+	if user.Disabled {
+		return nil, fmt.Errorf("%w: 用户已禁用", auth.ErrInvalidCredentials)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, auth.ErrInvalidCredentials
+	}
+	return user, nil
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"bytes"
+	"customs/model"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"strings"
+)
+
+const (
+	dictionarySheetName     = "数据字典" // 字典工作表名（上传校验只读取第一个工作表，须排在首位）
+	instructionsSheetName   = "填写说明" // 模板说明工作表名（首行非标准表头，解析时不会作为字典行入库）
+	templateFileName        = "system-db.xlsx"
+	maxSheetRows            = 1048576 // xlsx单个工作表的最大行数（数据校验覆盖整列）
+	templateHeaderFillRGB   = "D9E1F2"
+	templateRequiredFontRGB = "C00000"
+)
+
+// buildTemplateWorkbook 按model.TemplateColumns生成空白上传模板（含填写说明与示例）
+func buildTemplateWorkbook() ([]byte, error) {
+	f, err := newDictionaryWorkbook(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := writeInstructionsSheet(f); err != nil {
+		return nil, err
+	}
+	return writeWorkbook(f)
+}
+
+// buildDictionaryWorkbook 将已入库的字段及其关联关系写成模板格式的工作簿（每个字段一行）
+func buildDictionaryWorkbook(fields []model.DictionaryField, relations []model.TableRelation) ([]byte, error) {
+	refs := make(map[[2]string]model.TableRelation, len(relations))
+	for _, rel := range relations {
+		refs[[2]string{rel.TableNameEN, rel.FieldNameEN}] = rel
+	}
+	rows := make([][]string, len(fields))
+	for i, field := range fields {
+		primaryKey, refTable, refField := "", "", ""
+		if field.PrimaryKey {
+			primaryKey = model.PrimaryKeyYes
+		}
+		if rel, ok := refs[[2]string{field.TableNameEN, field.FieldNameEN}]; ok {
+			refTable, refField = rel.RefTableNameEN, rel.RefFieldNameEN
+			switch {
+			case rel.RefSystemName != "" && rel.RefSystemName != field.SystemName:
+				refTable = rel.RefSystemName + "-" + rel.RefDBName + "." + rel.RefTableNameEN
+			case rel.RefDBName != field.DBName:
+				refTable = rel.RefDBName + "." + rel.RefTableNameEN
+			}
+		}
+		rows[i] = []string{field.TableNameEN, field.TableNameCN, field.FieldNameEN, field.FieldNameCN, field.FieldDesc,
+			primaryKey, refTable, refField}
+	}
+	f, err := newDictionaryWorkbook(rows)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return writeWorkbook(f)
+}
+
+// newDictionaryWorkbook 创建首个工作表为字典表的工作簿：带样式的表头、列宽、冻结首行与按列定义生成的数据校验
+func newDictionaryWorkbook(rows [][]string) (*excelize.File, error) {
+	f := excelize.NewFile()
+	if err := fillDictionarySheet(f, rows); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// fillDictionarySheet 写入字典工作表
+func fillDictionarySheet(f *excelize.File, rows [][]string) error {
+	sheet := dictionarySheetName
+	if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+		return err
+	}
+	if err := setSheetRows(f, sheet, 1, append([][]string{model.TemplateColumnNames}, rows...)); err != nil {
+		return err
+	}
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{templateHeaderFillRGB}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border:    cellBorders(),
+	})
+	if err != nil {
+		return err
+	}
+	requiredStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Color: templateRequiredFontRGB},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{templateHeaderFillRGB}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border:    cellBorders(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, col := range model.TemplateColumns {
+		name, _ := excelize.ColumnNumberToName(i + 1)
+		style := headerStyle
+		if col.Required {
+			style = requiredStyle
+		}
+		if err := f.SetCellStyle(sheet, name+"1", name+"1", style); err != nil {
+			return err
+		}
+		if err := f.SetColWidth(sheet, name, name, col.Width); err != nil {
+			return err
+		}
+		if dv, err := columnValidation(col, name); err != nil {
+			return err
+		} else if dv != nil {
+			if err := f.AddDataValidation(sheet, dv); err != nil {
+				return err
+			}
+		}
+	}
+	if err := f.SetRowHeight(sheet, 1, 22); err != nil {
+		return err
+	}
+	return f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+}
+
+// columnValidation 按列定义生成数据校验：有可选值时为下拉列表，否则按最大字符数限制；均未定义时仅给出输入提示
+func columnValidation(col model.TemplateColumn, colName string) (*excelize.DataValidation, error) {
+	if len(col.Options) == 0 && col.MaxLength == 0 && col.Note == "" {
+		return nil, nil
+	}
+	dv := excelize.NewDataValidation(true)
+	dv.SetSqref(fmt.Sprintf("%s2:%s%d", colName, colName, maxSheetRows))
+	switch {
+	case len(col.Options) > 0:
+		if err := dv.SetDropList(col.Options); err != nil {
+			return nil, err
+		}
+		dv.SetError(excelize.DataValidationErrorStyleStop, col.Name, "请从下拉列表中选择："+strings.Join(col.Options, "/"))
+	case col.MaxLength > 0:
+		if err := dv.SetRange(0, col.MaxLength, excelize.DataValidationTypeTextLength, excelize.DataValidationOperatorBetween); err != nil {
+			return nil, err
+		}
+		dv.SetError(excelize.DataValidationErrorStyleStop, col.Name, fmt.Sprintf("不能超过%d个字符", col.MaxLength))
+	}
+	if col.Note != "" {
+		dv.SetInput(col.Name, col.Note)
+	}
+	return dv, nil
+}
+
+// writeInstructionsSheet 写入填写说明工作表：列说明表与示例行
+func writeInstructionsSheet(f *excelize.File) error {
+	sheet := instructionsSheetName
+	if _, err := f.NewSheet(sheet); err != nil {
+		return err
+	}
+
+	rows := [][]string{
+		{"数据字典模板填写说明"},
+		{"1. 文件名须为\"系统名-数据库名.xlsx\"（以短横线分隔两部分），如\"通关系统-h2018.xlsx\"。"},
+		{"2. 在\"" + dictionarySheetName + "\"工作表中填写，每个字段一行，请勿修改表头；红色表头为必填列。"},
+		{"3. 同一张表的多个字段可只在首行填写表名，后续行留空时沿用上一行的表名。"},
+		{"4. " + strings.Join(model.OptionalColumns, "、") + "为可选列，用于声明主键与字段间的引用关系，不需要时可整列删除。"},
+		{""},
+	}
+	columnHeaderRow := len(rows) + 1
+	rows = append(rows, []string{"列名", "必填", "最大字符数", "可选值", "说明"})
+	for _, col := range model.TemplateColumns {
+		required, maxLength := "否", "不限"
+		if col.Required {
+			required = "是"
+		}
+		if col.MaxLength > 0 {
+			maxLength = fmt.Sprint(col.MaxLength)
+		}
+		rows = append(rows, []string{col.Name, required, maxLength, strings.Join(col.Options, "/"), col.Note})
+	}
+	rows = append(rows, []string{""}, []string{"填写示例"}, model.TemplateColumnNames)
+	exampleHeaderRow := len(rows)
+	rows = append(rows, model.TemplateExampleRows...)
+	if err := setSheetRows(f, sheet, 1, rows); err != nil {
+		return err
+	}
+
+	titleStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true, Size: 14}})
+	if err != nil {
+		return err
+	}
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font:   &excelize.Font{Bold: true},
+		Fill:   excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{templateHeaderFillRGB}},
+		Border: cellBorders(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(sheet, "A1", "A1", titleStyle); err != nil {
+		return err
+	}
+	lastCol, _ := excelize.ColumnNumberToName(len(model.TemplateColumns))
+	for _, row := range []int{columnHeaderRow, exampleHeaderRow} {
+		if err := f.SetCellStyle(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("%s%d", lastCol, row), headerStyle); err != nil {
+			return err
+		}
+	}
+	if err := f.SetCellStyle(sheet, fmt.Sprintf("A%d", exampleHeaderRow-1), fmt.Sprintf("A%d", exampleHeaderRow-1), titleStyle); err != nil {
+		return err
+	}
+	for i, col := range model.TemplateColumns {
+		name, _ := excelize.ColumnNumberToName(i + 1)
+		if err := f.SetColWidth(sheet, name, name, col.Width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSheetRows 从第startRow行起逐行写入
+func setSheetRows(f *excelize.File, sheet string, startRow int, rows [][]string) error {
+	for i, row := range rows {
+		cells := make([]interface{}, len(row))
+		for j, v := range row {
+			cells[j] = v
+		}
+		if err := f.SetSheetRow(sheet, fmt.Sprintf("A%d", startRow+i), &cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cellBorders 细实线四边框
+func cellBorders() []excelize.Border {
+	return []excelize.Border{
+		{Type: "left", Color: "BFBFBF", Style: 1},
+		{Type: "right", Color: "BFBFBF", Style: 1},
+		{Type: "top", Color: "BFBFBF", Style: 1},
+		{Type: "bottom", Color: "BFBFBF", Style: 1},
+	}
+}
+
+// writeWorkbook 序列化工作簿
+func writeWorkbook(f *excelize.File) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -2,11 +2,27 @@ package task
 
 import (
 	"context"
+	"customs/common/logger"
+	"customs/infrastructure/tracing"
+	"customs/model"
 	"customs/task/payload" // 替换为你的模块名
+	"errors"
 	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"time"
 )
 
+// 任务队列名称（与Worker的队列权重配置保持一致）
+const (
+	QueueExcel   = "excel"   // Excel解析队列
+	QueueDB      = "db"      // 数据入库队列
+	QueueDefault = "default" // 默认队列
+)
+
+// Queues 所有业务队列
+var Queues = []string{QueueExcel, QueueDB, QueueDefault}
+
 // Client 异步任务生产者客户端
 type Client struct {
 	asynqClient *asynq.Client
@@ -22,9 +38,12 @@ func NewClient(redisAddr, redisPassword string, redisDB int) *Client {
 	return &Client{asynqClient: client}
 }
 
-// CreateDFTask 生产“解析Excel”任务
-func (c *Client) CreateDFTask(ctx context.Context, resourceComment, excelName, taskID string) (*asynq.TaskInfo, error) {
-	task, err := payload.NewCreateDFTask(resourceComment, excelName, taskID)
+// CreateDFTask 生产“解析Excel”任务（上下文中的请求ID与链路信息随Payload传递给Worker）
+func (c *Client) CreateDFTask(ctx context.Context, resourceComment, excelName, objectName, taskID string) (_ *asynq.TaskInfo, err error) {
+	ctx, span := startEnqueueSpan(ctx, payload.TypeCreateDF, QueueExcel, taskID)
+	defer func() { tracing.End(span, err) }()
+
+	task, err := payload.NewCreateDFTask(resourceComment, excelName, objectName, newMeta(ctx, taskID))
 	if err != nil {
 		return nil, err
 	}
@@ -32,20 +51,83 @@ func (c *Client) CreateDFTask(ctx context.Context, resourceComment, excelName, t
 	return c.asynqClient.EnqueueContext(ctx, task,
 		asynq.MaxRetry(3),               // 失败重试3次
 		asynq.Timeout(5*60*time.Second), // 超时5分钟
-		asynq.Queue("excel"),            // 指定队列（可选，用于任务优先级）
+		asynq.Queue(QueueExcel),         // 指定队列（可选，用于任务优先级）
 	)
 }
 
 // InsertDFTask 生产“数据入库”任务
-func (c *Client) InsertDFTask(ctx context.Context, dbCSV, dictCSV, taskID string) (*asynq.TaskInfo, error) {
-	task, err := payload.NewInsertDFTask(dbCSV, dictCSV, taskID)
+func (c *Client) InsertDFTask(ctx context.Context, dbCSV, dictCSV, taskID string) (_ *asynq.TaskInfo, err error) {
+	ctx, span := startEnqueueSpan(ctx, payload.TypeInsertDF, QueueDB, taskID)
+	defer func() { tracing.End(span, err) }()
+
+	task, err := payload.NewInsertDFTask(dbCSV, dictCSV, newMeta(ctx, taskID))
 	if err != nil {
 		return nil, err
 	}
 	return c.asynqClient.EnqueueContext(ctx, task,
 		asynq.MaxRetry(3),
 		asynq.Timeout(10*60*time.Second),
-		asynq.Queue("db"),
+		asynq.Queue(QueueDB),
+	)
+}
+
+// DriftCheckTask 生产“表结构差异检查”任务（dbResourceID为空时由Worker为每个已配置连接的资源分别创建任务）
+func (c *Client) DriftCheckTask(ctx context.Context, dbResourceID, trigger, operator string) (_ *asynq.TaskInfo, err error) {
+	ctx, span := startEnqueueSpan(ctx, payload.TypeDriftCheck, QueueDefault, "")
+	defer func() { tracing.End(span, err) }()
+
+	task, err := payload.NewDriftCheckTask(dbResourceID, trigger, operator, newMeta(ctx, ""))
+	if err != nil {
+		return nil, err
+	}
+	return c.asynqClient.EnqueueContext(ctx, task,
+		asynq.MaxRetry(2),
+		asynq.Timeout(5*60*time.Second),
+		asynq.Queue(QueueDefault),
+	)
+}
+
+// driftRunRetention 定时检查拆分出的任务完成后的保留时长（保留期内同一次调度重复入队会因任务ID冲突被忽略）
+const driftRunRetention = time.Hour
+
+// ScheduledDriftCheckTask 为一次定时检查（runID为定时任务的ID）生产单个资源的差异检查任务
+// 任务ID由runID与资源ID组成，定时任务重试时已入队的资源不会重复创建，返回是否新入队
+func (c *Client) ScheduledDriftCheckTask(ctx context.Context, dbResourceID, runID string) (_ bool, err error) {
+	ctx, span := startEnqueueSpan(ctx, payload.TypeDriftCheck, QueueDefault, "")
+	defer func() { tracing.End(span, err) }()
+
+	task, err := payload.NewDriftCheckTask(dbResourceID, model.DriftTriggerSchedule, "", newMeta(ctx, ""))
+	if err != nil {
+		return false, err
+	}
+	_, err = c.asynqClient.EnqueueContext(ctx, task,
+		asynq.TaskID("drift:"+runID+":"+dbResourceID),
+		asynq.MaxRetry(2),
+		asynq.Timeout(5*60*time.Second),
+		asynq.Retention(driftRunRetention),
+		asynq.Queue(QueueDefault),
+	)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// newMeta 组装Payload关联字段（需在入队Span内调用，使Worker的Span挂在入队Span之下）
+func newMeta(ctx context.Context, taskID string) payload.Meta {
+	return payload.Meta{
+		TaskID:       taskID,
+		RequestID:    logger.RequestID(ctx),
+		TraceCarrier: tracing.Inject(ctx),
+	}
+}
+
+// startEnqueueSpan 开启任务入队的Producer Span
+func startEnqueueSpan(ctx context.Context, taskType, queue, taskID string) (context.Context, trace.Span) {
+	return tracing.StartWithKind(ctx, "asynq.enqueue "+taskType, trace.SpanKindProducer,
+		attribute.String("messaging.system", "asynq"),
+		attribute.String("messaging.destination.name", queue),
+		attribute.String(logger.KeyDictTask, taskID),
 	)
 }
 
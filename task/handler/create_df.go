@@ -1,28 +1,27 @@
 package handler
 
 import (
+	"bytes"
 	"context"
-	"customs/infrastructure/minio"
-	"customs/infrastructure/redis"
+	"customs/infrastructure/metrics"
 	"customs/model"
-	"customs/repository"
+	"customs/service"
 	"customs/task/payload"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/hibiken/asynq"
 	"github.com/xuri/excelize/v2"
-	"log"
+	"gorm.io/gorm"
+	"log/slog"
 	"time"
 )
 
-// CreateDFHandler 解析Excel任务的消费逻辑
-func CreateDFHandler(
-	ctx context.Context,
-	task *asynq.Task,
-	minioClient *minio.Client,
-	redisClient *redis.Client,
-	dictRepo *repository.DictionaryRepository,
-	dbResRepo *repository.DBResourceRepository,
-) error {
+// errNoDictionaryRows Excel中没有含标准列的数据行
+var errNoDictionaryRows = errors.New("Excel中没有包含标准列的数据字典行")
+
+// CreateDF 解析Excel任务的消费逻辑
+func (h *TaskHandler) CreateDF(ctx context.Context, task *asynq.Task) error {
 	ctx, cancel := context.WithTimeout(ctx, 290*time.Second)
 	defer cancel()
 	// 1. 解析任务参数
@@ -32,38 +31,35 @@ func CreateDFHandler(
 	}
 
 	// 2. 从MinIO下载Excel文件
-	excelFileBytes, err := minioClient.DownloadFile("excel-bucket", p.ExcelName)
+	objectName := p.Object()
+	excelFileBytes, err := h.minioClient.DownloadFile(ctx, h.cfg.Minio.ExcelBucket, objectName)
 	if err != nil {
-		// 更新任务状态为失败
-		dictTask, _ := dictRepo.GetByID(ctx, p.TaskID)
-		dictTask.UpdateCreateDFStatus(model.TaskStatusFailed, "下载Excel失败: "+err.Error())
-		dictRepo.Update(ctx, dictTask)
-		return err
+		return h.failCreateDF(ctx, p.TaskID, "下载Excel失败", err)
 	}
 
 	// 3. 解析Excel具体逻辑
-	// 3.1 打开Excel文件
+	// 3.1 打开Excel文件，按上传时识别的列映射方案映射表头
 	f, err := excelize.OpenReader(excelFileBytes)
 	if err != nil {
-		dictTask, _ := dictRepo.GetByID(ctx, p.TaskID)
-		dictTask.UpdateCreateDFStatus(model.TaskStatusFailed, "打开Excel失败: "+err.Error())
-		dictRepo.Update(ctx, dictTask)
-		return err
+		return contentFailure(h.failCreateDF(ctx, p.TaskID, "打开Excel失败", err))
 	}
 	defer f.Close()
+	mapper, err := h.headerMapper(ctx, p.TaskID)
+	if err != nil {
+		return h.failCreateDF(ctx, p.TaskID, "加载列映射方案失败", err)
+	}
 
 	// 3.2 解析Excel数据
 	parseResult := make(map[string]interface{}) // 存储最终解析结果
+	var dictRows [][]string                     // 含标准列的sheet中的字典行（按模板列顺序，写入CSV供入库）
+	var namingRows []service.NamingRow          // 字典行及其位置（命名规范检查）
 
 	// 遍历所有sheet
 	for _, sheetName := range f.GetSheetList() {
 		// 读取当前sheet的所有行
 		rows, err := f.GetRows(sheetName)
 		if err != nil {
-			dictTask, _ := dictRepo.GetByID(ctx, p.TaskID)
-			dictTask.UpdateCreateDFStatus(model.TaskStatusFailed, "读取sheet["+sheetName+"]失败: "+err.Error())
-			dictRepo.Update(ctx, dictTask)
-			return err
+			return skipRetry(h.failCreateDF(ctx, p.TaskID, "读取sheet["+sheetName+"]失败", err))
 		}
 
 		// 处理行数据（示例：第一行作为表头，后续行作为数据）
@@ -86,43 +82,133 @@ func CreateDFHandler(
 			}
 			sheetData = append(sheetData, rowData)
 		}
+		if records, lines, ok := extractDictionaryRows(mapper.Canonical(header), dataRows); ok {
+			dictRows = append(dictRows, records...)
+			for i, record := range records {
+				namingRows = append(namingRows, newNamingRow(sheetName, lines[i], record))
+			}
+		}
+		metrics.RowsParsedTotal.Add(float64(len(sheetData)))
+		h.logger.DebugContext(ctx, "sheet解析完成", slog.String("sheet", sheetName), slog.Int("rows", len(sheetData)))
 
 		// 将当前sheet的结果存入解析结果
 		parseResult[sheetName] = sheetData
 	}
 
-	// 3.3 生成CSV文件名（保持原有逻辑）
-	dbResourceCSVName := p.ExcelName + "_db.csv"
-	dataDictionaryCSVName := p.ExcelName + "_dict.csv"
-	csvName := p.ExcelName + "_all.csv"
+	if len(dictRows) == 0 {
+		return skipRetry(h.failCreateDF(ctx, p.TaskID, "未解析到数据字典行", errNoDictionaryRows))
+	}
+
+	// 3.3 生成CSV文件名（与Excel对象同目录，同名文件互不覆盖）并上传到CSV桶供入库任务使用
+	// 按字段中文名自动匹配业务术语，匹配结果随CSV交给入库任务建立关联
+	matcher, err := h.glossarySvc.NewTermMatcher(ctx)
+	if err != nil {
+		return h.failCreateDF(ctx, p.TaskID, "加载业务术语失败", err)
+	}
+	terms := termRows(dictRows, matcher.Match)
+	keys := keyRows(dictRows)
+
+	dbResourceCSVName := objectName + "_db.csv"
+	dataDictionaryCSVName := objectName + "_dict.csv"
+	termsCSVName := objectName + "_terms.csv"
+	keysCSVName := objectName + "_keys.csv"
+	csvName := objectName + "_all.csv"
+	csvFiles := []struct {
+		name    string
+		header  []string
+		records [][]string
+	}{
+		{dbResourceCSVName, tableColumns, tableRows(dictRows)},
+		{dataDictionaryCSVName, model.StdColumns, stdRows(dictRows)},
+		{termsCSVName, termColumns, terms},
+		{keysCSVName, keyColumns, keys},
+	}
+	for _, f := range csvFiles {
+		data, err := encodeCSV(f.header, f.records)
+		if err != nil {
+			return skipRetry(h.failCreateDF(ctx, p.TaskID, "生成CSV失败", err))
+		}
+		if err := h.minioClient.UploadFile(ctx, h.cfg.Minio.CSVBucket, f.name, bytes.NewReader(data), int64(len(data))); err != nil {
+			return h.failCreateDF(ctx, p.TaskID, "上传CSV失败", err)
+		}
+	}
+
+	// 3.4 命名规范检查，违规明细上传到CSV桶
+	violations := h.namingRules.Check(namingRows)
+	if violations == nil {
+		violations = []service.NamingViolation{}
+	}
+	namingReportName := objectName + "_naming.json"
+	namingReport, err := json.Marshal(violations)
+	if err != nil {
+		return skipRetry(h.failCreateDF(ctx, p.TaskID, "序列化命名规范检查结果失败", err))
+	}
+	if err := h.minioClient.UploadFile(ctx, h.cfg.Minio.CSVBucket, namingReportName, bytes.NewReader(namingReport), int64(len(namingReport))); err != nil {
+		return h.failCreateDF(ctx, p.TaskID, "上传命名规范检查结果失败", err)
+	}
+	namingErrors, namingWarnings := service.CountNamingViolations(violations)
 
 	// 4. 将解析结果存入Redis
 	redisKey := "dict_task_" + p.TaskID
 	// resultJSON := 解析后的结果序列化
 	resultJSON, err := json.Marshal(parseResult)
 	if err != nil {
-		dictTask, _ := dictRepo.GetByID(ctx, p.TaskID)
-		dictTask.UpdateCreateDFStatus(model.TaskStatusFailed, "序列化解析结果失败: "+err.Error())
-		dictRepo.Update(ctx, dictTask)
-		return err
+		return skipRetry(h.failCreateDF(ctx, p.TaskID, "序列化解析结果失败", err))
 	}
 
-	if err := redisClient.Set(redisKey, resultJSON, 12*3600); err != nil {
-		log.Printf("缓存写入失败：%v, taskID=%s", err, p.TaskID)
-		dictTask, _ := dictRepo.GetByID(ctx, p.TaskID)
-		dictTask.UpdateCreateDFStatus(model.TaskStatusFailed, "缓存解析结果失败: "+err.Error())
-		dictRepo.Update(ctx, dictTask)
-		return err
+	if err := h.redisClient.Set(ctx, redisKey, resultJSON, 12*3600); err != nil {
+		return h.failCreateDF(ctx, p.TaskID, "缓存解析结果失败", err)
 	}
 
 	// 5. 更新任务状态为成功
-	dictTask, err := dictRepo.GetByID(ctx, p.TaskID)
+	dictTask, err := h.dictRepo.GetByID(ctx, p.TaskID)
 	if err != nil {
 		return err
 	}
 	dictTask.DBResourceCSVName = dbResourceCSVName
 	dictTask.DataDictionaryCSVName = dataDictionaryCSVName
 	dictTask.CSVName = csvName
+	dictTask.NamingReportName = namingReportName
+	dictTask.TermsCSVName = termsCSVName
+	dictTask.KeysCSVName = keysCSVName
+	dictTask.NamingErrors = namingErrors
+	dictTask.NamingWarnings = namingWarnings
 	dictTask.UpdateCreateDFStatus(model.TaskStatusSucceeded)
-	return dictRepo.Update(ctx, dictTask)
+	if err := h.dictRepo.Update(ctx, dictTask); err != nil {
+		return err
+	}
+	h.auditSvc.RecordAs(ctx, taskActor(dictTask, model.AuditActionParseSucceeded), model.AuditActionParseSucceeded,
+		dictTask.ID, "", map[string]interface{}{
+			"excel_name":      p.ExcelName,
+			"sheets":          len(parseResult),
+			"fields":          len(dictRows),
+			"naming_errors":   namingErrors,
+			"naming_warnings": namingWarnings,
+			"term_matches":    len(terms),
+			"keys":            len(keys),
+		})
+	return nil
+}
+
+// headerMapper 按任务记录的列映射方案生成表头映射（未使用方案时只识别模板列名）
+// 优先使用上传时保存的别名快照；快照之前创建的任务按方案ID加载，方案已删除时不再重试
+func (h *TaskHandler) headerMapper(ctx context.Context, dictTaskID string) (*service.HeaderMapper, error) {
+	dictTask, err := h.dictRepo.GetByID(ctx, dictTaskID)
+	if err != nil {
+		return nil, err
+	}
+	if dictTask.ColumnMappingID == "" {
+		return service.NewHeaderMapper(nil), nil
+	}
+	if len(dictTask.ColumnAliases) > 0 {
+		return service.NewHeaderMapper(&model.ColumnMapping{ID: dictTask.ColumnMappingID, Aliases: dictTask.ColumnAliases}), nil
+	}
+	mapping, err := h.mappingRepo.GetByID(ctx, dictTask.ColumnMappingID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: 列映射方案%s已删除", asynq.SkipRetry, dictTask.ColumnMappingID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return service.NewHeaderMapper(mapping), nil
 }
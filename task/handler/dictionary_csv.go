@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"bytes"
+	"customs/model"
+	"customs/service"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// tableColumns 数据库资源CSV的列（每张表一行）
+var tableColumns = []string{model.ColumnTableNameEN, model.ColumnTableNameCN}
+
+// termColumns 业务术语匹配结果CSV的列（每个匹配到术语的字段一行）
+var termColumns = []string{model.ColumnTableNameEN, model.ColumnFieldNameEN, "术语ID"}
+
+// keyColumns 主键与关联关系CSV的列（每个声明了主键或关联表的字段一行；关联库为空表示与本字段同库，可写作"系统名-库名"）
+var keyColumns = []string{model.ColumnTableNameEN, model.ColumnFieldNameEN, model.ColumnPrimaryKey, "关联库", model.ColumnRefTable, model.ColumnRefField}
+
+// extractDictionaryRows 按模板列顺序提取字典行及其Excel行号；sheet缺少标准列时返回false（可选列缺失时取空值）
+// 模板中同一张表的表名常为合并单元格（仅首行有值），空表名沿用上一行；字段名均为空的行跳过
+func extractDictionaryRows(header []string, dataRows [][]string) ([][]string, []int, bool) {
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		index[strings.TrimSpace(col)] = i
+	}
+	positions := make([]int, len(model.TemplateColumnNames))
+	for i, col := range model.TemplateColumnNames {
+		pos, ok := index[col]
+		if !ok && i < len(model.StdColumns) {
+			return nil, nil, false
+		}
+		if !ok {
+			pos = -1
+		}
+		positions[i] = pos
+	}
+
+	var (
+		records                  [][]string
+		lines                    []int
+		lastTableEN, lastTableCN string
+	)
+	for n, row := range dataRows {
+		record := make([]string, len(positions))
+		for i, pos := range positions {
+			if pos >= 0 && pos < len(row) {
+				record[i] = strings.TrimSpace(row[pos])
+			}
+		}
+		// 列顺序与model.TemplateColumnNames一致：0表名英文 1表名中文 2字段名英文 3字段名中文 4字段说明 5是否主键 6关联表 7关联字段
+		if record[0] == "" && record[1] == "" {
+			record[0], record[1] = lastTableEN, lastTableCN
+		}
+		lastTableEN, lastTableCN = record[0], record[1]
+		if record[2] == "" && record[3] == "" {
+			continue
+		}
+		records = append(records, record)
+		lines = append(lines, n+2) // 表头为第1行
+	}
+	return records, lines, true
+}
+
+// stdRows 截取字典行的标准列（写入字典CSV）
+func stdRows(dictRows [][]string) [][]string {
+	rows := make([][]string, len(dictRows))
+	for i, row := range dictRows {
+		rows[i] = row[:len(model.StdColumns)]
+	}
+	return rows
+}
+
+// keyRows 提取声明了主键或关联表的字段，返回 表名、字段名、是否主键、关联库、关联表、关联字段 行
+// 关联表可写作"库名.表名"引用其他库的表，库名在多个系统中重名时写作"系统名-库名.表名"；关联字段为空时与本字段同名
+func keyRows(dictRows [][]string) [][]string {
+	var rows [][]string
+	for _, row := range dictRows {
+		primaryKey, refTable, refField := isPrimaryKey(row[5]), row[6], row[7]
+		if row[2] == "" || (!primaryKey && refTable == "") {
+			continue
+		}
+		var refDB string
+		if db, table, ok := strings.Cut(refTable, "."); ok {
+			refDB, refTable = strings.TrimSpace(db), strings.TrimSpace(table)
+		}
+		if refTable != "" && refField == "" {
+			refField = row[2]
+		}
+		pk := model.PrimaryKeyNo
+		if primaryKey {
+			pk = model.PrimaryKeyYes
+		}
+		rows = append(rows, []string{row[0], row[2], pk, refDB, refTable, refField})
+	}
+	return rows
+}
+
+// isPrimaryKey 是否主键列的取值（兼容是/Y/YES/TRUE/1，不区分大小写）
+func isPrimaryKey(value string) bool {
+	switch strings.ToUpper(value) {
+	case model.PrimaryKeyYes, "Y", "YES", "TRUE", "1":
+		return true
+	default:
+		return false
+	}
+}
+
+// newNamingRow 按模板列组装待检查的字典行
+func newNamingRow(sheet string, line int, record []string) service.NamingRow {
+	values := make(map[string]string, len(model.TemplateColumnNames))
+	for i, col := range model.TemplateColumnNames {
+		values[col] = record[i]
+	}
+	return service.NamingRow{Sheet: sheet, Row: line, Values: values}
+}
+
+// tableRows 由字典行汇总去重的表（保持首次出现的顺序）
+func tableRows(dictRows [][]string) [][]string {
+	seen := make(map[string]bool)
+	var tables [][]string
+	for _, row := range dictRows {
+		if row[0] == "" || seen[row[0]] {
+			continue
+		}
+		seen[row[0]] = true
+		tables = append(tables, []string{row[0], row[1]})
+	}
+	return tables
+}
+
+// termRows 按字段中文名匹配业务术语（match通常为TermMatcher.Match），返回 表名、字段名、术语ID 行（同一字段只保留首次出现）
+func termRows(dictRows [][]string, match func(fieldNameCN string) (string, bool)) [][]string {
+	seen := make(map[[2]string]bool)
+	var rows [][]string
+	for _, row := range dictRows {
+		key := [2]string{row[0], row[2]}
+		if row[2] == "" || seen[key] {
+			continue
+		}
+		if termID, ok := match(row[3]); ok {
+			seen[key] = true
+			rows = append(rows, []string{row[0], row[2], termID})
+		}
+	}
+	return rows
+}
+
+// encodeCSV 编码带表头的CSV
+func encodeCSV(header []string, records [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeCSV 读取带表头的CSV，按columns顺序返回数据行（按列名定位，兼容列顺序变化）
+func decodeCSV(r io.Reader, columns []string) ([][]string, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	index := make(map[string]int, len(records[0]))
+	for i, col := range records[0] {
+		index[col] = i
+	}
+	positions := make([]int, len(columns))
+	for i, col := range columns {
+		pos, ok := index[col]
+		if !ok {
+			return nil, fmt.Errorf("CSV缺少列：%s", col)
+		}
+		positions[i] = pos
+	}
+
+	rows := make([][]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make([]string, len(positions))
+		for i, pos := range positions {
+			if pos < len(record) {
+				row[i] = record[pos]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"customs/model"
+	"reflect"
+	"testing"
+)
+
+// dictRow 按模板列顺序组装字典行：表名英文、表名中文、字段名英文、字段名中文、字段说明、是否主键、关联表、关联字段
+func dictRow(tableEN, fieldEN, fieldCN, primaryKey, refTable, refField string) []string {
+	return []string{tableEN, "", fieldEN, fieldCN, "", primaryKey, refTable, refField}
+}
+
+func TestKeyRows(t *testing.T) {
+	got := keyRows([][]string{
+		dictRow("entry_head", "entry_id", "报关单号", "是", "", ""),
+		dictRow("entry_head", "decl_date", "申报日期", "", "", ""),
+		dictRow("entry_head", "trade_code", "企业编码", "", "corp.corp_info", "corp_code"),
+		dictRow("entry_list", "entry_id", "报关单号", "Y", "entry_head", ""),
+		dictRow("entry_list", "owner_code", "货主编码", "否", " ops-corp . corp_info ", "corp_code"),
+		dictRow("entry_list", "", "", "是", "", ""),
+	})
+	want := [][]string{
+		{"entry_head", "entry_id", model.PrimaryKeyYes, "", "", ""},
+		{"entry_head", "trade_code", model.PrimaryKeyNo, "corp", "corp_info", "corp_code"},
+		{"entry_list", "entry_id", model.PrimaryKeyYes, "", "entry_head", "entry_id"}, // 关联字段为空时与本字段同名
+		{"entry_list", "owner_code", model.PrimaryKeyNo, "ops-corp", "corp_info", "corp_code"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("keyRows() = %v, want %v", got, want)
+	}
+}
+
+func TestSplitRefDB(t *testing.T) {
+	tests := []struct {
+		refDB              string
+		wantSystem, wantDB string
+	}{
+		{"", "customs", "entry"},        // 同库引用
+		{"corp", "", "corp"},            // 只写库名时不限定系统
+		{"ops-corp", "ops", "corp"},     // 系统名-库名
+		{" ops - corp ", "ops", "corp"}, // 忽略空白
+	}
+	for _, tt := range tests {
+		system, db := splitRefDB(tt.refDB, "customs", "entry")
+		if system != tt.wantSystem || db != tt.wantDB {
+			t.Errorf("splitRefDB(%q) = %q, %q, want %q, %q", tt.refDB, system, db, tt.wantSystem, tt.wantDB)
+		}
+	}
+}
+
+func TestTermRows(t *testing.T) {
+	terms := map[string]string{"报关单号": "term-1", "企业编码": "term-2"}
+	match := func(fieldNameCN string) (string, bool) {
+		id, ok := terms[fieldNameCN]
+		return id, ok
+	}
+	got := termRows([][]string{
+		dictRow("entry_head", "entry_id", "报关单号", "", "", ""),
+		dictRow("entry_head", "entry_id", "报关单号", "", "", ""), // 同一字段只保留首次出现
+		dictRow("entry_head", "decl_date", "申报日期", "", "", ""),
+		dictRow("entry_list", "entry_id", "报关单号", "", "", ""),
+		dictRow("entry_list", "", "企业编码", "", "", ""), // 没有英文字段名的行跳过
+	}, match)
+	want := [][]string{
+		{"entry_head", "entry_id", "term-1"},
+		{"entry_list", "entry_id", "term-1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("termRows() = %v, want %v", got, want)
+	}
+}
+
+func TestExtractDictionaryRows(t *testing.T) {
+	header := append([]string{"备注"}, model.StdColumns...)
+	records, lines, ok := extractDictionaryRows(header, [][]string{
+		{"", "entry_head", "报关单表头", "entry_id", "报关单号", ""},
+		{"", "", "", "decl_date", "申报日期", "格式YYYYMMDD"}, // 合并单元格：沿用上一行的表名
+		{"", "", "", "", "", ""},
+		{"x", "entry_list", "报关单表体", " g_no ", "商品序号"},
+	})
+	if !ok {
+		t.Fatal("包含标准列的表头应能提取")
+	}
+	want := [][]string{
+		{"entry_head", "报关单表头", "entry_id", "报关单号", "", "", "", ""},
+		{"entry_head", "报关单表头", "decl_date", "申报日期", "格式YYYYMMDD", "", "", ""},
+		{"entry_list", "报关单表体", "g_no", "商品序号", "", "", "", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+	if !reflect.DeepEqual(lines, []int{2, 3, 5}) {
+		t.Errorf("lines = %v", lines)
+	}
+
+	if _, _, ok := extractDictionaryRows(model.StdColumns[1:], nil); ok {
+		t.Error("缺少标准列时应返回false")
+	}
+}
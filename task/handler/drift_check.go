@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"context"
+	"customs/common/errno"
+	"customs/task/payload"
+	"errors"
+	"github.com/hibiken/asynq"
+	"log/slog"
+)
+
+// DriftCheck 表结构差异检查任务的消费逻辑
+// 未指定资源时（定时触发）为每个已配置连接的资源分别创建检查任务，单个资源失败不影响其他资源
+func (h *TaskHandler) DriftCheck(ctx context.Context, task *asynq.Task) error {
+	p, err := payload.ParseDriftCheckPayload(task)
+	if err != nil {
+		return err
+	}
+
+	if p.DBResourceID == "" {
+		runID, _ := asynq.GetTaskID(ctx)
+		n, err := h.driftSvc.EnqueueAll(ctx, runID)
+		h.logger.InfoContext(ctx, "已创建表结构差异检查任务", slog.Int("count", n))
+		return err
+	}
+
+	_, err = h.driftSvc.Check(ctx, p.DBResourceID, p.Trigger, p.Operator)
+	if errors.Is(err, errno.ErrDBResourceNotFound) || errors.Is(err, errno.ErrConnectionMissing) {
+		// 资源已删除或连接已清除，重试无意义
+		h.logger.WarnContext(ctx, "跳过表结构差异检查", slog.String("db_resource_id", p.DBResourceID), slog.Any("error", err))
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"customs/config"
+	"customs/infrastructure/minio"
+	"customs/infrastructure/redis"
+	"customs/model"
+	"customs/repository"
+	"customs/service"
+	"errors"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"io"
+	"log/slog"
+	"net"
+	"time"
+)
+
+// statusUpdateTimeout 标记失败状态时使用的独立超时（任务上下文可能已超时）
+const statusUpdateTimeout = 10 * time.Second
+
+// TaskHandler 异步任务处理器（封装任务消费所需的依赖）
+type TaskHandler struct {
+	cfg         *config.Config                        // 全局配置（桶名等）
+	logger      *slog.Logger                          // 结构化日志
+	minioClient *minio.Client                         // MinIO工具（下载Excel/CSV）
+	redisClient *redis.Client                         // Redis工具（缓存解析结果）
+	dictRepo    *repository.DictionaryRepository      // 任务记录CRUD
+	dbResRepo   *repository.DBResourceRepository      // 资源备注CRUD
+	fieldRepo   *repository.DictionaryFieldRepository // 字段及关联关系入库（全文检索）
+	mappingRepo *repository.ColumnMappingRepository   // 列映射方案（解析时映射表头）
+	auditSvc    *service.AuditService                 // 审计日志
+	exportSvc   *service.ExportService                // 入库后重新生成文档
+	driftSvc    *service.DriftService                 // 表结构差异检查
+	namingRules *service.NamingRules                  // 命名规范规则集
+	glossarySvc *service.GlossaryService              // 业务术语匹配与关联
+	cleanupSvc  *service.UploadCleanupService         // 过期直传会话清理
+}
+
+// NewTaskHandler 初始化任务处理器（依赖注入）
+func NewTaskHandler(
+	cfg *config.Config,
+	logger *slog.Logger,
+	minioClient *minio.Client,
+	redisClient *redis.Client,
+	dictRepo *repository.DictionaryRepository,
+	dbResRepo *repository.DBResourceRepository,
+	fieldRepo *repository.DictionaryFieldRepository,
+	mappingRepo *repository.ColumnMappingRepository,
+	auditSvc *service.AuditService,
+	exportSvc *service.ExportService,
+	driftSvc *service.DriftService,
+	namingRules *service.NamingRules,
+	glossarySvc *service.GlossaryService,
+	cleanupSvc *service.UploadCleanupService,
+) *TaskHandler {
+	return &TaskHandler{
+		cfg:         cfg,
+		logger:      logger,
+		minioClient: minioClient,
+		redisClient: redisClient,
+		dictRepo:    dictRepo,
+		dbResRepo:   dbResRepo,
+		fieldRepo:   fieldRepo,
+		mappingRepo: mappingRepo,
+		auditSvc:    auditSvc,
+		exportSvc:   exportSvc,
+		driftSvc:    driftSvc,
+		namingRules: namingRules,
+		glossarySvc: glossarySvc,
+		cleanupSvc:  cleanupSvc,
+	}
+}
+
+// failCreateDF 将解析任务标记为失败，返回原始错误（状态更新失败仅记录日志）
+// 重试无法成功的失败由调用方以skipRetry或contentFailure包装返回值，避免已标记失败的任务反复重试
+func (h *TaskHandler) failCreateDF(ctx context.Context, dictTaskID, remark string, cause error) error {
+	h.markFailed(ctx, dictTaskID, remark, cause, model.AuditActionParseFailed,
+		(*model.DictionaryTask).UpdateCreateDFStatus, h.dictRepo.UpdateCreateDFStatus)
+	return cause
+}
+
+// failInsertDF 将入库任务标记为失败，返回原始错误（状态更新失败仅记录日志）
+func (h *TaskHandler) failInsertDF(ctx context.Context, dictTaskID, remark string, cause error) error {
+	h.markFailed(ctx, dictTaskID, remark, cause, model.AuditActionInsertFailed,
+		(*model.DictionaryTask).UpdateInsertDFStatus, h.dictRepo.UpdateInsertDFStatus)
+	return cause
+}
+
+// skipRetry 包装确定性失败（未解析到字典行、列映射方案缺失、序列化失败等），asynq不再重试
+func skipRetry(err error) error {
+	return fmt.Errorf("%w: %v", asynq.SkipRetry, err)
+}
+
+// contentFailure 读取并解析MinIO对象失败时的重试策略：网络中断、超时与MinIO服务端错误可重试，
+// 对象不存在、Excel或CSV内容错误重试也无法成功，不再重试
+func contentFailure(err error) error {
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || minio.IsServerError(err) {
+		return err
+	}
+	return skipRetry(err)
+}
+
+// markFailed 查询任务记录并以失败状态更新（只写状态与备注列），同时记录审计日志
+func (h *TaskHandler) markFailed(
+	ctx context.Context,
+	dictTaskID, remark string,
+	cause error,
+	auditAction string,
+	update func(t *model.DictionaryTask, status string, remark ...string),
+	save func(ctx context.Context, t *model.DictionaryTask) error,
+) {
+	h.logger.ErrorContext(ctx, remark, slog.Any("error", cause))
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statusUpdateTimeout)
+	defer cancel()
+
+	dictTask, err := h.dictRepo.GetByID(ctx, dictTaskID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "查询任务记录失败，无法标记失败状态", slog.Any("error", err))
+		return
+	}
+	update(dictTask, model.TaskStatusFailed, remark+": "+cause.Error())
+	if err := save(ctx, dictTask); err != nil {
+		h.logger.ErrorContext(ctx, "更新任务失败状态失败", slog.Any("error", err))
+	}
+	h.auditSvc.RecordAs(ctx, taskActor(dictTask, auditAction), auditAction, dictTaskID, "", map[string]string{
+		"remark": remark,
+		"error":  cause.Error(),
+	})
+}
+
+// taskActor 异步任务审计日志的操作人（解析归属上传人，入库归属审批人）
+func taskActor(dictTask *model.DictionaryTask, auditAction string) string {
+	switch auditAction {
+	case model.AuditActionInsertSucceeded, model.AuditActionInsertFailed:
+		return dictTask.Confirmer
+	default:
+		return dictTask.Uploader
+	}
+}
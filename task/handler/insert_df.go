@@ -2,22 +2,20 @@ package handler
 
 import (
 	"context"
-	"customs/infrastructure/minio"
+	"customs/common"
+	"customs/infrastructure/metrics"
 	"customs/model"
-	"customs/repository"
 	"customs/task/payload"
+	"errors"
 	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
 	"time"
 )
 
-// InsertDFHandler 数据入库任务的消费逻辑
-func InsertDFHandler(
-	ctx context.Context,
-	task *asynq.Task,
-	minioClient *minio.Client,
-	dictRepo *repository.DictionaryRepository,
-	dbResRepo *repository.DBResourceRepository,
-) error {
+// InsertDF 数据入库任务的消费逻辑
+func (h *TaskHandler) InsertDF(ctx context.Context, task *asynq.Task) error {
 	ctx, cancel := context.WithTimeout(ctx, 590*time.Second)
 	defer cancel()
 	// 1. 解析任务参数
@@ -26,30 +24,164 @@ func InsertDFHandler(
 		return err
 	}
 
-	// 2. 从MinIO下载CSV文件
-	_, err = minioClient.DownloadFile("csv-bucket", p.DBResourceCSVName)
+	// 2. 从MinIO下载CSV文件并解析
+	dbCSV, err := h.minioClient.DownloadFile(ctx, h.cfg.Minio.CSVBucket, p.DBResourceCSVName)
+	if err != nil {
+		return h.failInsertDF(ctx, p.TaskID, "下载DB CSV失败", err)
+	}
+	tables, err := decodeCSV(dbCSV, tableColumns)
+	if err != nil {
+		return contentFailure(h.failInsertDF(ctx, p.TaskID, "解析DB CSV失败", err))
+	}
+	dictCSV, err := h.minioClient.DownloadFile(ctx, h.cfg.Minio.CSVBucket, p.DataDictionaryCSVName)
+	if err != nil {
+		return h.failInsertDF(ctx, p.TaskID, "下载Dict CSV失败", err)
+	}
+	records, err := decodeCSV(dictCSV, model.StdColumns)
+	if err != nil {
+		return contentFailure(h.failInsertDF(ctx, p.TaskID, "解析Dict CSV失败", err))
+	}
+
+	// 3. 登记数据库资源（创建人为Excel上传人），并刷新关联表名
+	dictTask, err := h.dictRepo.GetByID(ctx, p.TaskID)
 	if err != nil {
-		dictTask, _ := dictRepo.GetByID(ctx, p.TaskID)
-		dictTask.UpdateInsertDFStatus(model.TaskStatusFailed, "下载DB CSV失败: "+err.Error())
-		dictRepo.Update(ctx, dictTask)
 		return err
 	}
-	_, err = minioClient.DownloadFile("csv-bucket", p.DataDictionaryCSVName)
+	resource, err := h.registerDBResource(ctx, dictTask)
 	if err != nil {
-		dictTask, _ := dictRepo.GetByID(ctx, p.TaskID)
-		dictTask.UpdateInsertDFStatus(model.TaskStatusFailed, "下载Dict CSV失败: "+err.Error())
-		dictRepo.Update(ctx, dictTask)
+		return h.failInsertDF(ctx, p.TaskID, "登记数据库资源失败", err)
+	}
+	systemName, dbName, _ := common.SplitExcelName(dictTask.ExcelName)
+	tableNames := make([]string, 0, len(tables))
+	for _, t := range tables {
+		tableNames = append(tableNames, t[0])
+	}
+	resource.SystemName = systemName
+	resource.TableNames = strings.Join(tableNames, ",")
+	if err := h.dbResRepo.Update(ctx, resource); err != nil {
+		return h.failInsertDF(ctx, p.TaskID, "更新数据库资源失败", err)
+	}
+
+	// 4. 字段与关联关系入库：在同一事务中整体替换该资源备注+库名下的旧字段（全文索引随之同步）及该资源声明的旧关联关系
+	keys, err := h.loadKeys(ctx, dictTask)
+	if err != nil {
+		return contentFailure(h.failInsertDF(ctx, p.TaskID, "读取主键与关联关系失败", err))
+	}
+	primaryKeys := make(map[[2]string]bool, len(keys))
+	relations := make([]*model.TableRelation, 0, len(keys))
+	for _, k := range keys {
+		// 列顺序与keyColumns一致
+		if k[2] == model.PrimaryKeyYes {
+			primaryKeys[[2]string{k[0], k[1]}] = true
+		}
+		if k[4] == "" {
+			continue
+		}
+		refSystemName, refDBName := splitRefDB(k[3], systemName, dbName)
+		relations = append(relations, model.NewTableRelation(resource.ID, dictTask.ID, systemName, dbName,
+			k[0], k[1], refSystemName, refDBName, k[4], k[5]))
+	}
+	fields := make([]*model.DictionaryField, 0, len(records))
+	for i, r := range records {
+		// 列顺序与model.StdColumns一致
+		field := model.NewDictionaryField(resource.ID, dictTask.ID, dictTask.ResourceComment,
+			systemName, dbName, r[0], r[1], r[2], r[3], r[4])
+		field.PrimaryKey = primaryKeys[[2]string{r[0], r[2]}]
+		field.RowNo = i + 1
+		fields = append(fields, field)
+	}
+	if err := h.fieldRepo.ReplaceScope(ctx, dictTask.ResourceComment, dbName, fields, resource.ID, relations); err != nil {
+		return h.failInsertDF(ctx, p.TaskID, "数据字典入库失败", err)
+	}
+	metrics.RowsInsertedTotal.Add(float64(len(fields)))
+	h.logger.InfoContext(ctx, "数据字典入库完成",
+		slog.Int("rows", len(fields)), slog.Int("tables", len(tables)), slog.Int("relations", len(relations)))
+
+	// 5. 按解析时的匹配结果替换业务术语的自动关联（手动关联保留；关联由字典派生，失败不影响入库结果）
+	if err := h.linkTerms(ctx, dictTask, resource.ID); err != nil {
+		h.logger.WarnContext(ctx, "关联业务术语失败", slog.String("db_resource_id", resource.ID), slog.Any("error", err))
+	}
+
+	// 6. 更新任务状态为成功
+	dictTask.UpdateInsertDFStatus(model.TaskStatusSucceeded)
+	if err := h.dictRepo.UpdateInsertDFStatus(ctx, dictTask); err != nil {
 		return err
 	}
+	h.auditSvc.RecordAs(ctx, taskActor(dictTask, model.AuditActionInsertSucceeded), model.AuditActionInsertSucceeded,
+		dictTask.ID, resource.ID, map[string]interface{}{
+			"rows":      len(fields),
+			"tables":    len(tables),
+			"relations": len(relations),
+			"db_name":   resource.DBName,
+		})
+
+	// 7. 重新生成文档（文档由字典派生，失败不影响入库结果，下载时会按需重新生成）
+	if err := h.exportSvc.PublishDocs(ctx, resource.ID); err != nil {
+		h.logger.WarnContext(ctx, "生成数据字典文档失败", slog.String("db_resource_id", resource.ID), slog.Any("error", err))
+	}
+	return nil
+}
 
-	// 3. 解析CSV并入库（核心业务逻辑，替换为实际入库代码）
-	// ......CSV解析+数据库入库逻辑......
+// splitRefDB 拆分关联库列（"库名"或"系统名-库名"），返回被引用的系统名与库名
+// 关联库为空表示与本字段同库（系统名取本字段所在系统）；只写库名时系统名为空，查询入向关系时按库名匹配
+func splitRefDB(refDB, systemName, dbName string) (string, string) {
+	if refDB == "" {
+		return systemName, dbName
+	}
+	if system, db, ok := strings.Cut(refDB, "-"); ok {
+		return strings.TrimSpace(system), strings.TrimSpace(db)
+	}
+	return "", refDB
+}
 
-	// 4. 更新任务状态为成功
-	dictTask, err := dictRepo.GetByID(ctx, p.TaskID)
+// linkTerms 读取解析时的术语匹配结果并建立自动关联（启用业务术语之前解析的任务没有匹配结果）
+func (h *TaskHandler) linkTerms(ctx context.Context, dictTask *model.DictionaryTask, dbResourceID string) error {
+	if dictTask.TermsCSVName == "" {
+		return nil
+	}
+	termsCSV, err := h.minioClient.DownloadFile(ctx, h.cfg.Minio.CSVBucket, dictTask.TermsCSVName)
 	if err != nil {
 		return err
 	}
-	dictTask.UpdateInsertDFStatus(model.TaskStatusSucceeded)
-	return dictRepo.Update(ctx, dictTask)
+	rows, err := decodeCSV(termsCSV, termColumns)
+	if err != nil {
+		return err
+	}
+	links := make([]*model.TermLink, 0, len(rows))
+	for _, r := range rows {
+		links = append(links, model.NewTermLink(r[2], dbResourceID, r[0], r[1], model.TermLinkAuto, dictTask.Confirmer))
+	}
+	return h.glossarySvc.ReplaceAutoLinks(ctx, dbResourceID, links)
+}
+
+// loadKeys 读取解析时提取的主键与关联关系（支持可选列之前解析的任务没有该文件）
+func (h *TaskHandler) loadKeys(ctx context.Context, dictTask *model.DictionaryTask) ([][]string, error) {
+	if dictTask.KeysCSVName == "" {
+		return nil, nil
+	}
+	keysCSV, err := h.minioClient.DownloadFile(ctx, h.cfg.Minio.CSVBucket, dictTask.KeysCSVName)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCSV(keysCSV, keyColumns)
+}
+
+// registerDBResource 登记数据库资源（同一资源备注下的同名库只登记一次）
+func (h *TaskHandler) registerDBResource(ctx context.Context, dictTask *model.DictionaryTask) (*model.DBResource, error) {
+	_, dbName, _ := common.SplitExcelName(dictTask.ExcelName)
+
+	resource, err := h.dbResRepo.GetByCommentAndDBName(ctx, dictTask.ResourceComment, dbName)
+	if err == nil {
+		return resource, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	resource = model.NewDBResource(dictTask.ResourceComment, "", dbName, "", dictTask.Uploader)
+	if err := h.dbResRepo.Create(ctx, resource); err != nil {
+		return nil, err
+	}
+	h.logger.InfoContext(ctx, "已登记数据库资源", slog.String("db_resource_id", resource.ID), slog.String("db_name", dbName))
+	return resource, nil
 }
@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"context"
+	"github.com/hibiken/asynq"
+	"log/slog"
+)
+
+// UploadCleanup 过期直传会话清理任务的消费逻辑（定时触发）
+func (h *TaskHandler) UploadCleanup(ctx context.Context, _ *asynq.Task) error {
+	n, err := h.cleanupSvc.CleanupExpired(ctx)
+	if n > 0 {
+		h.logger.InfoContext(ctx, "已清理过期上传会话", slog.Int("count", n))
+	}
+	return err
+}
@@ -1,12 +1,19 @@
 package task
 
 import (
+	"context"
 	"customs/model"
 	"errors"
+	"fmt"
 	"github.com/hibiken/asynq"
 	"strconv"
+	"time"
 )
 
+// inspectorIOTimeout Inspector访问Redis的连接与读写超时
+// asynq的Inspector不接受context，以此保证探活等调用在超时后返回，不会在Redis无响应时一直阻塞
+const inspectorIOTimeout = 3 * time.Second
+
 // Inspector 任务状态查询器
 type Inspector struct {
 	inspector *asynq.Inspector
@@ -16,9 +23,12 @@ type Inspector struct {
 func NewInspector(redisAddr, redisPassword string, redisDB int) *Inspector {
 	return &Inspector{
 		inspector: asynq.NewInspector(asynq.RedisClientOpt{
-			Addr:     redisAddr,
-			Password: redisPassword,
-			DB:       redisDB,
+			Addr:         redisAddr,
+			Password:     redisPassword,
+			DB:           redisDB,
+			DialTimeout:  inspectorIOTimeout,
+			ReadTimeout:  inspectorIOTimeout,
+			WriteTimeout: inspectorIOTimeout,
 		}),
 	}
 }
@@ -56,6 +66,100 @@ func (i *Inspector) GetTaskStatus(taskID string) (string, error) {
 	}
 }
 
+// CheckQueues 检查队列是否可访问（队列尚未产生过任务时视为正常；ctx结束后不再发起查询）
+func (i *Inspector) CheckQueues(ctx context.Context, queues ...string) error {
+	existing, err := i.existingQueues(queues)
+	if err != nil {
+		return err
+	}
+	for _, q := range existing {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := i.inspector.GetQueueInfo(q); err != nil {
+			return fmt.Errorf("队列%s不可访问：%w", q, err)
+		}
+	}
+	return nil
+}
+
+// QueueDepths 查询各队列各状态的任务数（queue -> state -> count）
+func (i *Inspector) QueueDepths(queues ...string) (map[string]map[string]int, error) {
+	existing, err := i.existingQueues(queues)
+	if err != nil {
+		return nil, err
+	}
+	depths := make(map[string]map[string]int, len(existing))
+	for _, q := range existing {
+		info, err := i.inspector.GetQueueInfo(q)
+		if err != nil {
+			return nil, err
+		}
+		depths[q] = map[string]int{
+			"pending":   info.Pending,
+			"active":    info.Active,
+			"scheduled": info.Scheduled,
+			"retry":     info.Retry,
+			"archived":  info.Archived,
+		}
+	}
+	return depths, nil
+}
+
+// existingQueues 过滤出Redis中已存在的队列（队列在首个任务入队时才会创建）
+func (i *Inspector) existingQueues(queues []string) ([]string, error) {
+	all, err := i.inspector.Queues()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{}, len(all))
+	for _, q := range all {
+		known[q] = struct{}{}
+	}
+	existing := make([]string, 0, len(queues))
+	for _, q := range queues {
+		if _, ok := known[q]; ok {
+			existing = append(existing, q)
+		}
+	}
+	return existing, nil
+}
+
+// ActiveServers 查询仍在上报心跳的Worker数量（心跳过期的Worker会被Asynq自动移除）
+func (i *Inspector) ActiveServers(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	servers, err := i.inspector.Servers()
+	if err != nil {
+		return 0, err
+	}
+	active := 0
+	for _, srv := range servers {
+		if srv.Status == "active" {
+			active++
+		}
+	}
+	return active, nil
+}
+
+// HasServer 查询指定主机与进程的Worker是否仍在上报心跳
+func (i *Inspector) HasServer(ctx context.Context, host string, pid int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	servers, err := i.inspector.Servers()
+	if err != nil {
+		return false, err
+	}
+	for _, srv := range servers {
+		if srv.Host == host && srv.PID == pid {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Close 关闭Inspector
 func (i *Inspector) Close() error {
 	return i.inspector.Close()
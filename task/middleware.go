@@ -0,0 +1,71 @@
+package task
+
+import (
+	"context"
+	"customs/common/logger"
+	"customs/infrastructure/metrics"
+	"customs/infrastructure/tracing"
+	"customs/task/payload"
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"time"
+)
+
+// MetricsMiddleware 记录每个任务的执行耗时与结果（按任务类型、队列）
+func MetricsMiddleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		start := time.Now()
+		queue, _ := asynq.GetQueueName(ctx)
+
+		err := next.ProcessTask(ctx, t)
+		metrics.ObserveTask(t.Type(), queue, start, err)
+		return err
+	})
+}
+
+// LoggingMiddleware 将Payload中的请求ID与DictionaryTask ID写入上下文，并记录任务开始/结束日志
+func LoggingMiddleware(log *slog.Logger) asynq.MiddlewareFunc {
+	return func(next asynq.Handler) asynq.Handler {
+		return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+			meta := payload.ParseMeta(t)
+			ctx = logger.WithRequestID(ctx, meta.RequestID)
+			ctx = logger.WithDictTaskID(ctx, meta.TaskID)
+
+			asynqTaskID, _ := asynq.GetTaskID(ctx)
+			retried, _ := asynq.GetRetryCount(ctx)
+			log := log.With(slog.String("task_type", t.Type()), slog.String(logger.KeyAsynqTask, asynqTaskID))
+			log.InfoContext(ctx, "任务开始执行", slog.Int("retried", retried))
+
+			start := time.Now()
+			err := next.ProcessTask(ctx, t)
+			if err != nil {
+				log.ErrorContext(ctx, "任务执行失败", slog.Int64("duration_ms", time.Since(start).Milliseconds()), slog.Any("error", err))
+				return err
+			}
+			log.InfoContext(ctx, "任务执行成功", slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+			return nil
+		})
+	}
+}
+
+// TracingMiddleware 从Payload恢复链路上下文，并为任务执行开启Consumer Span（需注册在日志中间件之前）
+func TracingMiddleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) (err error) {
+		meta := payload.ParseMeta(t)
+		queue, _ := asynq.GetQueueName(ctx)
+		asynqTaskID, _ := asynq.GetTaskID(ctx)
+
+		ctx = tracing.Extract(ctx, meta.TraceCarrier)
+		ctx, span := tracing.StartWithKind(ctx, "asynq.process "+t.Type(), trace.SpanKindConsumer,
+			attribute.String("messaging.system", "asynq"),
+			attribute.String("messaging.destination.name", queue),
+			attribute.String("messaging.message.id", asynqTaskID),
+			attribute.String(logger.KeyDictTask, meta.TaskID),
+		)
+		defer func() { tracing.End(span, err) }()
+
+		return next.ProcessTask(ctx, t)
+	})
+}
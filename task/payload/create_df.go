@@ -6,26 +6,38 @@ import (
 	"github.com/hibiken/asynq"
 )
 
+// TypeCreateDF 解析Excel任务类型，用于Worker识别处理器
+const TypeCreateDF = "task:create_df"
+
 // CreateDFPayload 解析Excel任务的参数
 type CreateDFPayload struct {
 	ResourceComment string `json:"resource_comment"` // 资源备注
-	ExcelName       string `json:"excel_name"`       // MinIO中的Excel文件名
-	TaskID          string `json:"task_id"`          // 关联的DictionaryTask ID
+	ExcelName       string `json:"excel_name"`       // 上传的Excel文件名
+	ObjectName      string `json:"object_name"`      // MinIO中的Excel对象名（字典任务ID/文件名）
+	Meta                   // 关联字段（DictionaryTask ID、请求ID、链路上下文）
 }
 
 // NewCreateDFTask 封装Payload为Asynq任务
-func NewCreateDFTask(rc, excelName, taskID string) (*asynq.Task, error) {
+func NewCreateDFTask(rc, excelName, objectName string, meta Meta) (*asynq.Task, error) {
 	p := CreateDFPayload{
 		ResourceComment: rc,
 		ExcelName:       excelName,
-		TaskID:          taskID,
+		ObjectName:      objectName,
+		Meta:            meta,
 	}
 	payloadBytes, err := json.Marshal(p)
 	if err != nil {
 		return nil, err
 	}
-	// 任务类型标记为"task:create_df"，用于Worker识别处理器
-	return asynq.NewTask("task:create_df", payloadBytes), nil
+	return asynq.NewTask(TypeCreateDF, payloadBytes), nil
+}
+
+// Object 返回Excel对象名（按任务ID分目录之前入队的任务以文件名作为对象名）
+func (p *CreateDFPayload) Object() string {
+	if p.ObjectName == "" {
+		return p.ExcelName
+	}
+	return p.ObjectName
 }
 
 // ParseCreateDFPayload 解析任务参数
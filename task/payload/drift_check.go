@@ -0,0 +1,42 @@
+package payload
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/hibiken/asynq"
+)
+
+// TypeDriftCheck 表结构差异检查任务类型
+const TypeDriftCheck = "task:drift_check"
+
+// DriftCheckPayload 表结构差异检查任务的参数
+type DriftCheckPayload struct {
+	DBResourceID string `json:"db_resource_id"` // 数据库资源ID（为空表示为所有已配置连接的资源分别创建检查任务）
+	Trigger      string `json:"trigger"`        // 触发方式（SCHEDULE/MANUAL）
+	Operator     string `json:"operator"`       // 手动触发人
+	Meta                // 关联字段（请求ID、链路上下文）
+}
+
+// NewDriftCheckTask 封装Payload为Asynq任务
+func NewDriftCheckTask(dbResourceID, trigger, operator string, meta Meta) (*asynq.Task, error) {
+	p := DriftCheckPayload{
+		DBResourceID: dbResourceID,
+		Trigger:      trigger,
+		Operator:     operator,
+		Meta:         meta,
+	}
+	payloadBytes, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TypeDriftCheck, payloadBytes), nil
+}
+
+// ParseDriftCheckPayload 解析任务参数
+func ParseDriftCheckPayload(task *asynq.Task) (*DriftCheckPayload, error) {
+	var p DriftCheckPayload
+	if err := json.Unmarshal(task.Payload(), &p); err != nil {
+		return nil, errors.New("解析drift_check任务参数失败: " + err.Error())
+	}
+	return &p, nil
+}
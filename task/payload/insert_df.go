@@ -6,25 +6,28 @@ import (
 	"github.com/hibiken/asynq"
 )
 
+// TypeInsertDF 数据入库任务类型
+const TypeInsertDF = "task:insert_df"
+
 // InsertDFPayload 数据入库任务的参数
 type InsertDFPayload struct {
 	DBResourceCSVName     string `json:"db_resource_csv_name"`     // 数据库资源CSV名
 	DataDictionaryCSVName string `json:"data_dictionary_csv_name"` // 数据字典CSV名
-	TaskID                string `json:"task_id"`                  // 关联的DictionaryTask ID
+	Meta                         // 关联字段（DictionaryTask ID、请求ID、链路上下文）
 }
 
 // NewInsertDFTask 封装Payload为Asynq任务
-func NewInsertDFTask(dbCSV, dictCSV, taskID string) (*asynq.Task, error) {
+func NewInsertDFTask(dbCSV, dictCSV string, meta Meta) (*asynq.Task, error) {
 	p := InsertDFPayload{
 		DBResourceCSVName:     dbCSV,
 		DataDictionaryCSVName: dictCSV,
-		TaskID:                taskID,
+		Meta:                  meta,
 	}
 	payloadBytes, err := json.Marshal(p)
 	if err != nil {
 		return nil, err
 	}
-	return asynq.NewTask("task:insert_df", payloadBytes), nil
+	return asynq.NewTask(TypeInsertDF, payloadBytes), nil
 }
 
 // ParseInsertDFPayload 解析任务参数
@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"encoding/json"
+	"github.com/hibiken/asynq"
+)
+
+// Meta 所有任务Payload共有的关联字段（内嵌到各Payload中，JSON平铺）
+type Meta struct {
+	TaskID       string            `json:"task_id"`                 // 关联的DictionaryTask ID
+	RequestID    string            `json:"request_id"`              // 发起任务的HTTP请求ID（日志关联）
+	TraceCarrier map[string]string `json:"trace_carrier,omitempty"` // W3C链路上下文（traceparent等）
+}
+
+// ParseMeta 从任意任务中解析关联字段（解析失败时返回空值）
+func ParseMeta(task *asynq.Task) Meta {
+	var m Meta
+	_ = json.Unmarshal(task.Payload(), &m)
+	return m
+}
@@ -0,0 +1,23 @@
+package payload
+
+import (
+	"encoding/json"
+	"github.com/hibiken/asynq"
+)
+
+// TypeUploadCleanup 清理过期直传会话任务类型
+const TypeUploadCleanup = "task:upload_cleanup"
+
+// UploadCleanupPayload 清理过期直传会话任务的参数
+type UploadCleanupPayload struct {
+	Meta // 关联字段（请求ID、链路上下文）
+}
+
+// NewUploadCleanupTask 封装Payload为Asynq任务
+func NewUploadCleanupTask(meta Meta) (*asynq.Task, error) {
+	payloadBytes, err := json.Marshal(UploadCleanupPayload{Meta: meta})
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TypeUploadCleanup, payloadBytes), nil
+}
@@ -0,0 +1,66 @@
+package task
+
+import (
+	"customs/model"
+	"customs/task/payload"
+	"github.com/hibiken/asynq"
+	"time"
+)
+
+// driftScheduleUnique 定时差异检查的去重时长（多个Worker副本同时调度时只入队一次）
+const driftScheduleUnique = 10 * time.Minute
+
+// uploadCleanupUnique 定时清理过期直传会话的去重时长
+const uploadCleanupUnique = 5 * time.Minute
+
+// Scheduler 周期任务调度器（随Worker启动，多副本部署时依赖任务去重避免重复执行）
+type Scheduler struct {
+	scheduler *asynq.Scheduler
+}
+
+// NewScheduler 初始化调度器
+func NewScheduler(redisAddr, redisPassword string, redisDB int, logger asynq.Logger) *Scheduler {
+	scheduler := asynq.NewScheduler(
+		asynq.RedisClientOpt{Addr: redisAddr, Password: redisPassword, DB: redisDB},
+		&asynq.SchedulerOpts{Logger: logger, Location: time.Local},
+	)
+	return &Scheduler{scheduler: scheduler}
+}
+
+// RegisterDriftCheck 按cron表达式定时检查所有已配置连接的数据库资源的表结构差异
+func (s *Scheduler) RegisterDriftCheck(cronspec string) error {
+	task, err := payload.NewDriftCheckTask("", model.DriftTriggerSchedule, "", payload.Meta{})
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Register(cronspec, task,
+		asynq.MaxRetry(2),
+		asynq.Queue(QueueDefault),
+		asynq.Unique(driftScheduleUnique),
+	)
+	return err
+}
+
+// RegisterUploadCleanup 按cron表达式定时清理过期的直传会话
+func (s *Scheduler) RegisterUploadCleanup(cronspec string) error {
+	task, err := payload.NewUploadCleanupTask(payload.Meta{})
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Register(cronspec, task,
+		asynq.MaxRetry(1),
+		asynq.Queue(QueueDefault),
+		asynq.Unique(uploadCleanupUnique),
+	)
+	return err
+}
+
+// Start 启动调度（非阻塞）
+func (s *Scheduler) Start() error {
+	return s.scheduler.Start()
+}
+
+// Shutdown 停止调度
+func (s *Scheduler) Shutdown() {
+	s.scheduler.Shutdown()
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// asynqLogger 将Asynq内部日志转接到slog（实现asynq.Logger）
+type asynqLogger struct {
+	log *slog.Logger
+}
+
+// newAsynqLogger 初始化Asynq日志适配器
+func newAsynqLogger(log *slog.Logger) *asynqLogger {
+	return &asynqLogger{log: log.With(slog.String("component", "asynq"))}
+}
+
+func (l *asynqLogger) Debug(args ...interface{}) { l.log.Debug(fmt.Sprint(args...)) }
+func (l *asynqLogger) Info(args ...interface{})  { l.log.Info(fmt.Sprint(args...)) }
+func (l *asynqLogger) Warn(args ...interface{})  { l.log.Warn(fmt.Sprint(args...)) }
+func (l *asynqLogger) Error(args ...interface{}) { l.log.Error(fmt.Sprint(args...)) }
+
+// Fatal 记录日志后退出（asynq.Logger约定）
+func (l *asynqLogger) Fatal(args ...interface{}) {
+	l.log.Error(fmt.Sprint(args...))
+	os.Exit(1)
+}
@@ -2,47 +2,168 @@ package main
 
 import (
 	"context"
+	"customs/api/handler"
+	"customs/common/logger"
+	"customs/config"
 	"customs/infrastructure/db"
+	"customs/infrastructure/metrics"
 	"customs/infrastructure/minio"
 	"customs/infrastructure/redis"
+	"customs/infrastructure/tracing"
 	"customs/repository"
-	"customs/task/handler"
+	"customs/service"
+	"customs/task"
+	taskhandler "customs/task/handler"
+	"customs/task/payload"
+	"github.com/gin-gonic/gin"
 	"github.com/hibiken/asynq"
-	"log"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"log/slog"
+	"os"
 )
 
 func main() {
+	cfg := config.Load()
+	log := logger.New(cfg.Env)
+	slog.SetDefault(log)
+
+	// 初始化链路追踪
+	shutdownTracing, err := tracing.Init(context.Background(), cfg, "customs-worker")
+	if err != nil {
+		fatal(log, "链路追踪初始化失败", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error("链路追踪关闭失败", slog.Any("error", err))
+		}
+	}()
+
 	// 初始化依赖
-	mysqlClient, _ := db.NewMySQLClient("root:123456@tcp(127.0.0.1:3306)/customs?parseTime=true&charset=utf8mb4")
-	minioClient, _ := minio.NewMinioClient("127.0.0.1:9000", "minioadmin", "minioadmin", false)
-	redisClient := redis.NewRedisClient("127.0.0.1:6379", "", 0)
+	mysqlClient, err := db.NewMySQLClient(cfg.MySQL.DSN)
+	if err != nil {
+		fatal(log, "MySQL初始化失败", err)
+	}
+	minioClient, err := minio.NewMinioClient(cfg.Minio.Endpoint, cfg.Minio.AccessKey, cfg.Minio.SecretKey, cfg.Minio.Secure)
+	if err != nil {
+		fatal(log, "MinIO初始化失败", err)
+	}
+	redisClient := redis.NewRedisClient(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
 	repoContainer := repository.NewRepositoryContainer(mysqlClient)
+	taskInspector := task.NewInspector(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
+	defer taskInspector.Close()
+
+	// 注册队列深度指标采集器
+	if err := metrics.RegisterQueueCollector(func() (map[string]map[string]int, error) {
+		return taskInspector.QueueDepths(task.Queues...)
+	}); err != nil {
+		fatal(log, "队列指标注册失败", err)
+	}
+	taskClient := task.NewClient(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB) // 差异检查按资源拆分任务
+	defer taskClient.Close()
 
 	// 初始化Asynq Worker
 	worker := asynq.NewServer(
-		asynq.RedisClientOpt{Addr: "127.0.0.1:6379", Password: "", DB: 0},
+		asynq.RedisClientOpt{Addr: cfg.Redis.Addr, Password: cfg.Redis.Password, DB: cfg.Redis.DB},
 		asynq.Config{
-			Concurrency: 5,
+			Concurrency: cfg.Worker.Concurrency,
 			Queues: map[string]int{
-				"excel":   10,
-				"db":      5,
-				"default": 3,
+				task.QueueExcel:   10,
+				task.QueueDB:      5,
+				task.QueueDefault: 3,
 			},
+			Logger: newAsynqLogger(log),
 		},
 	)
 
 	// 注册任务处理器
+	namingRules, err := service.LoadNamingRules(cfg.Naming.RulesFile)
+	if err != nil {
+		fatal(log, "命名规范规则加载失败", err)
+	}
+	auditSvc := service.NewAuditService(log, repoContainer.AuditLog)
+	taskHandler := taskhandler.NewTaskHandler(
+		cfg,
+		log,
+		minioClient,
+		redisClient,
+		repoContainer.Dictionary,
+		repoContainer.DBResource,
+		repoContainer.Field,
+		repoContainer.Mapping,
+		auditSvc,
+		service.NewExportService(cfg, log, minioClient, repoContainer.DBResource, repoContainer.Field, repoContainer.Relation, auditSvc),
+		service.NewDriftService(cfg, log, taskClient, repoContainer.DBResource, repoContainer.Field, repoContainer.Drift, auditSvc),
+		namingRules,
+		service.NewGlossaryService(log, repoContainer.Glossary, repoContainer.Field, repoContainer.DBResource, auditSvc),
+		service.NewUploadCleanupService(cfg, log, minioClient, repoContainer.Upload),
+	)
 	mux := asynq.NewServeMux()
-	mux.HandleFunc("task:create_df", func(ctx context.Context, t *asynq.Task) error {
-		return handler.CreateDFHandler(ctx, t, minioClient, redisClient, repoContainer.Dictionary, repoContainer.DBResource)
-	})
-	mux.HandleFunc("task:insert_df", func(ctx context.Context, t *asynq.Task) error {
-		return handler.InsertDFHandler(ctx, t, minioClient, repoContainer.Dictionary, repoContainer.DBResource)
-	})
+	mux.Use(task.TracingMiddleware)
+	mux.Use(task.LoggingMiddleware(log))
+	mux.Use(task.MetricsMiddleware)
+	mux.HandleFunc(payload.TypeCreateDF, taskHandler.CreateDF)
+	mux.HandleFunc(payload.TypeInsertDF, taskHandler.InsertDF)
+	mux.HandleFunc(payload.TypeDriftCheck, taskHandler.DriftCheck)
+	mux.HandleFunc(payload.TypeUploadCleanup, taskHandler.UploadCleanup)
+
+	// 启动周期任务调度（定时检查数据字典与数据库表结构的差异、清理过期的直传会话）
+	if cfg.Drift.Schedule != "" || cfg.Upload.SessionCleanupSchedule != "" {
+		scheduler := task.NewScheduler(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB, newAsynqLogger(log))
+		if cfg.Drift.Schedule != "" {
+			if err := scheduler.RegisterDriftCheck(cfg.Drift.Schedule); err != nil {
+				fatal(log, "注册表结构差异定时检查失败", err)
+			}
+			log.Info("表结构差异定时检查已启用", slog.String("schedule", cfg.Drift.Schedule))
+		}
+		if cfg.Upload.SessionCleanupSchedule != "" {
+			if err := scheduler.RegisterUploadCleanup(cfg.Upload.SessionCleanupSchedule); err != nil {
+				fatal(log, "注册过期上传会话定时清理失败", err)
+			}
+			log.Info("过期上传会话定时清理已启用", slog.String("schedule", cfg.Upload.SessionCleanupSchedule))
+		}
+		if err := scheduler.Start(); err != nil {
+			fatal(log, "周期任务调度启动失败", err)
+		}
+		defer scheduler.Shutdown()
+	}
+
+	// 启动健康检查与指标HTTP服务（供容器编排探活、Prometheus采集）
+	healthSvc := service.NewHealthService(
+		log,
+		cfg.Health.Timeout,
+		service.MySQLHealthCheck(mysqlClient),
+		service.RedisHealthCheck(redisClient),
+		service.MinioHealthCheck(minioClient, cfg.Minio.Buckets()...),
+		service.QueueHealthCheck(taskInspector, task.Queues...),
+		service.WorkerSelfHealthCheck(taskInspector),
+	)
+	go runHealthServer(cfg.Worker.HealthAddr, healthSvc, log)
 
 	// 启动Worker
-	log.Println("Worker启动成功，监听任务队列...")
+	log.Info("Worker启动成功，监听任务队列...")
 	if err := worker.Run(mux); err != nil {
-		log.Fatal("Worker启动失败:", err)
+		fatal(log, "Worker启动失败", err)
+	}
+}
+
+// runHealthServer 启动Worker的健康检查与指标HTTP服务
+func runHealthServer(addr string, healthSvc *service.HealthService, log *slog.Logger) {
+	healthHandler := handler.NewHealthHandler(healthSvc)
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.GET("/health/live", healthHandler.Liveness)
+	r.GET("/health/ready", healthHandler.Readiness)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	log.Info("Worker健康检查服务启动", slog.String("addr", addr))
+	if err := r.Run(addr); err != nil {
+		log.Error("Worker健康检查服务启动失败", slog.Any("error", err))
 	}
 }
+
+// fatal 记录错误日志并退出进程
+func fatal(log *slog.Logger, msg string, err error) {
+	log.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}